@@ -27,9 +27,17 @@ func main() {
 		commands.IndexCommand,
 		commands.ImportCommand,
 		commands.PurgeCommand,
+		commands.GeotagCommand,
+		commands.TimeshiftCommand,
+		commands.RenameCommand,
+		commands.MergeCommand,
+		commands.DuplicatesCommand,
+		commands.VerifyCommand,
+		commands.JobsCommand,
 		commands.CopyCommand,
 		commands.ConvertCommand,
 		commands.ResampleCommand,
+		commands.TranscodeCommand,
 		commands.MigrateCommand,
 		commands.ConfigCommand,
 		commands.VersionCommand,
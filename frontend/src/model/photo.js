@@ -74,6 +74,7 @@ export class Photo extends RestModel {
             FileRoot: "",
             FileName: "",
             Hash: "",
+            MotionHash: "",
             Width: "",
             Height: "",
             // Date fields.
@@ -159,6 +160,10 @@ export class Photo extends RestModel {
     }
 
     videoUrl() {
+        if (this.MotionHash) {
+            return "/api/v1/videos/" + this.MotionHash + "/" + TypeMP4;
+        }
+
         const file = this.videoFile();
 
         if (!file) {
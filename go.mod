@@ -15,6 +15,7 @@ require (
 	github.com/dsoprea/go-jpeg-image-structure v0.0.0-20200519062215-d6e72d1f73f3
 	github.com/dsoprea/go-png-image-structure v0.0.0-20200518003737-91ceb687d379
 	github.com/dustin/go-humanize v1.0.0
+	github.com/fsnotify/fsnotify v1.4.7
 	github.com/gin-gonic/gin v1.6.3
 	github.com/gogo/protobuf v1.2.0 // indirect
 	github.com/golang/geo v0.0.0-20200319012246-673a6f80352d
@@ -74,7 +75,7 @@ require (
 	github.com/urfave/cli v1.22.4
 	go.uber.org/atomic v1.4.0 // indirect
 	golang.org/x/crypto v0.0.0-20200510223506-06a226fb4e37
-	golang.org/x/image v0.0.0-20200430140353-33d19683fad8 // indirect
+	golang.org/x/image v0.0.0-20200430140353-33d19683fad8
 	golang.org/x/net v0.0.0-20200513185701-a91f0712d120
 	golang.org/x/sys v0.0.0-20200515095857-1151b9dac4a9 // indirect
 	golang.org/x/time v0.0.0-20200416051211-89c76fbcd5d1 // indirect
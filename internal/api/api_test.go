@@ -32,6 +32,15 @@ func PerformRequest(r http.Handler, method, path string) *httptest.ResponseRecor
 	return w
 }
 
+// Performs API GET request with a Range header.
+func PerformRangeRequest(r http.Handler, path, byteRange string) *httptest.ResponseRecorder {
+	req, _ := http.NewRequest("GET", path, nil)
+	req.Header.Set("Range", byteRange)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
 // Performs API request including request body as string.
 func PerformRequestWithBody(r http.Handler, method, path, body string) *httptest.ResponseRecorder {
 	reader := strings.NewReader(body)
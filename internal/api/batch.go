@@ -10,7 +10,9 @@ import (
 	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/event"
 	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/photoprism"
 	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/internal/workers"
 	"github.com/photoprism/photoprism/pkg/txt"
 
 	"github.com/gin-gonic/gin"
@@ -191,6 +193,76 @@ func BatchPhotosPrivate(router *gin.RouterGroup, conf *config.Config) {
 	})
 }
 
+// POST /api/v1/batch/photos/time
+func BatchPhotosTime(router *gin.RouterGroup, conf *config.Config) {
+	router.POST("/batch/photos/time", func(c *gin.Context) {
+		if Unauthorized(c, conf) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, ErrUnauthorized)
+			return
+		}
+
+		start := time.Now()
+
+		var f form.Timeshift
+
+		if err := c.BindJSON(&f); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		if f.Selection.Empty() {
+			log.Error("no items selected")
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst("no items selected")})
+			return
+		}
+
+		opt := photoprism.TimeshiftOptions{
+			Selection: f.Selection,
+			TimeZone:  f.TimeZone,
+			Yaml:      f.Yaml && !conf.ReadOnly(),
+		}
+
+		if f.Offset != "" {
+			offset, err := time.ParseDuration(f.Offset)
+
+			if err != nil {
+				c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+				return
+			}
+
+			opt.Offset = offset
+		}
+
+		if opt.Offset == 0 && opt.TimeZone == "" {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst("no offset or time zone")})
+			return
+		}
+
+		job, err := workers.EnqueueJob(entity.JobTimeshift, entity.JobPriorityHigh, opt)
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		// The time is changed in the background if the client disconnects.
+		if job, err = workers.WaitForJob(c.Request.Context(), conf, job.JobUID); err != nil {
+			log.Debugf("timeshift: %s", err)
+			return
+		}
+
+		switch job.JobStatus {
+		case entity.JobCanceled:
+			c.JSON(http.StatusOK, gin.H{"message": "time change canceled", "job": job})
+		case entity.JobDone:
+			elapsed := time.Since(start)
+			c.JSON(http.StatusOK, gin.H{"message": fmt.Sprintf("changed time of photos in %s", elapsed), "job": job})
+		default:
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(job.JobError), "job": job})
+		}
+	})
+}
+
 // POST /api/v1/batch/labels/delete
 func BatchLabelsDelete(router *gin.RouterGroup, conf *config.Config) {
 	router.POST("/batch/labels/delete", func(c *gin.Context) {
@@ -198,3 +198,34 @@ func TestBatchLabelsDelete(t *testing.T) {
 		assert.Equal(t, http.StatusBadRequest, r.Code)
 	})
 }
+
+func TestBatchPhotosTime(t *testing.T) {
+	t.Run("no items selected", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		BatchPhotosTime(router, conf)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/batch/photos/time", `{"photos": [], "offset": "1h"}`)
+		val := gjson.Get(r.Body.String(), "error")
+		assert.Equal(t, "No items selected", val.String())
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("invalid offset", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		BatchPhotosTime(router, conf)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/batch/photos/time", `{"photos": ["pt9jtdre2lvl0yh8"], "offset": "one hour"}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("no offset or time zone", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		BatchPhotosTime(router, conf)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/batch/photos/time", `{"photos": ["pt9jtdre2lvl0yh8"]}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("successful request", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		BatchPhotosTime(router, conf)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/batch/photos/time", `{"photos": ["pt9jtdre2lvl0yh8"], "timezone": "Europe/Berlin"}`)
+		val := gjson.Get(r.Body.String(), "job.Status")
+		assert.Equal(t, "done", val.String())
+		assert.Equal(t, http.StatusOK, r.Code)
+	})
+}
@@ -0,0 +1,67 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// POST /api/v1/cameras/:slug/merge
+//
+// Parameters:
+//   slug: string Camera slug the other cameras are merged into
+func MergeCameras(router *gin.RouterGroup, conf *config.Config) {
+	router.POST("/cameras/:slug/merge", func(c *gin.Context) {
+		if Unauthorized(c, conf) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, ErrUnauthorized)
+			return
+		}
+
+		if conf.ReadOnly() {
+			c.AbortWithStatusJSON(http.StatusForbidden, ErrReadOnly)
+			return
+		}
+
+		var f form.CameraMerge
+
+		if err := c.BindJSON(&f); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		if len(f.Cameras) == 0 {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "No cameras selected"})
+			return
+		}
+
+		m, err := query.CameraBySlug(c.Param("slug"))
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusNotFound, ErrCameraNotFound)
+			return
+		}
+
+		others, err := query.CamerasBySlug(f.Cameras)
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		if err := m.Merge(others); err != nil {
+			log.Errorf("camera: %s", err)
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		event.Success("cameras merged")
+		event.Publish("config.updated", event.Data(conf.ClientConfig()))
+
+		c.JSON(http.StatusOK, m)
+	})
+}
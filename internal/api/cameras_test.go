@@ -0,0 +1,32 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+)
+
+func TestMergeCameras(t *testing.T) {
+	t.Run("no cameras", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		MergeCameras(router, conf)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/cameras/nikon-d750/merge", `{"cameras": []}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("not found", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		MergeCameras(router, conf)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/cameras/xxx/merge", `{"cameras": ["nikon-corporation-nikon-d750"]}`)
+		assert.Equal(t, "Camera not found", gjson.Get(r.Body.String(), "error").String())
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+	t.Run("successful request", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		MergeCameras(router, conf)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/cameras/nikon-d750/merge", `{"cameras": ["nikon-corporation-nikon-d750"]}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "nikon-d750", gjson.Get(r.Body.String(), "Slug").String())
+	})
+}
@@ -0,0 +1,127 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// GET /api/v1/duplicates
+func GetDuplicates(router *gin.RouterGroup, conf *config.Config) {
+	router.GET("/duplicates", func(c *gin.Context) {
+		if Unauthorized(c, conf) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, ErrUnauthorized)
+			return
+		}
+
+		var f form.DuplicateSearch
+
+		err := c.MustBindWith(&f, binding.Form)
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		result, err := query.DuplicateGroups(f)
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		c.Header("X-Count", strconv.Itoa(len(result)))
+		c.Header("X-Limit", strconv.Itoa(f.Count))
+		c.Header("X-Offset", strconv.Itoa(f.Offset))
+
+		c.JSON(http.StatusOK, result)
+	})
+}
+
+// POST /api/v1/duplicates/:uid/resolve
+//
+// Parameters:
+//   uid: string Photo UID of the copy to keep
+func ResolveDuplicates(router *gin.RouterGroup, conf *config.Config) {
+	router.POST("/duplicates/:uid/resolve", func(c *gin.Context) {
+		if Unauthorized(c, conf) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, ErrUnauthorized)
+			return
+		}
+
+		if conf.ReadOnly() {
+			c.AbortWithStatusJSON(http.StatusForbidden, ErrReadOnly)
+			return
+		}
+
+		var f form.DuplicateResolve
+
+		if err := c.BindJSON(&f); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		if len(f.Photos) == 0 {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "No photos selected"})
+			return
+		}
+
+		if f.Action != entity.ResolveArchive && f.Action != entity.ResolveStack {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Action must be archive or stack"})
+			return
+		}
+
+		m, err := query.PhotoByUID(c.Param("uid"))
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusNotFound, ErrPhotoNotFound)
+			return
+		}
+
+		group, err := query.DuplicateGroup(m.PhotoUID, f.Distance)
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		for _, uid := range f.Photos {
+			if uid == m.PhotoUID || !group.Contains(uid) {
+				c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("%s is not a duplicate of %s", txt.Quote(uid), txt.Quote(m.PhotoUID))})
+				return
+			}
+		}
+
+		others, err := query.PhotoSelection(form.Selection{Photos: f.Photos})
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		if err := m.ResolveDuplicates(others, f.Action); err != nil {
+			log.Errorf("duplicates: %s", err)
+			c.AbortWithStatusJSON(http.StatusInternalServerError, ErrSaveFailed)
+			return
+		}
+
+		if err := entity.UpdatePhotoCounts(); err != nil {
+			log.Errorf("duplicates: %s", err)
+		}
+
+		event.Success("duplicates resolved")
+		event.Publish("config.updated", event.Data(conf.ClientConfig()))
+		event.EntitiesArchived("photos", f.Photos)
+
+		c.JSON(http.StatusOK, m)
+	})
+}
@@ -0,0 +1,61 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+)
+
+func TestGetDuplicates(t *testing.T) {
+	t.Run("successful request", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		GetDuplicates(router, conf)
+		r := PerformRequest(app, "GET", "/api/v1/duplicates?count=10")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.True(t, gjson.Get(r.Body.String(), "#").Int() >= 1)
+	})
+	t.Run("invalid request", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		GetDuplicates(router, conf)
+		r := PerformRequest(app, "GET", "/api/v1/duplicates?count=xxx")
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+}
+
+func TestResolveDuplicates(t *testing.T) {
+	t.Run("no photos", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		ResolveDuplicates(router, conf)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/duplicates/pt9jtdre2lvl0yh0/resolve", `{"photos": [], "action": "archive"}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("invalid action", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		ResolveDuplicates(router, conf)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/duplicates/pt9jtdre2lvl0yh0/resolve", `{"photos": ["pt9jtdre2lvl0yh9"], "action": "delete"}`)
+		assert.Equal(t, "Action must be archive or stack", gjson.Get(r.Body.String(), "error").String())
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("not found", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		ResolveDuplicates(router, conf)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/duplicates/xxx/resolve", `{"photos": ["pt9jtdre2lvl0yh9"], "action": "stack"}`)
+		assert.Equal(t, "Photo not found", gjson.Get(r.Body.String(), "error").String())
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+	t.Run("not a duplicate", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		ResolveDuplicates(router, conf)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/duplicates/pt9jtdre2lvl0yh0/resolve", `{"photos": ["pt9jtdre2lvl0yh8"], "action": "archive"}`)
+		assert.Equal(t, "\"pt9jtdre2lvl0yh8\" is not a duplicate of \"pt9jtdre2lvl0yh0\"", gjson.Get(r.Body.String(), "error").String())
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("same photo", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		ResolveDuplicates(router, conf)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/duplicates/pt9jtdre2lvl0yh0/resolve", `{"photos": ["pt9jtdre2lvl0yh0"], "action": "archive"}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+}
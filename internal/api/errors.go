@@ -17,9 +17,16 @@ var (
 	ErrAlbumNotFound    = gin.H{"code": http.StatusNotFound, "error": "Album not found"}
 	ErrPhotoNotFound    = gin.H{"code": http.StatusNotFound, "error": "Photo not found"}
 	ErrLabelNotFound    = gin.H{"code": http.StatusNotFound, "error": "Label not found"}
+	ErrPersonNotFound   = gin.H{"code": http.StatusNotFound, "error": "Person not found"}
+	ErrCameraNotFound   = gin.H{"code": http.StatusNotFound, "error": "Camera not found"}
+	ErrLensNotFound     = gin.H{"code": http.StatusNotFound, "error": "Lens not found"}
 	ErrFileNotFound     = gin.H{"code": http.StatusNotFound, "error": "File not found"}
+	ErrJobNotFound      = gin.H{"code": http.StatusNotFound, "error": "Job not found"}
+	ErrProgressNotFound = gin.H{"code": http.StatusNotFound, "error": "Progress not found"}
 	ErrUnexpectedError  = gin.H{"code": http.StatusInternalServerError, "error": "Unexpected error"}
 	ErrSaveFailed       = gin.H{"code": http.StatusInternalServerError, "error": "Changes could not be saved"}
 	ErrFormInvalid      = gin.H{"code": http.StatusBadRequest, "error": "Changes could not be saved"}
 	ErrFeatureDisabled  = gin.H{"code": http.StatusForbidden, "error": "Feature disabled"}
+	ErrBusy             = gin.H{"code": http.StatusTooManyRequests, "error": "Busy, please try again later"}
+	ErrTranscoding      = gin.H{"code": http.StatusServiceUnavailable, "error": "Video is being transcoded, please try again later"}
 )
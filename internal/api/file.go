@@ -1,13 +1,20 @@
 package api
 
 import (
+	"fmt"
 	"net/http"
+	"path/filepath"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
 	"github.com/photoprism/photoprism/internal/config"
 	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/photoprism"
 	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/internal/service"
 	"github.com/photoprism/photoprism/pkg/txt"
 )
 
@@ -33,6 +40,84 @@ func GetFile(router *gin.RouterGroup, conf *config.Config) {
 	})
 }
 
+// GET /api/v1/errors/files
+//
+// Parameters:
+//   count: int Maximum number of results
+//   offset: int Result offset
+func GetFileErrors(router *gin.RouterGroup, conf *config.Config) {
+	router.GET("/errors/files", func(c *gin.Context) {
+		if Unauthorized(c, conf) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, ErrUnauthorized)
+			return
+		}
+
+		var f form.FileErrorSearch
+
+		if err := c.MustBindWith(&f, binding.Form); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		files, err := query.FileErrors(f.Count, f.Offset)
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		c.Header("X-Count", strconv.Itoa(len(files)))
+		c.Header("X-Limit", strconv.Itoa(f.Count))
+		c.Header("X-Offset", strconv.Itoa(f.Offset))
+
+		c.JSON(http.StatusOK, files)
+	})
+}
+
+// POST /api/v1/files/:uid/check
+//
+// Parameters:
+//   uid: string File UID
+func CheckFile(router *gin.RouterGroup, conf *config.Config) {
+	router.POST("/files/:uid/check", func(c *gin.Context) {
+		if Unauthorized(c, conf) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, ErrUnauthorized)
+			return
+		}
+
+		m, err := query.FileByUID(c.Param("uid"))
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusNotFound, ErrFileNotFound)
+			return
+		}
+
+		mf, err := photoprism.NewMediaFile(filepath.Join(conf.OriginalsPath(), m.FileName))
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusNotFound, ErrFileNotFound)
+			return
+		}
+
+		if err := service.Index().Recheck(mf); err == photoprism.ErrRecheckBusy {
+			c.AbortWithStatusJSON(http.StatusTooManyRequests, ErrBusy)
+			return
+		} else if err != nil {
+			query.QuarantineFile(m.FileUID, txt.Clip(err.Error(), photoprism.IntegrityErrorLength))
+			event.Error(fmt.Sprintf("%s is still corrupt", txt.Quote(filepath.Base(m.FileName))))
+		} else {
+			event.Success(fmt.Sprintf("%s has been indexed again", txt.Quote(filepath.Base(m.FileName))))
+		}
+
+		if m, err = query.FileByUID(m.FileUID); err != nil {
+			c.AbortWithStatusJSON(http.StatusNotFound, ErrFileNotFound)
+			return
+		}
+
+		c.JSON(http.StatusOK, m)
+	})
+}
+
 // POST /api/v1/files/:uid/link
 //
 // Parameters:
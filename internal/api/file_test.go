@@ -28,6 +28,33 @@ func TestGetFile(t *testing.T) {
 	})
 }
 
+func TestGetFileErrors(t *testing.T) {
+	t.Run("successful request", func(t *testing.T) {
+		app, router, ctx := NewApiTest()
+		GetFileErrors(router, ctx)
+		r := PerformRequest(app, "GET", "/api/v1/errors/files?count=10")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.GreaterOrEqual(t, len(gjson.Get(r.Body.String(), "#.Error").Array()), 2)
+	})
+	t.Run("without count", func(t *testing.T) {
+		app, router, ctx := NewApiTest()
+		GetFileErrors(router, ctx)
+		r := PerformRequest(app, "GET", "/api/v1/errors/files")
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+}
+
+func TestCheckFile(t *testing.T) {
+	t.Run("file not found", func(t *testing.T) {
+		app, router, ctx := NewApiTest()
+		CheckFile(router, ctx)
+		r := PerformRequest(app, "POST", "/api/v1/files/xxx/check")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+		val := gjson.Get(r.Body.String(), "error")
+		assert.Equal(t, "File not found", val.String())
+	})
+}
+
 func TestLinkFile(t *testing.T) {
 	t.Run("successful request", func(t *testing.T) {
 		app, router, ctx := NewApiTest()
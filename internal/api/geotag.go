@@ -0,0 +1,158 @@
+package api
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/meta"
+	"github.com/photoprism/photoprism/internal/photoprism"
+	"github.com/photoprism/photoprism/internal/service"
+	"github.com/photoprism/photoprism/internal/workers"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// POST /api/v1/geotag
+//
+// Form:
+//   files: GPX or KML track files
+//   offset: string Added to the time a photo was taken, e.g. "-1h30m"
+//   maxgap: string Maximum time between a photo and the nearest track point, e.g. "5m"
+//   dry: bool Don't change anything
+func Geotag(router *gin.RouterGroup, conf *config.Config) {
+	router.POST("/geotag", func(c *gin.Context) {
+		if conf.ReadOnly() {
+			c.AbortWithStatusJSON(http.StatusForbidden, ErrReadOnly)
+			return
+		}
+
+		if Unauthorized(c, conf) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, ErrUnauthorized)
+			return
+		}
+
+		start := time.Now()
+
+		var f form.GeotagOptions
+
+		if err := c.ShouldBind(&f); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		opt := photoprism.GeotagOptions{Dry: f.Dry}
+
+		if f.Offset != "" {
+			d, err := time.ParseDuration(f.Offset)
+
+			if err != nil {
+				c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+				return
+			}
+
+			opt.Offset = d
+		}
+
+		if f.MaxGap != "" {
+			d, err := time.ParseDuration(f.MaxGap)
+
+			if err != nil {
+				c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+				return
+			}
+
+			opt.MaxGap = d
+		}
+
+		mf, err := c.MultipartForm()
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		if err := os.MkdirAll(conf.TempPath(), os.ModePerm); err != nil {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		dir, err := ioutil.TempDir(conf.TempPath(), "geotag")
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		defer os.RemoveAll(dir)
+
+		for _, file := range mf.File["files"] {
+			fileName := filepath.Join(dir, filepath.Base(file.Filename))
+
+			if !meta.IsTrack(fileName) {
+				c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("%s is not a gpx or kml file", txt.Quote(file.Filename))})
+				return
+			}
+
+			if err := c.SaveUploadedFile(file, fileName); err != nil {
+				c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+				return
+			}
+
+			opt.Tracks = append(opt.Tracks, fileName)
+		}
+
+		// Dry runs don't change anything, so they run right away and return the photos found.
+		if opt.Dry {
+			tagged, err := service.Geotag().Start(opt)
+
+			if err != nil {
+				c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+				return
+			}
+
+			elapsed := int(time.Since(start).Seconds())
+
+			c.JSON(http.StatusOK, gin.H{"message": fmt.Sprintf("%d photos would be tagged, found in %d s", len(tagged), elapsed), "photos": tagged})
+			return
+		}
+
+		job, err := workers.EnqueueJob(entity.JobGeotag, entity.JobPriorityHigh, opt)
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		jobUID := job.JobUID
+
+		// The track files are removed when the request is done, so the job is canceled if the client disconnects.
+		if job, err = workers.WaitForJob(c.Request.Context(), conf, jobUID); err != nil {
+			log.Debugf("geotag: %s", err)
+
+			if job := entity.FindJob(jobUID); job != nil {
+				if err := job.Cancel(); err != nil {
+					log.Warnf("geotag: %s", err)
+				}
+			}
+
+			return
+		}
+
+		switch job.JobStatus {
+		case entity.JobCanceled:
+			c.JSON(http.StatusOK, gin.H{"message": "geotagging canceled", "job": job})
+		case entity.JobDone:
+			elapsed := int(time.Since(start).Seconds())
+			c.JSON(http.StatusOK, gin.H{"message": fmt.Sprintf("geotagging completed in %d s", elapsed), "job": job})
+		default:
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(job.JobError), "job": job})
+		}
+	})
+}
@@ -0,0 +1,17 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGeotag(t *testing.T) {
+	t.Run("no multipart form", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		Geotag(router, conf)
+		r := PerformRequest(app, "POST", "/api/v1/geotag")
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+}
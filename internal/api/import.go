@@ -3,18 +3,17 @@ package api
 import (
 	"fmt"
 	"net/http"
-	"os"
 	"path/filepath"
 	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/photoprism/photoprism/internal/config"
-	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/form"
 	"github.com/photoprism/photoprism/internal/photoprism"
 	"github.com/photoprism/photoprism/internal/service"
-	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/internal/workers"
 	"github.com/photoprism/photoprism/pkg/txt"
 )
 
@@ -53,36 +52,58 @@ func StartImport(router *gin.RouterGroup, conf *config.Config) {
 
 		path = filepath.Clean(path)
 
-		imp := service.Import()
-
 		var opt photoprism.ImportOptions
 
+		if f.Dry {
+			if f.Move {
+				opt = photoprism.ImportOptionsMove(path)
+			} else {
+				opt = photoprism.ImportOptionsCopy(path)
+			}
+
+			plan, err := service.Import().Plan(opt)
+
+			if err != nil {
+				c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{"error": txt.UcFirst(err.Error())})
+				return
+			}
+
+			c.JSON(http.StatusOK, gin.H{
+				"message": fmt.Sprintf("%d new, %d duplicate, %d existing and %d ignored files, %d archives", plan.Count(photoprism.PlanNew), plan.Count(photoprism.PlanDuplicate), plan.Count(photoprism.PlanExists), plan.Count(photoprism.PlanIgnored), plan.Count(photoprism.PlanArchive)),
+				"plan":    plan,
+			})
+			return
+		}
+
 		if f.Move {
-			event.Info(fmt.Sprintf("moving files from %s", txt.Quote(filepath.Base(path))))
 			opt = photoprism.ImportOptionsMove(path)
 		} else {
-			event.Info(fmt.Sprintf("copying files from %s", txt.Quote(filepath.Base(path))))
 			opt = photoprism.ImportOptionsCopy(path)
 		}
 
-		imp.Start(opt)
+		// Imports run before indexing, so that uploads don't have to wait.
+		job, err := workers.EnqueueJob(entity.JobImport, entity.JobPriorityHigh, opt)
 
-		if subPath != "" && path != conf.ImportPath() && fs.IsEmpty(path) {
-			if err := os.Remove(path); err != nil {
-				log.Errorf("import: could not delete empty folder %s: %s", txt.Quote(path), err)
-			} else {
-				log.Infof("import: deleted empty folder %s", txt.Quote(path))
-			}
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": txt.UcFirst(err.Error())})
+			return
 		}
 
-		elapsed := int(time.Since(start).Seconds())
-
-		event.Success(fmt.Sprintf("import completed in %d s", elapsed))
-		event.Publish("import.completed", event.Data{"path": path, "seconds": elapsed})
-		event.Publish("index.completed", event.Data{"path": path, "seconds": elapsed})
-		event.Publish("config.updated", event.Data(conf.ClientConfig()))
+		// The import continues in the background if the client disconnects.
+		if job, err = workers.WaitForJob(c.Request.Context(), conf, job.JobUID); err != nil {
+			log.Debugf("import: %s", err)
+			return
+		}
 
-		c.JSON(http.StatusOK, gin.H{"message": fmt.Sprintf("import completed in %d s", elapsed)})
+		switch job.JobStatus {
+		case entity.JobCanceled:
+			c.JSON(http.StatusOK, gin.H{"message": "import canceled", "job": job})
+		case entity.JobDone:
+			elapsed := int(time.Since(start).Seconds())
+			c.JSON(http.StatusOK, gin.H{"message": fmt.Sprintf("import completed in %d s", elapsed), "job": job})
+		default:
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": txt.UcFirst(job.JobError), "job": job})
+		}
 	})
 }
 
@@ -94,9 +115,10 @@ func CancelImport(router *gin.RouterGroup, conf *config.Config) {
 			return
 		}
 
-		imp := service.Import()
-
-		imp.Cancel()
+		// Work that was not queued, e.g. started by the file watcher, is canceled directly.
+		if workers.CancelJobs(entity.JobImport) == 0 {
+			service.Import().Cancel()
+		}
 
 		c.JSON(http.StatusOK, gin.H{"message": "import canceled"})
 	})
@@ -4,17 +4,23 @@ import (
 	"fmt"
 	"net/http"
 	"path/filepath"
+	"sync"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/photoprism/photoprism/internal/config"
-	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/form"
 	"github.com/photoprism/photoprism/internal/photoprism"
-	"github.com/photoprism/photoprism/internal/service"
+	"github.com/photoprism/photoprism/internal/workers"
 	"github.com/photoprism/photoprism/pkg/txt"
 )
 
+// indexJobs contains the UIDs of index jobs started with the API, so that jobs queued otherwise,
+// e.g. by the file watcher, are not canceled along with them.
+var indexJobs = make(map[string]bool)
+var indexJobsMutex = sync.Mutex{}
+
 // POST /api/v1/index
 func StartIndexing(router *gin.RouterGroup, conf *config.Config) {
 	router.POST("/index", func(c *gin.Context) {
@@ -32,45 +38,45 @@ func StartIndexing(router *gin.RouterGroup, conf *config.Config) {
 			return
 		}
 
-		path := conf.OriginalsPath()
-
-		ind := service.Index()
-
 		indOpt := photoprism.IndexOptions{
 			Rescan:  f.Rescan,
 			Convert: f.Convert && !conf.ReadOnly(),
 			Path:    filepath.Clean(f.Path),
+			Resume:  f.Resume,
 		}
 
-		if len(indOpt.Path) > 1 {
-			event.Info(fmt.Sprintf("indexing files in %s", txt.Quote(indOpt.Path)))
-		} else {
-			event.Info("indexing originals...")
-		}
-
-		indexed := ind.Start(indOpt)
-
-		prg := service.Purge()
-
-		prgOpt := photoprism.PurgeOptions{
-			Path:   filepath.Clean(f.Path),
-			Ignore: indexed,
-		}
+		job, err := workers.EnqueueJob(entity.JobIndex, entity.JobPriorityDefault, indOpt)
 
-		if files, photos, err := prg.Start(prgOpt); err != nil {
+		if err != nil {
 			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": txt.UcFirst(err.Error())})
 			return
-		} else if len(files) > 0 || len(photos) > 0 {
-			event.Info(fmt.Sprintf("removed %d files and %d photos", len(files), len(photos)))
 		}
 
-		elapsed := int(time.Since(start).Seconds())
+		jobUID := job.JobUID
+
+		indexJobsMutex.Lock()
+		indexJobs[jobUID] = true
+		indexJobsMutex.Unlock()
 
-		event.Success(fmt.Sprintf("indexing completed in %d s", elapsed))
-		event.Publish("index.completed", event.Data{"path": path, "seconds": elapsed})
-		event.Publish("config.updated", event.Data(conf.ClientConfig()))
+		// Indexing continues in the background if the client disconnects.
+		if job, err = workers.WaitForJob(c.Request.Context(), conf, jobUID); err != nil {
+			log.Debugf("index: %s", err)
+			return
+		}
 
-		c.JSON(http.StatusOK, gin.H{"message": fmt.Sprintf("indexing completed in %d s", elapsed)})
+		indexJobsMutex.Lock()
+		delete(indexJobs, jobUID)
+		indexJobsMutex.Unlock()
+
+		switch job.JobStatus {
+		case entity.JobCanceled:
+			c.JSON(http.StatusOK, gin.H{"message": "indexing canceled", "job": job})
+		case entity.JobDone:
+			elapsed := int(time.Since(start).Seconds())
+			c.JSON(http.StatusOK, gin.H{"message": fmt.Sprintf("indexing completed in %d s", elapsed), "job": job})
+		default:
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": txt.UcFirst(job.JobError), "job": job})
+		}
 	})
 }
 
@@ -82,9 +88,18 @@ func CancelIndexing(router *gin.RouterGroup, conf *config.Config) {
 			return
 		}
 
-		ind := service.Index()
+		indexJobsMutex.Lock()
+		defer indexJobsMutex.Unlock()
+
+		for jobUID := range indexJobs {
+			if job := entity.FindJob(jobUID); job != nil && !job.Finished() {
+				if err := job.Cancel(); err != nil {
+					log.Warnf("index: %s", err)
+				}
+			}
 
-		ind.Cancel()
+			delete(indexJobs, jobUID)
+		}
 
 		c.JSON(http.StatusOK, gin.H{"message": "indexing canceled"})
 	})
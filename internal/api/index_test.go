@@ -1,10 +1,14 @@
 package api
 
 import (
-	"github.com/stretchr/testify/assert"
-	"github.com/tidwall/gjson"
 	"net/http"
 	"testing"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/photoprism"
+	"github.com/photoprism/photoprism/internal/workers"
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
 )
 
 func TestCancelIndex(t *testing.T) {
@@ -16,4 +20,25 @@ func TestCancelIndex(t *testing.T) {
 		assert.Equal(t, "indexing canceled", val.String())
 		assert.Equal(t, http.StatusOK, r.Code)
 	})
+	t.Run("queued by watcher", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		CancelIndexing(router, conf)
+
+		job, err := workers.EnqueueJob(entity.JobIndex, entity.JobPriorityLow, photoprism.IndexOptions{Path: "cancel-index-test"})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		defer job.Cancel()
+
+		r := PerformRequest(app, "DELETE", "/api/v1/index")
+		assert.Equal(t, http.StatusOK, r.Code)
+
+		if job = entity.FindJob(job.JobUID); job == nil {
+			t.Fatal("job should not be nil")
+		}
+
+		assert.Equal(t, entity.JobQueued, job.JobStatus)
+	})
 }
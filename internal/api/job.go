@@ -0,0 +1,93 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// GET /api/v1/jobs
+func GetJobs(router *gin.RouterGroup, conf *config.Config) {
+	router.GET("/jobs", func(c *gin.Context) {
+		if Unauthorized(c, conf) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, ErrUnauthorized)
+			return
+		}
+
+		var f form.JobSearch
+
+		if err := c.MustBindWith(&f, binding.Form); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		jobs, err := query.Jobs(f)
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		c.Header("X-Count", strconv.Itoa(len(jobs)))
+		c.Header("X-Limit", strconv.Itoa(f.Count))
+		c.Header("X-Offset", strconv.Itoa(f.Offset))
+
+		c.JSON(http.StatusOK, jobs)
+	})
+}
+
+// GET /api/v1/jobs/:uid
+//
+// Parameters:
+//   uid: string Job UID
+func GetJob(router *gin.RouterGroup, conf *config.Config) {
+	router.GET("/jobs/:uid", func(c *gin.Context) {
+		if Unauthorized(c, conf) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, ErrUnauthorized)
+			return
+		}
+
+		job := entity.FindJob(c.Param("uid"))
+
+		if job == nil {
+			c.AbortWithStatusJSON(http.StatusNotFound, ErrJobNotFound)
+			return
+		}
+
+		c.JSON(http.StatusOK, job)
+	})
+}
+
+// DELETE /api/v1/jobs/:uid
+//
+// Parameters:
+//   uid: string Job UID
+func CancelJob(router *gin.RouterGroup, conf *config.Config) {
+	router.DELETE("/jobs/:uid", func(c *gin.Context) {
+		if Unauthorized(c, conf) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, ErrUnauthorized)
+			return
+		}
+
+		job := entity.FindJob(c.Param("uid"))
+
+		if job == nil {
+			c.AbortWithStatusJSON(http.StatusNotFound, ErrJobNotFound)
+			return
+		}
+
+		if err := job.Cancel(); err != nil {
+			c.AbortWithStatusJSON(http.StatusConflict, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		c.JSON(http.StatusOK, job)
+	})
+}
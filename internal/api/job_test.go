@@ -0,0 +1,83 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+)
+
+func TestGetJobs(t *testing.T) {
+	t.Run("successful request", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		GetJobs(router, conf)
+		r := PerformRequest(app, "GET", "/api/v1/jobs?count=10&status=done")
+		count := gjson.Get(r.Body.String(), "#")
+		assert.LessOrEqual(t, int64(1), count.Int())
+		val := gjson.Get(r.Body.String(), "0.Status")
+		assert.Equal(t, "done", val.String())
+		assert.Equal(t, http.StatusOK, r.Code)
+	})
+	t.Run("invalid request", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		GetJobs(router, conf)
+		r := PerformRequest(app, "GET", "/api/v1/jobs?xxx=10")
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+}
+
+func TestGetJob(t *testing.T) {
+	t.Run("successful request", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		GetJob(router, conf)
+		r := PerformRequest(app, "GET", "/api/v1/jobs/qqcwk7ew9ss5xjb2")
+		val := gjson.Get(r.Body.String(), "Type")
+		assert.Equal(t, "index", val.String())
+		assert.Equal(t, http.StatusOK, r.Code)
+	})
+	t.Run("job not found", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		GetJob(router, conf)
+		r := PerformRequest(app, "GET", "/api/v1/jobs/qqcwk7ew9ss5xxxx")
+		val := gjson.Get(r.Body.String(), "error")
+		assert.Equal(t, "Job not found", val.String())
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
+
+func TestCancelJob(t *testing.T) {
+	t.Run("queued", func(t *testing.T) {
+		job, err := entity.NewJob(entity.JobResample, entity.JobPriorityLow, struct{ Force bool }{Force: true})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		job.RunAfter = job.RunAfter.AddDate(1, 0, 0)
+
+		if err := job.Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		app, router, conf := NewApiTest()
+		CancelJob(router, conf)
+		r := PerformRequest(app, "DELETE", "/api/v1/jobs/"+job.JobUID)
+		val := gjson.Get(r.Body.String(), "Status")
+		assert.Equal(t, "canceled", val.String())
+		assert.Equal(t, http.StatusOK, r.Code)
+	})
+	t.Run("already done", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		CancelJob(router, conf)
+		r := PerformRequest(app, "DELETE", "/api/v1/jobs/qqcwk7ew9ss5xjb2")
+		assert.Equal(t, http.StatusConflict, r.Code)
+	})
+	t.Run("job not found", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		CancelJob(router, conf)
+		r := PerformRequest(app, "DELETE", "/api/v1/jobs/qqcwk7ew9ss5xxxx")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
@@ -0,0 +1,67 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// POST /api/v1/lenses/:slug/merge
+//
+// Parameters:
+//   slug: string Lens slug the other lenses are merged into
+func MergeLenses(router *gin.RouterGroup, conf *config.Config) {
+	router.POST("/lenses/:slug/merge", func(c *gin.Context) {
+		if Unauthorized(c, conf) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, ErrUnauthorized)
+			return
+		}
+
+		if conf.ReadOnly() {
+			c.AbortWithStatusJSON(http.StatusForbidden, ErrReadOnly)
+			return
+		}
+
+		var f form.LensMerge
+
+		if err := c.BindJSON(&f); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		if len(f.Lenses) == 0 {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "No lenses selected"})
+			return
+		}
+
+		m, err := query.LensBySlug(c.Param("slug"))
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusNotFound, ErrLensNotFound)
+			return
+		}
+
+		others, err := query.LensesBySlug(f.Lenses)
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		if err := m.Merge(others); err != nil {
+			log.Errorf("lens: %s", err)
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		event.Success("lenses merged")
+		event.Publish("config.updated", event.Data(conf.ClientConfig()))
+
+		c.JSON(http.StatusOK, m)
+	})
+}
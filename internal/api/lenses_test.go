@@ -0,0 +1,38 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+)
+
+func TestMergeLenses(t *testing.T) {
+	t.Run("no lenses", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		MergeLenses(router, conf)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/lenses/ef24-105mm-f-4l-is-usm/merge", `{"lenses": []}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("not found", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		MergeLenses(router, conf)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/lenses/xxx/merge", `{"lenses": ["24-0-105-0-mm"]}`)
+		assert.Equal(t, "Lens not found", gjson.Get(r.Body.String(), "error").String())
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+	t.Run("unknown lens", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		MergeLenses(router, conf)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/lenses/ef24-105mm-f-4l-is-usm/merge", `{"lenses": ["zz"]}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("successful request", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		MergeLenses(router, conf)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/lenses/ef24-105mm-f-4l-is-usm/merge", `{"lenses": ["24-0-105-0-mm"]}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "ef24-105mm-f-4l-is-usm", gjson.Get(r.Body.String(), "Slug").String())
+	})
+}
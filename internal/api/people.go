@@ -0,0 +1,149 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// GET /api/v1/people
+func GetPeople(router *gin.RouterGroup, conf *config.Config) {
+	router.GET("/people", func(c *gin.Context) {
+		if Unauthorized(c, conf) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, ErrUnauthorized)
+			return
+		}
+
+		var f form.PersonSearch
+
+		err := c.MustBindWith(&f, binding.Form)
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		result, err := query.People(f)
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		c.Header("X-Limit", strconv.Itoa(f.Count))
+		c.Header("X-Offset", strconv.Itoa(f.Offset))
+
+		c.JSON(http.StatusOK, result)
+	})
+}
+
+// PUT /api/v1/people/:uid
+//
+// Parameters:
+//   uid: string Person UID
+func UpdatePerson(router *gin.RouterGroup, conf *config.Config) {
+	router.PUT("/people/:uid", func(c *gin.Context) {
+		if Unauthorized(c, conf) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, ErrUnauthorized)
+			return
+		}
+
+		if conf.ReadOnly() {
+			c.AbortWithStatusJSON(http.StatusForbidden, ErrReadOnly)
+			return
+		}
+
+		var f form.Person
+
+		if err := c.BindJSON(&f); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		m, err := query.PersonByUID(c.Param("uid"))
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusNotFound, ErrPersonNotFound)
+			return
+		}
+
+		if err := m.SetName(f.PersonName); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		m.PersonFavorite = f.PersonFavorite
+		m.PersonNotes = f.PersonNotes
+
+		if err := m.Save(); err != nil {
+			log.Errorf("person: %s", err)
+			c.AbortWithStatusJSON(http.StatusInternalServerError, ErrSaveFailed)
+			return
+		}
+
+		event.Success("person saved")
+
+		c.JSON(http.StatusOK, m)
+	})
+}
+
+// POST /api/v1/people/:uid/merge
+//
+// Parameters:
+//   uid: string Person UID the other people are merged into
+func MergePeople(router *gin.RouterGroup, conf *config.Config) {
+	router.POST("/people/:uid/merge", func(c *gin.Context) {
+		if Unauthorized(c, conf) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, ErrUnauthorized)
+			return
+		}
+
+		if conf.ReadOnly() {
+			c.AbortWithStatusJSON(http.StatusForbidden, ErrReadOnly)
+			return
+		}
+
+		var f form.PersonMerge
+
+		if err := c.BindJSON(&f); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		if len(f.People) == 0 {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "No people selected"})
+			return
+		}
+
+		m, err := query.PersonByUID(c.Param("uid"))
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusNotFound, ErrPersonNotFound)
+			return
+		}
+
+		others, err := query.PeopleByUID(f.People)
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		if err := m.Merge(others); err != nil {
+			log.Errorf("person: %s", err)
+			c.AbortWithStatusJSON(http.StatusInternalServerError, ErrSaveFailed)
+			return
+		}
+
+		event.Success("people merged")
+
+		c.JSON(http.StatusOK, m)
+	})
+}
@@ -0,0 +1,72 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+)
+
+func TestGetPeople(t *testing.T) {
+	t.Run("successful request", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		GetPeople(router, conf)
+		r := PerformRequest(app, "GET", "/api/v1/people?count=10")
+		count := gjson.Get(r.Body.String(), "#")
+		assert.LessOrEqual(t, int64(2), count.Int())
+		assert.Equal(t, http.StatusOK, r.Code)
+	})
+	t.Run("invalid request", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		GetPeople(router, conf)
+		r := PerformRequest(app, "GET", "/api/v1/people?xxx=10")
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+}
+
+func TestUpdatePerson(t *testing.T) {
+	t.Run("successful request", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		UpdatePerson(router, conf)
+		r := PerformRequestWithBody(app, "PUT", "/api/v1/people/jt9k3pw1wowuy3c3", `{"Name": "John Q. Doe", "Favorite": true}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "John Q. Doe", gjson.Get(r.Body.String(), "Name").String())
+		assert.Equal(t, "john-q-doe", gjson.Get(r.Body.String(), "Slug").String())
+		assert.True(t, gjson.Get(r.Body.String(), "Favorite").Bool())
+	})
+	t.Run("empty name", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		UpdatePerson(router, conf)
+		r := PerformRequestWithBody(app, "PUT", "/api/v1/people/jt9k3pw1wowuy3c3", `{"Name": ""}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("not found", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		UpdatePerson(router, conf)
+		r := PerformRequestWithBody(app, "PUT", "/api/v1/people/xxx", `{"Name": "Foo"}`)
+		assert.Equal(t, "Person not found", gjson.Get(r.Body.String(), "error").String())
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
+
+func TestMergePeople(t *testing.T) {
+	t.Run("no people", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		MergePeople(router, conf)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/people/jt9k3pw1wowuy3c2/merge", `{"people": []}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("not found", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		MergePeople(router, conf)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/people/xxx/merge", `{"people": ["jt9k3pw1wowuy3c4"]}`)
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+	t.Run("successful request", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		MergePeople(router, conf)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/people/jt9k3pw1wowuy3c3/merge", `{"people": ["jt9k3pw1wowuy3c4"]}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+	})
+}
@@ -0,0 +1,43 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/photoprism"
+)
+
+// GET /api/v1/progress
+func GetProgressList(router *gin.RouterGroup, conf *config.Config) {
+	router.GET("/progress", func(c *gin.Context) {
+		if Unauthorized(c, conf) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, ErrUnauthorized)
+			return
+		}
+
+		c.JSON(http.StatusOK, photoprism.ProgressList())
+	})
+}
+
+// GET /api/v1/progress/:name
+//
+// Parameters:
+//   name: string index, import or convert
+func GetProgress(router *gin.RouterGroup, conf *config.Config) {
+	router.GET("/progress/:name", func(c *gin.Context) {
+		if Unauthorized(c, conf) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, ErrUnauthorized)
+			return
+		}
+
+		progress := photoprism.GetProgress(c.Param("name"))
+
+		if progress == nil {
+			c.AbortWithStatusJSON(http.StatusNotFound, ErrProgressNotFound)
+			return
+		}
+
+		c.JSON(http.StatusOK, progress.Info())
+	})
+}
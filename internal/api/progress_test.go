@@ -0,0 +1,43 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/photoprism"
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+)
+
+func TestGetProgressList(t *testing.T) {
+	t.Run("successful request", func(t *testing.T) {
+		photoprism.NewProgress("api-list", 10).Finish()
+
+		app, router, conf := NewApiTest()
+		GetProgressList(router, conf)
+		r := PerformRequest(app, "GET", "/api/v1/progress")
+		assert.LessOrEqual(t, int64(1), gjson.Get(r.Body.String(), "#").Int())
+		assert.Equal(t, http.StatusOK, r.Code)
+	})
+}
+
+func TestGetProgress(t *testing.T) {
+	t.Run("successful request", func(t *testing.T) {
+		p := photoprism.NewProgress("api-test", 10)
+		p.Processed(5, 1024, "2020")
+
+		app, router, conf := NewApiTest()
+		GetProgress(router, conf)
+		r := PerformRequest(app, "GET", "/api/v1/progress/api-test")
+		assert.Equal(t, int64(10), gjson.Get(r.Body.String(), "total").Int())
+		assert.Equal(t, int64(5), gjson.Get(r.Body.String(), "processed").Int())
+		assert.Equal(t, "2020", gjson.Get(r.Body.String(), "directory").String())
+		assert.Equal(t, http.StatusOK, r.Code)
+	})
+	t.Run("not found", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		GetProgress(router, conf)
+		r := PerformRequest(app, "GET", "/api/v1/progress/xxx")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/photoprism/photoprism/internal/config"
 	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/photoprism"
 	"github.com/photoprism/photoprism/pkg/txt"
 )
 
@@ -32,12 +33,19 @@ func SaveSettings(router *gin.RouterGroup, conf *config.Config) {
 		}
 
 		s := conf.Settings()
+		template := s.Import.Template
 
 		if err := c.BindJSON(s); err != nil {
 			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
 			return
 		}
 
+		if _, err := photoprism.NewPathTemplate(s.Import.Template); err != nil {
+			s.Import.Template = template
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
 		if err := s.Save(conf.SettingsFile()); err != nil {
 			c.AbortWithStatusJSON(http.StatusInternalServerError, err)
 			return
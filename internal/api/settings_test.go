@@ -43,4 +43,11 @@ func TestSaveSettings(t *testing.T) {
 		r := PerformRequestWithBody(app, "POST", "/api/v1/settings", `{"language": 123}`)
 		assert.Equal(t, http.StatusBadRequest, r.Code)
 	})
+	t.Run("invalid import template", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		SaveSettings(router, conf)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/settings", `{"import": {"template": "/{year}/{foo}"}}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+		assert.Equal(t, "", conf.Settings().Import.Template)
+	})
 }
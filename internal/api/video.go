@@ -3,11 +3,16 @@ package api
 import (
 	"net/http"
 	"path"
+	"path/filepath"
 
 	"github.com/gin-gonic/gin"
 	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/photoprism"
 	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/internal/service"
 	"github.com/photoprism/photoprism/internal/video"
+	"github.com/photoprism/photoprism/internal/workers"
 	"github.com/photoprism/photoprism/pkg/fs"
 	"github.com/photoprism/photoprism/pkg/txt"
 )
@@ -66,12 +71,137 @@ func GetVideo(router *gin.RouterGroup, conf *config.Config) {
 			return
 		}
 
+		contentType := video.ContentType(fs.FileType(f.FileType))
+
 		if c.Query("download") != "" {
+			c.Header("Content-Type", contentType)
 			c.FileAttachment(fileName, f.ShareFileName())
-		} else {
-			c.File(fileName)
+			return
+		}
+
+		// Videos browsers can't play are transcoded in the background after the first request, unless
+		// already done after indexing. The original is served until then.
+		if conf.VideoTranscode() != "none" && !video.Playable(fs.FileType(f.FileType), f.FileCodec) {
+			if avcName, err := service.Transcode().AvcName(f.FileHash); err != nil {
+				log.Errorf("video: %s", err)
+			} else if fs.FileExists(avcName) {
+				fileName = avcName
+				contentType = video.ContentType(fs.TypeMP4)
+			} else {
+				queueTranscode(conf, f.FileHash)
+			}
 		}
 
+		// Range requests are handled by http.ServeFile, the content type is set so that it isn't guessed.
+		c.Header("Content-Type", contentType)
+		c.File(fileName)
+
 		return
 	})
 }
+
+// videoRetryAfter is the number of seconds after which players should request a playlist again while it is transcoded.
+const videoRetryAfter = "10"
+
+// GET /api/v1/videos/:hash/:type/:name
+//
+// Parameters:
+//   hash: string The photo or video file hash as returned by the search API
+//   type: string Stream type, currently only hls
+//   name: string Playlist or segment file name, starting with index.m3u8
+func GetVideoStream(router *gin.RouterGroup, conf *config.Config) {
+	router.GET("/videos/:hash/:type/:name", func(c *gin.Context) {
+		fileHash := c.Param("hash")
+		typeName := c.Param("type")
+		name := c.Param("name")
+
+		if typeName != "hls" {
+			c.AbortWithStatusJSON(http.StatusNotFound, ErrFileNotFound)
+			return
+		}
+
+		if !conf.VideoHls() {
+			c.AbortWithStatusJSON(http.StatusForbidden, ErrFeatureDisabled)
+			return
+		}
+
+		var contentType string
+
+		switch filepath.Ext(name) {
+		case ".m3u8":
+			contentType = video.ContentTypePlaylist
+		case ".ts":
+			contentType = video.ContentTypeSegment
+		default:
+			c.AbortWithStatusJSON(http.StatusNotFound, ErrFileNotFound)
+			return
+		}
+
+		f, err := query.FileByHash(fileHash)
+
+		if err == nil && !f.FileVideo {
+			f, err = query.VideoByPhotoUID(f.PhotoUID)
+		}
+
+		if err != nil {
+			log.Errorf("video: %s", err.Error())
+			c.AbortWithStatusJSON(http.StatusNotFound, ErrFileNotFound)
+			return
+		}
+
+		if f.FileError != "" {
+			log.Errorf("video: file error %s", f.FileError)
+			c.AbortWithStatusJSON(http.StatusNotFound, ErrFileNotFound)
+			return
+		}
+
+		if f.FileMissing || !fs.FileExists(path.Join(conf.OriginalsPath(), f.FileName)) {
+			log.Errorf("video: file %s is missing", txt.Quote(f.FileName))
+			c.AbortWithStatusJSON(http.StatusNotFound, ErrFileNotFound)
+
+			// Set missing flag so that the file doesn't show up in search results anymore.
+			if !f.FileMissing {
+				report("video", f.Update("FileMissing", true))
+			}
+
+			return
+		}
+
+		hlsPath, err := service.Transcode().HlsPath(f.FileHash)
+
+		if err != nil {
+			log.Errorf("video: %s", err)
+			c.AbortWithStatusJSON(http.StatusNotFound, ErrFileNotFound)
+			return
+		}
+
+		fileName := filepath.Join(hlsPath, filepath.Base(name))
+
+		if !fs.FileExists(fileName) {
+			// Renditions are created in the background when the master playlist is requested for the first time,
+			// players should request it again later.
+			if name == photoprism.HlsPlaylist {
+				queueTranscode(conf, f.FileHash)
+				c.Header("Retry-After", videoRetryAfter)
+				c.AbortWithStatusJSON(http.StatusServiceUnavailable, ErrTranscoding)
+				return
+			}
+
+			c.AbortWithStatusJSON(http.StatusNotFound, ErrFileNotFound)
+			return
+		}
+
+		c.Header("Content-Type", contentType)
+		c.File(fileName)
+	})
+}
+
+// queueTranscode transcodes the video with the given file hash in the background, so that requests don't block.
+func queueTranscode(conf *config.Config, fileHash string) {
+	if _, err := workers.EnqueueJob(entity.JobTranscode, entity.JobPriorityHigh, workers.TranscodeJobOptions{Hash: fileHash}); err != nil {
+		log.Errorf("video: %s", err)
+		return
+	}
+
+	workers.StartJobs(conf)
+}
@@ -1,9 +1,19 @@
 package api
 
 import (
-	"github.com/stretchr/testify/assert"
+	"io/ioutil"
 	"net/http"
+	"os"
+	"path/filepath"
 	"testing"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/photoprism"
+	"github.com/photoprism/photoprism/internal/service"
+	"github.com/photoprism/photoprism/internal/video"
+	"github.com/photoprism/photoprism/internal/workers"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestGetVideo(t *testing.T) {
@@ -31,4 +41,170 @@ func TestGetVideo(t *testing.T) {
 		r := PerformRequest(app, "GET", "/api/v1/videos/acad9168fa6acc5c5c2965ddf6ec465ca42fd832/mp4")
 		assert.Equal(t, http.StatusOK, r.Code)
 	})
+	t.Run("range original", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		GetVideo(router, conf)
+
+		f, cleanup := createTestVideo(t, conf, "b0c6f9ee9a3d4e3e8f4b6f7d2b8f0e4a1c2d3e41", "mp4", "avc1")
+		defer cleanup()
+
+		r := PerformRangeRequest(app, "/api/v1/videos/"+f.FileHash+"/mp4", "bytes=0-3")
+		assert.Equal(t, http.StatusPartialContent, r.Code)
+		assert.Equal(t, "bytes 0-3/16", r.Header().Get("Content-Range"))
+		assert.Equal(t, "video/mp4", r.Header().Get("Content-Type"))
+		assert.Equal(t, "orig", r.Body.String())
+	})
+	t.Run("range transcoded", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+
+		if conf.VideoTranscode() == "none" {
+			t.Skip("transcoding disabled, ffmpeg not installed")
+		}
+
+		GetVideo(router, conf)
+
+		f, cleanup := createTestVideo(t, conf, "b0c6f9ee9a3d4e3e8f4b6f7d2b8f0e4a1c2d3e42", "avi", "xvid")
+		defer cleanup()
+
+		avcName, err := service.Transcode().AvcName(f.FileHash)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if err := os.MkdirAll(filepath.Dir(avcName), 0755); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := ioutil.WriteFile(avcName, []byte("transcoded video"), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		defer os.Remove(avcName)
+
+		r := PerformRangeRequest(app, "/api/v1/videos/"+f.FileHash+"/mp4", "bytes=4-9")
+		assert.Equal(t, http.StatusPartialContent, r.Code)
+		assert.Equal(t, "bytes 4-9/16", r.Header().Get("Content-Range"))
+		assert.Equal(t, "video/mp4", r.Header().Get("Content-Type"))
+		assert.Equal(t, "scoded", r.Body.String())
+	})
+}
+
+func TestGetVideoStream(t *testing.T) {
+	t.Run("invalid type", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		GetVideoStream(router, conf)
+		r := PerformRequest(app, "GET", "/api/v1/videos/acad9168fa6acc5c5c2965ddf6ec465ca42fd831/dash/index.m3u8")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+	t.Run("hls disabled", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+
+		if conf.VideoHls() {
+			t.Skip("hls enabled")
+		}
+
+		GetVideoStream(router, conf)
+		r := PerformRequest(app, "GET", "/api/v1/videos/acad9168fa6acc5c5c2965ddf6ec465ca42fd831/hls/index.m3u8")
+		assert.Equal(t, http.StatusForbidden, r.Code)
+	})
+	t.Run("file with error", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+
+		if !conf.VideoHls() {
+			t.Skip("hls disabled, ffmpeg not installed")
+		}
+
+		GetVideoStream(router, conf)
+		r := PerformRequest(app, "GET", "/api/v1/videos/acad9168fa6acc5c5c2965ddf6ec465ca42fd832/hls/index.m3u8")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+	t.Run("playlist", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+
+		if !conf.VideoHls() {
+			t.Skip("hls disabled, ffmpeg not installed")
+		}
+
+		GetVideoStream(router, conf)
+
+		f, cleanup := createTestVideo(t, conf, "b0c6f9ee9a3d4e3e8f4b6f7d2b8f0e4a1c2d3e43", "mp4", "avc1")
+		defer cleanup()
+
+		hlsPath, err := service.Transcode().HlsPath(f.FileHash)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if err := os.MkdirAll(hlsPath, 0755); err != nil {
+			t.Fatal(err)
+		}
+
+		defer os.RemoveAll(hlsPath)
+
+		playlist := "#EXTM3U\n#EXT-X-VERSION:3\n#EXT-X-STREAM-INF:BANDWIDTH=1128000,RESOLUTION=640x360\n360p.m3u8\n"
+
+		if err := ioutil.WriteFile(filepath.Join(hlsPath, photoprism.HlsPlaylist), []byte(playlist), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		r := PerformRequest(app, "GET", "/api/v1/videos/"+f.FileHash+"/hls/index.m3u8")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, video.ContentTypePlaylist, r.Header().Get("Content-Type"))
+		assert.Equal(t, playlist, r.Body.String())
+
+		r = PerformRequest(app, "GET", "/api/v1/videos/"+f.FileHash+"/hls/360p_000.ts")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+	t.Run("playlist transcoding", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+
+		if !conf.VideoHls() {
+			t.Skip("hls disabled, ffmpeg not installed")
+		}
+
+		GetVideoStream(router, conf)
+
+		f, cleanup := createTestVideo(t, conf, "b0c6f9ee9a3d4e3e8f4b6f7d2b8f0e4a1c2d3e44", "mp4", "avc1")
+		defer cleanup()
+
+		defer workers.CancelJobs(entity.JobTranscode)
+
+		r := PerformRequest(app, "GET", "/api/v1/videos/"+f.FileHash+"/hls/index.m3u8")
+		assert.Equal(t, http.StatusServiceUnavailable, r.Code)
+		assert.Equal(t, videoRetryAfter, r.Header().Get("Retry-After"))
+	})
+}
+
+// createTestVideo adds a video file with 16 bytes of content to the originals folder and the index,
+// the returned function removes it again.
+func createTestVideo(t *testing.T, conf *config.Config, fileHash, fileType, codec string) (entity.File, func()) {
+	f := entity.File{
+		FileName:  "api-video-test/" + fileHash + "." + fileType,
+		FileRoot:  entity.RootOriginals,
+		FileHash:  fileHash,
+		FileType:  fileType,
+		FileCodec: codec,
+		FileVideo: true,
+	}
+
+	fileName := filepath.Join(conf.OriginalsPath(), f.FileName)
+
+	if err := os.MkdirAll(filepath.Dir(fileName), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := ioutil.WriteFile(fileName, []byte("original video 1"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := entity.Db().Create(&f).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	return f, func() {
+		entity.Db().Unscoped().Delete(&f)
+		os.RemoveAll(filepath.Dir(fileName))
+	}
 }
@@ -30,6 +30,30 @@ func LocationLabel(name string, uncertainty int, priority int) Label {
 	return label
 }
 
+// HierarchicalLabel returns a new label for a hierarchical keyword like "Animals|Birds|Owl".
+func HierarchicalLabel(keyword string, source string) Label {
+	var parts []string
+
+	for _, s := range strings.Split(keyword, "|") {
+		if s = strings.TrimSpace(s); s != "" {
+			parts = append(parts, s)
+		}
+	}
+
+	if len(parts) == 0 {
+		return Label{Source: source}
+	}
+
+	name := parts[len(parts)-1]
+	var categories []string
+
+	for i := len(parts) - 2; i >= 0; i-- {
+		categories = append(categories, parts[i])
+	}
+
+	return Label{Name: name, Source: source, Uncertainty: 0, Priority: 0, Categories: categories}
+}
+
 // Title returns a formatted label title as string.
 func (l Label) Title() string {
 	return txt.Title(txt.Clip(l.Name, txt.ClipDefault))
@@ -30,6 +30,27 @@ func TestLabel_NewLocationLabel(t *testing.T) {
 	})
 }
 
+func TestHierarchicalLabel(t *testing.T) {
+	t.Run("Animals|Birds|Owl", func(t *testing.T) {
+		label := HierarchicalLabel("Animals|Birds|Owl", SrcXmp)
+		assert.Equal(t, "Owl", label.Name)
+		assert.Equal(t, "xmp", label.Source)
+		assert.Equal(t, 0, label.Uncertainty)
+		assert.Equal(t, []string{"Birds", "Animals"}, label.Categories)
+	})
+
+	t.Run("single", func(t *testing.T) {
+		label := HierarchicalLabel(" Cat ", SrcXmp)
+		assert.Equal(t, "Cat", label.Name)
+		assert.Empty(t, label.Categories)
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		label := HierarchicalLabel("|", SrcXmp)
+		assert.Equal(t, "", label.Name)
+	})
+}
+
 func TestLabel_Title(t *testing.T) {
 	t.Run("locationtest123", func(t *testing.T) {
 		LocLabel := LocationLabel("locationtest123", 23, 1)
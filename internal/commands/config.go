@@ -52,6 +52,11 @@ func configAction(ctx *cli.Context) error {
 	// Background workers and logging
 	fmt.Printf("%-25s %d\n", "workers", conf.Workers())
 	fmt.Printf("%-25s %d\n", "wakeup-interval", conf.WakeupInterval()/time.Second)
+	fmt.Printf("%-25s %t\n", "watch", conf.Watch())
+	fmt.Printf("%-25s %d\n", "watch-delay", conf.WatchDelay()/time.Second)
+	fmt.Printf("%-25s %d\n", "verify-budget", conf.VerifyBudget()/(1024*1024))
+	fmt.Printf("%-25s %d\n", "verify-rate", conf.VerifyRate()/(1024*1024))
+	fmt.Printf("%-25s %d\n", "verify-age", conf.VerifyAge()/(24*time.Hour))
 	fmt.Printf("%-25s %s\n", "log-level", conf.LogLevel())
 
 	// Path and file names
@@ -90,8 +95,16 @@ func configAction(ctx *cli.Context) error {
 	// External binaries
 	fmt.Printf("%-25s %s\n", "sips-bin", conf.SipsBin())
 	fmt.Printf("%-25s %s\n", "darktable-bin", conf.DarktableBin())
+	fmt.Printf("%-25s %s\n", "rawtherapee-bin", conf.RawTherapeeBin())
+	fmt.Printf("%-25s %s\n", "imagemagick-bin", conf.ImageMagickBin())
 	fmt.Printf("%-25s %s\n", "heifconvert-bin", conf.HeifConvertBin())
 	fmt.Printf("%-25s %s\n", "ffmpeg-bin", conf.FFmpegBin())
+	fmt.Printf("%-25s %s\n", "ffprobe-bin", conf.FFprobeBin())
+	fmt.Printf("%-25s %s\n", "video-transcode", conf.VideoTranscode())
+	fmt.Printf("%-25s %d\n", "video-bitrate", conf.VideoBitrate())
+	fmt.Printf("%-25s %d\n", "video-size", conf.VideoSize())
+	fmt.Printf("%-25s %t\n", "video-hls", conf.VideoHls())
+	fmt.Printf("%-25s %s\n", "video-path", conf.VideoPath())
 	fmt.Printf("%-25s %s\n", "exiftool-bin", conf.ExifToolBin())
 	fmt.Printf("%-25s %t\n", "sidecar-json", conf.SidecarJson())
 	fmt.Printf("%-25s %t\n", "sidecar-yaml", conf.SidecarYaml())
@@ -4,7 +4,9 @@ import (
 	"time"
 
 	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/service"
+	"github.com/photoprism/photoprism/internal/workers"
 	"github.com/urfave/cli"
 )
 
@@ -12,6 +14,7 @@ import (
 var ConvertCommand = cli.Command{
 	Name:   "convert",
 	Usage:  "Converts originals in other formats to JPEG",
+	Flags:  []cli.Flag{queueFlag},
 	Action: convertAction,
 }
 
@@ -30,14 +33,22 @@ func convertAction(ctx *cli.Context) error {
 		return err
 	}
 
+	if ctx.Bool("queue") {
+		return queueJob(conf, entity.JobConvert, entity.JobPriorityLow, workers.ConvertJobOptions{Path: conf.OriginalsPath()})
+	}
+
 	log.Infof("converting RAW images in %s to JPEG", conf.OriginalsPath())
 
 	convert := service.Convert()
 
+	stopProgress := showProgress("convert")
+
 	if err := convert.Start(conf.OriginalsPath()); err != nil {
 		log.Error(err)
 	}
 
+	stopProgress()
+
 	elapsed := time.Since(start)
 
 	log.Infof("image conversion completed in %s", elapsed)
@@ -69,7 +69,9 @@ func copyAction(ctx *cli.Context) error {
 	imp := service.Import()
 	opt := photoprism.ImportOptionsCopy(sourcePath)
 
-	imp.Start(opt)
+	if _, err := imp.Start(opt); err != nil {
+		return err
+	}
 
 	elapsed := time.Since(start)
 
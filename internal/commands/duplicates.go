@@ -0,0 +1,124 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/internal/service"
+	"github.com/photoprism/photoprism/pkg/txt"
+	"github.com/urfave/cli"
+)
+
+// DuplicatesCommand is used to register the duplicates cli command
+var DuplicatesCommand = cli.Command{
+	Name:   "duplicates",
+	Usage:  "Lists photos that look the same and optionally keeps only the best copy",
+	Flags:  duplicatesFlags,
+	Action: duplicatesAction,
+}
+
+var duplicatesFlags = []cli.Flag{
+	cli.IntFlag{
+		Name:  "distance, d",
+		Usage: "maximum number of differing perceptual hash bits",
+		Value: query.DuplicateDistance,
+	},
+	cli.BoolFlag{
+		Name:  "archive",
+		Usage: "archive lower-quality copies",
+	},
+	cli.BoolFlag{
+		Name:  "stack",
+		Usage: "stack lower-quality copies on the best copy",
+	},
+}
+
+// duplicatesAction lists groups of near-duplicates and resolves them if requested
+func duplicatesAction(ctx *cli.Context) error {
+	action := ""
+
+	switch {
+	case ctx.Bool("archive") && ctx.Bool("stack"):
+		return errors.New("use either --archive or --stack")
+	case ctx.Bool("archive"):
+		action = entity.ResolveArchive
+	case ctx.Bool("stack"):
+		action = entity.ResolveStack
+	}
+
+	conf := config.NewConfig(ctx)
+	service.SetConfig(conf)
+
+	cctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	if err := conf.Init(cctx); err != nil {
+		return err
+	}
+
+	conf.InitDb()
+	defer conf.Shutdown()
+
+	if action != "" && conf.ReadOnly() {
+		return config.ErrReadOnly
+	}
+
+	groups, err := query.DuplicateGroups(form.DuplicateSearch{Distance: ctx.Int("distance")})
+
+	if err != nil {
+		return err
+	}
+
+	for _, group := range groups {
+		fmt.Printf("%s (keep)\n", txt.Quote(group[0].FileName))
+
+		for _, dupe := range group[1:] {
+			fmt.Printf("  %s distance %d\n", txt.Quote(dupe.FileName), dupe.Distance)
+		}
+
+		if action == "" {
+			continue
+		}
+
+		keep, err := query.PhotoByUID(group[0].PhotoUID)
+
+		if err != nil {
+			log.Errorf("duplicates: %s", err)
+			continue
+		}
+
+		var uids []string
+
+		for _, dupe := range group[1:] {
+			uids = append(uids, dupe.PhotoUID)
+		}
+
+		others, err := query.PhotoSelection(form.Selection{Photos: uids})
+
+		if err != nil {
+			log.Errorf("duplicates: %s", err)
+			continue
+		}
+
+		if err := keep.ResolveDuplicates(others, action); err != nil {
+			log.Errorf("duplicates: %s", err)
+		}
+	}
+
+	if action != "" && len(groups) > 0 {
+		if err := entity.UpdatePhotoCounts(); err != nil {
+			log.Errorf("duplicates: %s", err)
+		}
+
+		log.Infof("resolved %d groups of duplicates (%s)", len(groups), action)
+	} else {
+		log.Infof("found %d groups of duplicates", len(groups))
+	}
+
+	return nil
+}
@@ -0,0 +1,76 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/photoprism"
+	"github.com/photoprism/photoprism/internal/service"
+	"github.com/urfave/cli"
+)
+
+// GeotagCommand is used to register the geotag cli command
+var GeotagCommand = cli.Command{
+	Name:      "geotag",
+	Usage:     "Sets the location of photos without coordinates based on GPX or KML tracks",
+	ArgsUsage: "[track files...]",
+	Flags:     geotagFlags,
+	Action:    geotagAction,
+}
+
+var geotagFlags = []cli.Flag{
+	cli.DurationFlag{
+		Name:  "offset",
+		Usage: "added to the time a photo was taken to match the track time, e.g. -1h30s",
+	},
+	cli.DurationFlag{
+		Name:  "max-gap",
+		Usage: "maximum time between a photo and the nearest track point",
+		Value: photoprism.GeotagDefaultMaxGap,
+	},
+	cli.BoolFlag{
+		Name:  "dry",
+		Usage: "dry run, don't actually change anything",
+	},
+}
+
+// geotagAction sets the location of photos based on GPS tracks
+func geotagAction(ctx *cli.Context) error {
+	start := time.Now()
+
+	if !ctx.Args().Present() {
+		return errors.New("no track files specified")
+	}
+
+	conf := config.NewConfig(ctx)
+	service.SetConfig(conf)
+
+	cctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if err := conf.Init(cctx); err != nil {
+		return err
+	}
+
+	conf.InitDb()
+
+	opt := photoprism.GeotagOptions{
+		Tracks: ctx.Args(),
+		Offset: ctx.Duration("offset"),
+		MaxGap: ctx.Duration("max-gap"),
+		Dry:    ctx.Bool("dry"),
+	}
+
+	if tagged, err := service.Geotag().Start(opt); err != nil {
+		return err
+	} else {
+		elapsed := time.Since(start)
+
+		log.Infof("tagged %d photos in %s", len(tagged), elapsed)
+	}
+
+	conf.Shutdown()
+
+	return nil
+}
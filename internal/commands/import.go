@@ -8,8 +8,10 @@ import (
 	"time"
 
 	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/photoprism"
 	"github.com/photoprism/photoprism/internal/service"
+	"github.com/photoprism/photoprism/internal/workers"
 	"github.com/urfave/cli"
 )
 
@@ -18,9 +20,18 @@ var ImportCommand = cli.Command{
 	Name:    "import",
 	Aliases: []string{"mv"},
 	Usage:   "Moves files to originals folder, converts and indexes them as needed",
+	Flags:   importFlags,
 	Action:  importAction,
 }
 
+var importFlags = []cli.Flag{
+	cli.BoolFlag{
+		Name:  "dry",
+		Usage: "dry run, only show what would be imported",
+	},
+	queueFlag,
+}
+
 // importAction moves photos to originals path. Default import path is used if no path argument provided
 func importAction(ctx *cli.Context) error {
 	start := time.Now()
@@ -29,7 +40,7 @@ func importAction(ctx *cli.Context) error {
 	service.SetConfig(conf)
 
 	// very if copy directory exist and is writable
-	if conf.ReadOnly() {
+	if conf.ReadOnly() && !ctx.Bool("dry") {
 		return config.ErrReadOnly
 	}
 
@@ -69,7 +80,33 @@ func importAction(ctx *cli.Context) error {
 	imp := service.Import()
 	opt := photoprism.ImportOptionsMove(sourcePath)
 
-	imp.Start(opt)
+	if ctx.Bool("dry") {
+		plan, err := imp.Plan(opt)
+
+		if err != nil {
+			return err
+		}
+
+		log.Infof("dry run: %d new, %d duplicate, %d existing and %d ignored files, %d archives", plan.Count(photoprism.PlanNew), plan.Count(photoprism.PlanDuplicate), plan.Count(photoprism.PlanExists), plan.Count(photoprism.PlanIgnored), plan.Count(photoprism.PlanArchive))
+		conf.Shutdown()
+		return nil
+	}
+
+	if ctx.Bool("queue") {
+		_, err := workers.EnqueueJob(entity.JobImport, entity.JobPriorityHigh, opt)
+		conf.Shutdown()
+		return err
+	}
+
+	stopProgress := showProgress("import")
+
+	_, err := imp.Start(opt)
+
+	stopProgress()
+
+	if err != nil {
+		return err
+	}
 
 	elapsed := time.Since(start)
 
@@ -7,8 +7,10 @@ import (
 	"time"
 
 	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/photoprism"
 	"github.com/photoprism/photoprism/internal/service"
+	"github.com/photoprism/photoprism/internal/workers"
 	"github.com/photoprism/photoprism/pkg/txt"
 	"github.com/urfave/cli"
 )
@@ -26,6 +28,11 @@ var indexFlags = []cli.Flag{
 		Name:  "all, a",
 		Usage: "re-index all originals, including unchanged files",
 	},
+	cli.BoolFlag{
+		Name:  "resume, r",
+		Usage: "resume the last interrupted run with the same options",
+	},
+	queueFlag,
 }
 
 // indexAction indexes all photos in originals directory (photo library)
@@ -66,9 +73,24 @@ func indexAction(ctx *cli.Context) error {
 		Path:    subPath,
 		Rescan:  ctx.Bool("all"),
 		Convert: conf.Settings().Index.Convert && !conf.ReadOnly(),
+		Resume:  ctx.Bool("resume"),
+	}
+
+	if ctx.Bool("queue") {
+		_, err := workers.EnqueueJob(entity.JobIndex, entity.JobPriorityDefault, indOpt)
+		conf.Shutdown()
+		return err
 	}
 
-	indexed := ind.Start(indOpt)
+	stopProgress := showProgress("index")
+
+	indexed, err := ind.Start(indOpt)
+
+	stopProgress()
+
+	if err != nil {
+		return err
+	}
 
 	prg := service.Purge()
 
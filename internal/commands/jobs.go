@@ -0,0 +1,148 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/internal/service"
+	"github.com/photoprism/photoprism/internal/workers"
+	"github.com/photoprism/photoprism/pkg/txt"
+	"github.com/urfave/cli"
+)
+
+// JobsCommand is used to register the jobs cli command
+var JobsCommand = cli.Command{
+	Name:  "jobs",
+	Usage: "Lists and cancels queued background jobs",
+	Subcommands: []cli.Command{
+		{
+			Name:   "ls",
+			Usage:  "Lists recent jobs, most recent first",
+			Flags:  jobsListFlags,
+			Action: jobsListAction,
+		},
+		{
+			Name:      "cancel",
+			Usage:     "Cancels queued jobs and requests cancellation of running jobs",
+			ArgsUsage: "[job uids...]",
+			Action:    jobsCancelAction,
+		},
+	},
+}
+
+var jobsListFlags = []cli.Flag{
+	cli.StringFlag{
+		Name:  "status, s",
+		Usage: "only show jobs with this status, e.g. queued, running, failed, done or canceled",
+	},
+	cli.StringFlag{
+		Name:  "type, t",
+		Usage: "only show jobs of this type, e.g. index, import, purge, convert or resample",
+	},
+	cli.IntFlag{
+		Name:  "count, n",
+		Usage: "maximum number of jobs",
+		Value: 25,
+	},
+}
+
+// queueFlag is used by commands that can add their work to the job queue.
+var queueFlag = cli.BoolFlag{
+	Name:  "queue, q",
+	Usage: "add to the job queue of the running server instead of starting now",
+}
+
+// queueJob adds a job to the queue for commands that don't use the database otherwise.
+func queueJob(conf *config.Config, jobType string, priority int, options interface{}) error {
+	cctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	if err := conf.Init(cctx); err != nil {
+		return err
+	}
+
+	conf.InitDb()
+	defer conf.Shutdown()
+
+	_, err := workers.EnqueueJob(jobType, priority, options)
+
+	return err
+}
+
+// jobsListAction lists recent jobs
+func jobsListAction(ctx *cli.Context) error {
+	conf := config.NewConfig(ctx)
+	service.SetConfig(conf)
+
+	cctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	if err := conf.Init(cctx); err != nil {
+		return err
+	}
+
+	conf.InitDb()
+	defer conf.Shutdown()
+
+	jobs, err := query.Jobs(form.JobSearch{
+		Type:   ctx.String("type"),
+		Status: ctx.String("status"),
+		Count:  ctx.Int("count"),
+	})
+
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%-16s %-9s %-8s %8s %8s %-20s %s\n", "UID", "TYPE", "STATUS", "PRIORITY", "ATTEMPTS", "CREATED", "ERROR")
+
+	for _, job := range jobs {
+		fmt.Printf("%-16s %-9s %-8s %8d %8d %-20s %s\n", job.JobUID, job.JobType, job.JobStatus, job.JobPriority, job.JobAttempts, job.CreatedAt.Format("2006-01-02 15:04:05"), job.JobError)
+	}
+
+	return nil
+}
+
+// jobsCancelAction cancels jobs by uid
+func jobsCancelAction(ctx *cli.Context) error {
+	if ctx.NArg() < 1 {
+		return errors.New("at least one job uid required")
+	}
+
+	conf := config.NewConfig(ctx)
+	service.SetConfig(conf)
+
+	cctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	if err := conf.Init(cctx); err != nil {
+		return err
+	}
+
+	conf.InitDb()
+	defer conf.Shutdown()
+
+	for _, uid := range ctx.Args() {
+		job := entity.FindJob(uid)
+
+		if job == nil {
+			log.Errorf("job %s not found", txt.Quote(uid))
+			continue
+		}
+
+		if err := job.Cancel(); err != nil {
+			log.Error(err)
+		} else if job.JobStatus == entity.JobRunning {
+			log.Infof("requested cancellation of running %s job %s", job.JobType, job.JobUID)
+		} else {
+			log.Infof("canceled %s job %s", job.JobType, job.JobUID)
+		}
+	}
+
+	return nil
+}
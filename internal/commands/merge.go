@@ -0,0 +1,116 @@
+package commands
+
+import (
+	"context"
+	"errors"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/internal/service"
+	"github.com/photoprism/photoprism/pkg/txt"
+	"github.com/urfave/cli"
+)
+
+// MergeCommand is used to register the merge cli command
+var MergeCommand = cli.Command{
+	Name:  "merge",
+	Usage: "Merges cameras or lenses created from different metadata strings",
+	Subcommands: []cli.Command{
+		{
+			Name:      "cameras",
+			Usage:     "Moves photos of other cameras to the first camera and keeps their slugs as aliases",
+			ArgsUsage: "[camera slug] [other camera slugs...]",
+			Action:    mergeCamerasAction,
+		},
+		{
+			Name:      "lenses",
+			Usage:     "Moves photos of other lenses to the first lens and keeps their slugs as aliases",
+			ArgsUsage: "[lens slug] [other lens slugs...]",
+			Action:    mergeLensesAction,
+		},
+	},
+}
+
+// mergeCamerasAction merges cameras into the first camera
+func mergeCamerasAction(ctx *cli.Context) error {
+	if ctx.NArg() < 2 {
+		return errors.New("at least two camera slugs required")
+	}
+
+	conf := config.NewConfig(ctx)
+	service.SetConfig(conf)
+
+	cctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	if err := conf.Init(cctx); err != nil {
+		return err
+	}
+
+	conf.InitDb()
+	defer conf.Shutdown()
+
+	m, err := query.CameraBySlug(ctx.Args().First())
+
+	if err != nil {
+		return errors.New("camera " + txt.Quote(ctx.Args().First()) + " not found")
+	}
+
+	others, err := query.CamerasBySlug(ctx.Args().Tail())
+
+	if err != nil {
+		return err
+	} else if len(others) == 0 {
+		return errors.New("no cameras to merge found")
+	}
+
+	if err := m.Merge(others); err != nil {
+		return err
+	}
+
+	log.Infof("merged %d cameras into %s", len(others), txt.Quote(m.String()))
+
+	return nil
+}
+
+// mergeLensesAction merges lenses into the first lens
+func mergeLensesAction(ctx *cli.Context) error {
+	if ctx.NArg() < 2 {
+		return errors.New("at least two lens slugs required")
+	}
+
+	conf := config.NewConfig(ctx)
+	service.SetConfig(conf)
+
+	cctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	if err := conf.Init(cctx); err != nil {
+		return err
+	}
+
+	conf.InitDb()
+	defer conf.Shutdown()
+
+	m, err := query.LensBySlug(ctx.Args().First())
+
+	if err != nil {
+		return errors.New("lens " + txt.Quote(ctx.Args().First()) + " not found")
+	}
+
+	others, err := query.LensesBySlug(ctx.Args().Tail())
+
+	if err != nil {
+		return err
+	} else if len(others) == 0 {
+		return errors.New("no lenses to merge found")
+	}
+
+	if err := m.Merge(others); err != nil {
+		return err
+	}
+
+	log.Infof("merged %d lenses into %s", len(others), txt.Quote(m.LensModel))
+
+	return nil
+}
@@ -0,0 +1,80 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	"github.com/photoprism/photoprism/internal/photoprism"
+)
+
+// progressBarWidth is the number of characters used for the bar itself.
+const progressBarWidth = 30
+
+// showProgress prints the progress of the named run once per second until the returned function is called.
+func showProgress(name string) (stop func()) {
+	done := make(chan bool)
+	stopped := make(chan bool)
+
+	go func() {
+		ticker := time.NewTicker(time.Second)
+
+		defer func() {
+			ticker.Stop()
+			close(stopped)
+		}()
+
+		for {
+			select {
+			case <-done:
+				if p := photoprism.GetProgress(name); p != nil {
+					fmt.Fprintln(os.Stderr, progressBar(p.Info()))
+				}
+
+				return
+			case <-ticker.C:
+				if p := photoprism.GetProgress(name); p != nil && !p.Done() {
+					fmt.Fprintln(os.Stderr, progressBar(p.Info()))
+				}
+			}
+		}
+	}()
+
+	return func() {
+		close(done)
+		<-stopped
+	}
+}
+
+// progressBar returns the progress as a single line of text.
+func progressBar(info photoprism.ProgressInfo) string {
+	filled := info.Percent * progressBarWidth / 100
+	bar := strings.Repeat("=", filled)
+
+	if filled < progressBarWidth {
+		bar += ">" + strings.Repeat(" ", progressBarWidth-filled-1)
+	}
+
+	total := fmt.Sprintf("%d", info.Total)
+
+	if info.Estimated {
+		total = "~" + total
+	}
+
+	eta := "unknown"
+
+	if info.Eta >= 0 {
+		eta = (time.Duration(info.Eta) * time.Second).String()
+	}
+
+	result := fmt.Sprintf("[%s] %3d%% %d/%s files, %s, %.1f files/s, ETA %s, %d errors",
+		bar, info.Percent, info.Processed, total, humanize.Bytes(uint64(info.Bytes)), info.FilesPerSecond, eta, info.Errors)
+
+	if info.Directory != "" && info.Directory != "." {
+		result += ", " + info.Directory
+	}
+
+	return result
+}
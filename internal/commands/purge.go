@@ -7,8 +7,10 @@ import (
 	"time"
 
 	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/photoprism"
 	"github.com/photoprism/photoprism/internal/service"
+	"github.com/photoprism/photoprism/internal/workers"
 	"github.com/photoprism/photoprism/pkg/fs"
 	"github.com/photoprism/photoprism/pkg/txt"
 	"github.com/urfave/cli"
@@ -31,6 +33,7 @@ var purgeFlags = []cli.Flag{
 		Name:  "dry",
 		Usage: "dry run, don't actually remove anything",
 	},
+	queueFlag,
 }
 
 // purgeAction removes missing files from search results
@@ -69,6 +72,12 @@ func purgeAction(ctx *cli.Context) error {
 		Hard: ctx.Bool("hard"),
 	}
 
+	if ctx.Bool("queue") {
+		_, err := workers.EnqueueJob(entity.JobPurge, entity.JobPriorityLow, opt)
+		conf.Shutdown()
+		return err
+	}
+
 	if files, photos, err := prg.Start(opt); err != nil {
 		return err
 	} else {
@@ -0,0 +1,87 @@
+package commands
+
+import (
+	"context"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/photoprism"
+	"github.com/photoprism/photoprism/internal/service"
+	"github.com/photoprism/photoprism/internal/workers"
+	"github.com/urfave/cli"
+)
+
+// RenameCommand is used to register the rename cli command
+var RenameCommand = cli.Command{
+	Name:      "rename",
+	Usage:     "Moves indexed files to the paths generated by the import path template",
+	ArgsUsage: "[photo uids...]",
+	Flags:     renameFlags,
+	Action:    renameAction,
+}
+
+var renameFlags = []cli.Flag{
+	cli.StringFlag{
+		Name:  "template, t",
+		Usage: "path template, e.g. {year}/{year}-{month}-{day} {place}/{camera}_{original}",
+	},
+	cli.StringSliceFlag{
+		Name:  "album",
+		Usage: "album uid",
+	},
+	cli.StringSliceFlag{
+		Name:  "folder",
+		Usage: "folder uid",
+	},
+	cli.BoolFlag{
+		Name:  "dry-run, n",
+		Usage: "only show the new file names",
+	},
+	queueFlag,
+}
+
+// renameAction moves indexed files to their template paths
+func renameAction(ctx *cli.Context) error {
+	start := time.Now()
+
+	conf := config.NewConfig(ctx)
+	service.SetConfig(conf)
+
+	cctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if err := conf.Init(cctx); err != nil {
+		return err
+	}
+
+	conf.InitDb()
+
+	opt := photoprism.RenameOptions{
+		Selection: form.Selection{
+			Photos: ctx.Args(),
+			Albums: ctx.StringSlice("album"),
+			Files:  ctx.StringSlice("folder"),
+		},
+		Template: ctx.String("template"),
+		DryRun:   ctx.Bool("dry-run"),
+	}
+
+	if ctx.Bool("queue") && !opt.DryRun {
+		_, err := workers.EnqueueJob(entity.JobRename, entity.JobPriorityDefault, opt)
+		conf.Shutdown()
+		return err
+	}
+
+	if renamed, err := service.Rename().Start(opt); err != nil {
+		return err
+	} else {
+		elapsed := time.Since(start)
+
+		log.Infof("renamed %d photos in %s", len(renamed), elapsed)
+	}
+
+	conf.Shutdown()
+
+	return nil
+}
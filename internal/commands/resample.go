@@ -4,7 +4,9 @@ import (
 	"time"
 
 	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/service"
+	"github.com/photoprism/photoprism/internal/workers"
 	"github.com/photoprism/photoprism/pkg/txt"
 	"github.com/urfave/cli"
 )
@@ -19,6 +21,7 @@ var ResampleCommand = cli.Command{
 			Name:  "force, f",
 			Usage: "re-create existing thumbnails",
 		},
+		queueFlag,
 	},
 	Action: resampleAction,
 }
@@ -34,6 +37,10 @@ func resampleAction(ctx *cli.Context) error {
 		return err
 	}
 
+	if ctx.Bool("queue") {
+		return queueJob(conf, entity.JobResample, entity.JobPriorityLow, workers.ResampleJobOptions{Force: ctx.Bool("force")})
+	}
+
 	log.Infof("creating thumbnails in %s", txt.Quote(conf.ThumbPath()))
 
 	rs := service.Resample()
@@ -0,0 +1,83 @@
+package commands
+
+import (
+	"context"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/photoprism"
+	"github.com/photoprism/photoprism/internal/service"
+	"github.com/urfave/cli"
+)
+
+// TimeshiftCommand is used to register the timeshift cli command
+var TimeshiftCommand = cli.Command{
+	Name:      "timeshift",
+	Usage:     "Corrects the time photos were taken by an offset and / or time zone",
+	ArgsUsage: "[photo uids...]",
+	Flags:     timeshiftFlags,
+	Action:    timeshiftAction,
+}
+
+var timeshiftFlags = []cli.Flag{
+	cli.DurationFlag{
+		Name:  "offset",
+		Usage: "added to the local time, e.g. -1h30m if the camera clock was ahead",
+	},
+	cli.StringFlag{
+		Name:  "timezone, tz",
+		Usage: "time zone the camera clock was set to, e.g. Europe/Berlin",
+	},
+	cli.StringSliceFlag{
+		Name:  "album",
+		Usage: "album uid",
+	},
+	cli.StringSliceFlag{
+		Name:  "folder",
+		Usage: "folder uid",
+	},
+	cli.BoolFlag{
+		Name:  "yaml",
+		Usage: "update yaml sidecar files",
+	},
+}
+
+// timeshiftAction corrects the time photos were taken
+func timeshiftAction(ctx *cli.Context) error {
+	start := time.Now()
+
+	conf := config.NewConfig(ctx)
+	service.SetConfig(conf)
+
+	cctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if err := conf.Init(cctx); err != nil {
+		return err
+	}
+
+	conf.InitDb()
+
+	opt := photoprism.TimeshiftOptions{
+		Selection: form.Selection{
+			Photos: ctx.Args(),
+			Albums: ctx.StringSlice("album"),
+			Files:  ctx.StringSlice("folder"),
+		},
+		Offset:   ctx.Duration("offset"),
+		TimeZone: ctx.String("timezone"),
+		Yaml:     ctx.Bool("yaml") && !conf.ReadOnly(),
+	}
+
+	if shifted, err := service.Timeshift().Start(opt); err != nil {
+		return err
+	} else {
+		elapsed := time.Since(start)
+
+		log.Infof("changed time of %d photos in %s", len(shifted), elapsed)
+	}
+
+	conf.Shutdown()
+
+	return nil
+}
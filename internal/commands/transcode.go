@@ -0,0 +1,64 @@
+package commands
+
+import (
+	"context"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/service"
+	"github.com/photoprism/photoprism/internal/workers"
+	"github.com/photoprism/photoprism/pkg/txt"
+	"github.com/urfave/cli"
+)
+
+// TranscodeCommand is used to register the transcode cli command
+var TranscodeCommand = cli.Command{
+	Name:   "transcode",
+	Usage:  "Transcodes videos browsers can't play to H.264 MP4",
+	Flags:  []cli.Flag{queueFlag},
+	Action: transcodeAction,
+}
+
+// transcodeAction transcodes indexed videos that are not cached yet
+func transcodeAction(ctx *cli.Context) error {
+	start := time.Now()
+
+	conf := config.NewConfig(ctx)
+	service.SetConfig(conf)
+
+	if err := conf.CreateDirectories(); err != nil {
+		return err
+	}
+
+	cctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	if err := conf.Init(cctx); err != nil {
+		return err
+	}
+
+	conf.InitDb()
+	defer conf.Shutdown()
+
+	if ctx.Bool("queue") {
+		_, err := workers.EnqueueJob(entity.JobTranscode, entity.JobPriorityLow, workers.TranscodeJobOptions{})
+		return err
+	}
+
+	log.Infof("transcoding videos to %s", txt.Quote(conf.VideoPath()))
+
+	stopProgress := showProgress("transcode")
+
+	err := service.Transcode().Start("")
+
+	stopProgress()
+
+	if err != nil {
+		return err
+	}
+
+	log.Infof("transcoding completed in %s", time.Since(start))
+
+	return nil
+}
@@ -0,0 +1,126 @@
+package commands
+
+import (
+	"context"
+	"io"
+	"os"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/photoprism"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/internal/service"
+	"github.com/photoprism/photoprism/pkg/txt"
+	"github.com/urfave/cli"
+)
+
+// VerifyCommand is used to register the verify cli command
+var VerifyCommand = cli.Command{
+	Name:   "verify",
+	Usage:  "Verifies originals against the hashes stored in the index to detect bit rot",
+	Flags:  verifyFlags,
+	Action: verifyAction,
+}
+
+var verifyFlags = []cli.Flag{
+	cli.IntFlag{
+		Name:  "budget, b",
+		Usage: "megabytes to read, 0 for unlimited",
+	},
+	cli.IntFlag{
+		Name:  "rate, r",
+		Usage: "maximum read rate in megabytes per second, defaults to verify-rate",
+	},
+	cli.IntFlag{
+		Name:  "age",
+		Usage: "days after which files are verified again, defaults to verify-age",
+	},
+	cli.BoolFlag{
+		Name:  "all, a",
+		Usage: "verify all files, regardless of when they were last verified",
+	},
+	cli.StringFlag{
+		Name:  "export, e",
+		Usage: "export the mismatch report as CSV to a file, or - for stdout, without verifying files",
+	},
+}
+
+// verifyAction re-hashes originals and reports mismatches
+func verifyAction(ctx *cli.Context) error {
+	start := time.Now()
+
+	conf := config.NewConfig(ctx)
+	service.SetConfig(conf)
+
+	cctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	if err := conf.Init(cctx); err != nil {
+		return err
+	}
+
+	conf.InitDb()
+	defer conf.Shutdown()
+
+	if fileName := ctx.String("export"); fileName != "" {
+		return verifyExport(fileName)
+	}
+
+	opt := photoprism.VerifyOptionsConfig(conf)
+	opt.Budget = int64(ctx.Int("budget")) * 1024 * 1024
+
+	if rate := ctx.Int("rate"); rate > 0 {
+		opt.Rate = int64(rate) * 1024 * 1024
+	}
+
+	if ctx.Bool("all") {
+		opt.Age = 0
+	} else if age := ctx.Int("age"); age > 0 {
+		opt.Age = time.Duration(age) * 24 * time.Hour
+	}
+
+	result, err := service.Verify().Start(opt)
+
+	if err != nil {
+		return err
+	}
+
+	elapsed := time.Since(start)
+
+	log.Infof("verified %d files (%d bytes) in %s, %d mismatches", result.Files, result.Bytes, elapsed, result.Mismatches)
+
+	return nil
+}
+
+// verifyExport writes all file mismatches as CSV
+func verifyExport(fileName string) error {
+	mismatches, err := query.FileMismatches(-1, -1)
+
+	if err != nil {
+		return err
+	}
+
+	var w io.Writer = os.Stdout
+
+	if fileName != "-" {
+		f, err := os.Create(fileName)
+
+		if err != nil {
+			return err
+		}
+
+		defer f.Close()
+
+		w = f
+	}
+
+	if err := photoprism.WriteMismatchReport(w, mismatches); err != nil {
+		return err
+	}
+
+	if fileName != "-" {
+		log.Infof("exported %d mismatches to %s", len(mismatches), txt.Quote(fileName))
+	}
+
+	return nil
+}
@@ -236,6 +236,47 @@ func (c *Config) WakeupInterval() time.Duration {
 	return time.Duration(c.params.WakeupInterval) * time.Second
 }
 
+// Watch returns true if the originals folder should be watched for changes.
+func (c *Config) Watch() bool {
+	return c.params.Watch
+}
+
+// WatchDelay returns the time to wait for further changes before indexing modified folders.
+func (c *Config) WatchDelay() time.Duration {
+	if c.params.WatchDelay <= 0 {
+		return 10 * time.Second
+	}
+
+	return time.Duration(c.params.WatchDelay) * time.Second
+}
+
+// VerifyBudget returns the number of bytes of originals to verify per wakeup, 0 if scheduled verification is disabled.
+func (c *Config) VerifyBudget() int64 {
+	if c.params.VerifyBudget <= 0 {
+		return 0
+	}
+
+	return int64(c.params.VerifyBudget) * 1024 * 1024
+}
+
+// VerifyRate returns the maximum read rate in bytes per second when verifying originals, 0 for unlimited.
+func (c *Config) VerifyRate() int64 {
+	if c.params.VerifyRate <= 0 {
+		return 0
+	}
+
+	return int64(c.params.VerifyRate) * 1024 * 1024
+}
+
+// VerifyAge returns the time after which originals are verified again.
+func (c *Config) VerifyAge() time.Duration {
+	if c.params.VerifyAge <= 0 {
+		return 30 * 24 * time.Hour
+	}
+
+	return time.Duration(c.params.VerifyAge) * 24 * time.Hour
+}
+
 // GeoCodingApi returns the preferred geo coding api (none, osm or places).
 func (c *Config) GeoCodingApi() string {
 	switch c.params.GeoCodingApi {
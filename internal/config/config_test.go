@@ -4,6 +4,7 @@ import (
 	"os"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/photoprism/photoprism/pkg/fs"
 	"github.com/sirupsen/logrus"
@@ -216,6 +217,24 @@ func TestConfig_DarktableBin(t *testing.T) {
 	assert.Equal(t, "/usr/bin/darktable-cli", bin)
 }
 
+func TestConfig_RawTherapeeBin(t *testing.T) {
+	ctx := CliTestContext()
+	c := NewConfig(ctx)
+	c.params.RawTherapeeBin = "/usr/bin/rawtherapee-cli-missing"
+
+	bin := c.RawTherapeeBin()
+	assert.Equal(t, "", bin)
+}
+
+func TestConfig_ImageMagickBin(t *testing.T) {
+	ctx := CliTestContext()
+	c := NewConfig(ctx)
+	c.params.ImageMagickBin = "/usr/bin/convert-missing"
+
+	bin := c.ImageMagickBin()
+	assert.Equal(t, "", bin)
+}
+
 func TestConfig_HeifConvertBin(t *testing.T) {
 	ctx := CliTestContext()
 	c := NewConfig(ctx)
@@ -224,6 +243,14 @@ func TestConfig_HeifConvertBin(t *testing.T) {
 	assert.Equal(t, "/usr/bin/heif-convert", bin)
 }
 
+func TestConfig_FFprobeBin(t *testing.T) {
+	ctx := CliTestContext()
+	c := NewConfig(ctx)
+
+	bin := c.FFprobeBin()
+	assert.Equal(t, "/usr/bin/ffprobe", bin)
+}
+
 func TestConfig_ExifToolBin(t *testing.T) {
 	ctx := CliTestContext()
 	c := NewConfig(ctx)
@@ -374,3 +401,124 @@ func TestConfig_Workers(t *testing.T) {
 
 	assert.GreaterOrEqual(t, c.Workers(), 1)
 }
+
+func TestConfig_Watch(t *testing.T) {
+	ctx := CliTestContext()
+	c := NewConfig(ctx)
+
+	assert.False(t, c.Watch())
+}
+
+func TestConfig_WatchDelay(t *testing.T) {
+	ctx := CliTestContext()
+	c := NewConfig(ctx)
+
+	assert.Equal(t, 10*time.Second, c.WatchDelay())
+}
+
+func TestConfig_VerifyBudget(t *testing.T) {
+	ctx := CliTestContext()
+	c := NewConfig(ctx)
+
+	assert.Equal(t, int64(0), c.VerifyBudget())
+
+	c.params.VerifyBudget = 100
+
+	assert.Equal(t, int64(100*1024*1024), c.VerifyBudget())
+}
+
+func TestConfig_VerifyRate(t *testing.T) {
+	ctx := CliTestContext()
+	c := NewConfig(ctx)
+
+	assert.Equal(t, int64(0), c.VerifyRate())
+
+	c.params.VerifyRate = 20
+
+	assert.Equal(t, int64(20*1024*1024), c.VerifyRate())
+}
+
+func TestConfig_VerifyAge(t *testing.T) {
+	ctx := CliTestContext()
+	c := NewConfig(ctx)
+
+	assert.Equal(t, 30*24*time.Hour, c.VerifyAge())
+
+	c.params.VerifyAge = 7
+
+	assert.Equal(t, 7*24*time.Hour, c.VerifyAge())
+}
+
+func TestConfig_VideoPath(t *testing.T) {
+	ctx := CliTestContext()
+	c := NewConfig(ctx)
+
+	assert.True(t, strings.HasSuffix(c.VideoPath(), "assets/testdata/cache/videos"))
+}
+
+func TestConfig_VideoTranscode(t *testing.T) {
+	ctx := CliTestContext()
+	c := NewConfig(ctx)
+
+	c.params.FFmpegBin = "/usr/bin/ffmpeg-missing"
+
+	assert.Equal(t, "none", c.VideoTranscode())
+
+	c.params.FFmpegBin = ""
+
+	if c.FFmpegBin() == "" {
+		t.Skip("ffmpeg not installed")
+	}
+
+	assert.Equal(t, "lazy", c.VideoTranscode())
+
+	c.params.VideoTranscode = "index"
+
+	assert.Equal(t, "index", c.VideoTranscode())
+
+	c.params.VideoTranscode = "none"
+
+	assert.Equal(t, "none", c.VideoTranscode())
+}
+
+func TestConfig_VideoBitrate(t *testing.T) {
+	ctx := CliTestContext()
+	c := NewConfig(ctx)
+
+	assert.Equal(t, 8, c.VideoBitrate())
+
+	c.params.VideoBitrate = 200
+
+	assert.Equal(t, 100, c.VideoBitrate())
+
+	c.params.VideoBitrate = 20
+
+	assert.Equal(t, 20, c.VideoBitrate())
+}
+
+func TestConfig_VideoSize(t *testing.T) {
+	ctx := CliTestContext()
+	c := NewConfig(ctx)
+
+	assert.Equal(t, 720, c.VideoSize())
+
+	c.params.VideoSize = 10000
+
+	assert.Equal(t, 7680, c.VideoSize())
+
+	c.params.VideoSize = 3840
+
+	assert.Equal(t, 3840, c.VideoSize())
+}
+
+func TestConfig_VideoHls(t *testing.T) {
+	ctx := CliTestContext()
+	c := NewConfig(ctx)
+
+	assert.False(t, c.VideoHls())
+
+	c.params.VideoHls = true
+	c.params.FFmpegBin = "/usr/bin/ffmpeg-missing"
+
+	assert.False(t, c.VideoHls())
+}
@@ -58,6 +58,10 @@ func (c *Config) CreateDirectories() error {
 		return createError(c.ThumbPath(), err)
 	}
 
+	if err := os.MkdirAll(c.VideoPath(), os.ModePerm); err != nil {
+		return createError(c.VideoPath(), err)
+	}
+
 	if err := os.MkdirAll(c.ResourcesPath(), os.ModePerm); err != nil {
 		return createError(c.ResourcesPath(), err)
 	}
@@ -142,6 +146,16 @@ func (c *Config) DarktableBin() string {
 	return findExecutable(c.params.DarktableBin, "darktable-cli")
 }
 
+// RawTherapeeBin returns the rawtherapee-cli executable file name.
+func (c *Config) RawTherapeeBin() string {
+	return findExecutable(c.params.RawTherapeeBin, "rawtherapee-cli")
+}
+
+// ImageMagickBin returns the ImageMagick convert executable file name.
+func (c *Config) ImageMagickBin() string {
+	return findExecutable(c.params.ImageMagickBin, "convert")
+}
+
 // ExifToolBin returns the exiftool executable file name.
 func (c *Config) ExifToolBin() string {
 	return findExecutable(c.params.ExifToolBin, "exiftool")
@@ -180,6 +194,17 @@ func (c *Config) FFmpegBin() string {
 	return findExecutable(c.params.FFmpegBin, "ffmpeg")
 }
 
+// FFprobeBin returns the ffprobe executable file name, which is expected next to ffmpeg.
+func (c *Config) FFprobeBin() string {
+	if ffmpegBin := c.FFmpegBin(); ffmpegBin != "" {
+		if result := findExecutable(filepath.Join(filepath.Dir(ffmpegBin), "ffprobe"), "ffprobe"); result != "" {
+			return result
+		}
+	}
+
+	return findExecutable("", "ffprobe")
+}
+
 // TempPath returns a temporary directory name for uploads and downloads.
 func (c *Config) TempPath() string {
 	if c.params.TempPath == "" {
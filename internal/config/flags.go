@@ -48,6 +48,31 @@ var GlobalFlags = []cli.Flag{
 		Usage:  "background worker wakeup interval in seconds",
 		EnvVar: "PHOTOPRISM_WAKEUP_INTERVAL",
 	},
+	cli.BoolFlag{
+		Name:   "watch",
+		Usage:  "watch originals for changes and index modified folders automatically",
+		EnvVar: "PHOTOPRISM_WATCH",
+	},
+	cli.IntFlag{
+		Name:   "watch-delay",
+		Usage:  "seconds to wait for further changes before indexing modified folders",
+		EnvVar: "PHOTOPRISM_WATCH_DELAY",
+	},
+	cli.IntFlag{
+		Name:   "verify-budget",
+		Usage:  "megabytes of originals to verify against stored hashes per wakeup, 0 to disable",
+		EnvVar: "PHOTOPRISM_VERIFY_BUDGET",
+	},
+	cli.IntFlag{
+		Name:   "verify-rate",
+		Usage:  "maximum read rate in megabytes per second when verifying originals, 0 for unlimited",
+		EnvVar: "PHOTOPRISM_VERIFY_RATE",
+	},
+	cli.IntFlag{
+		Name:   "verify-age",
+		Usage:  "days after which originals are verified again",
+		EnvVar: "PHOTOPRISM_VERIFY_AGE",
+	},
 	cli.StringFlag{
 		Name:   "url",
 		Usage:  "canonical site URL",
@@ -161,6 +186,18 @@ var GlobalFlags = []cli.Flag{
 		Value:  "darktable-cli",
 		EnvVar: "PHOTOPRISM_DARKTABLE_BIN",
 	},
+	cli.StringFlag{
+		Name:   "rawtherapee-bin",
+		Usage:  "rawtherapee-cli executable `FILENAME`",
+		Value:  "rawtherapee-cli",
+		EnvVar: "PHOTOPRISM_RAWTHERAPEE_BIN",
+	},
+	cli.StringFlag{
+		Name:   "imagemagick-bin",
+		Usage:  "ImageMagick convert executable `FILENAME`",
+		Value:  "convert",
+		EnvVar: "PHOTOPRISM_IMAGEMAGICK_BIN",
+	},
 	cli.StringFlag{
 		Name:   "heifconvert-bin",
 		Usage:  "heif-convert executable `FILENAME`",
@@ -173,6 +210,29 @@ var GlobalFlags = []cli.Flag{
 		Value:  "ffmpeg",
 		EnvVar: "PHOTOPRISM_FFMPEG_BIN",
 	},
+	cli.StringFlag{
+		Name:   "video-transcode",
+		Usage:  "transcode videos browsers can't play to H.264 MP4 (none, lazy or index)",
+		Value:  "lazy",
+		EnvVar: "PHOTOPRISM_VIDEO_TRANSCODE",
+	},
+	cli.IntFlag{
+		Name:   "video-bitrate",
+		Usage:  "transcoding bitrate limit in Mbit/s (1-100)",
+		Value:  8,
+		EnvVar: "PHOTOPRISM_VIDEO_BITRATE",
+	},
+	cli.IntFlag{
+		Name:   "video-size",
+		Usage:  "transcoding size limit in pixels (720-7680)",
+		Value:  1920,
+		EnvVar: "PHOTOPRISM_VIDEO_SIZE",
+	},
+	cli.BoolFlag{
+		Name:   "video-hls",
+		Usage:  "stream videos as cached HLS renditions in multiple resolutions",
+		EnvVar: "PHOTOPRISM_VIDEO_HLS",
+	},
 	cli.StringFlag{
 		Name:   "exiftool-bin",
 		Usage:  "exiftool executable `FILENAME`",
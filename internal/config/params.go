@@ -42,6 +42,11 @@ type Params struct {
 	Experimental       bool   `yaml:"experimental" flag:"experimental"`
 	Workers            int    `yaml:"workers" flag:"workers"`
 	WakeupInterval     int    `yaml:"wakeup-interval" flag:"wakeup-interval"`
+	Watch              bool   `yaml:"watch" flag:"watch"`
+	WatchDelay         int    `yaml:"watch-delay" flag:"watch-delay"`
+	VerifyBudget       int    `yaml:"verify-budget" flag:"verify-budget"`
+	VerifyRate         int    `yaml:"verify-rate" flag:"verify-rate"`
+	VerifyAge          int    `yaml:"verify-age" flag:"verify-age"`
 	AdminPassword      string `yaml:"admin-password" flag:"admin-password"`
 	WebDAVPassword     string `yaml:"webdav-password" flag:"webdav-password"`
 	LogLevel           string `yaml:"log-level" flag:"log-level"`
@@ -66,8 +71,14 @@ type Params struct {
 	HttpServerPassword string `yaml:"http-password" flag:"http-password"`
 	SipsBin            string `yaml:"sips-bin" flag:"sips-bin"`
 	DarktableBin       string `yaml:"darktable-bin" flag:"darktable-bin"`
+	RawTherapeeBin     string `yaml:"rawtherapee-bin" flag:"rawtherapee-bin"`
+	ImageMagickBin     string `yaml:"imagemagick-bin" flag:"imagemagick-bin"`
 	HeifConvertBin     string `yaml:"heifconvert-bin" flag:"heifconvert-bin"`
 	FFmpegBin          string `yaml:"ffmpeg-bin" flag:"ffmpeg-bin"`
+	VideoTranscode     string `yaml:"video-transcode" flag:"video-transcode"`
+	VideoBitrate       int    `yaml:"video-bitrate" flag:"video-bitrate"`
+	VideoSize          int    `yaml:"video-size" flag:"video-size"`
+	VideoHls           bool   `yaml:"video-hls" flag:"video-hls"`
 	ExifToolBin        string `yaml:"exiftool-bin" flag:"exiftool-bin"`
 	SidecarJson        bool   `yaml:"sidecar-json" flag:"sidecar-json"`
 	SidecarYaml        bool   `yaml:"sidecar-yaml" flag:"sidecar-yaml"`
@@ -32,8 +32,27 @@ type IndexSettings struct {
 }
 
 type ImportSettings struct {
-	Path string `json:"path" yaml:"path"`
-	Move bool   `json:"move" yaml:"move"`
+	Path     string `json:"path" yaml:"path"`
+	Move     bool   `json:"move" yaml:"move"`
+	Template string `json:"template" yaml:"template"`
+}
+
+// ConvertSettings configures the converters used to create JPEGs, e.g. to disable a converter
+// or to change the order in which converters are tried for a file type like raw or heif.
+type ConvertSettings struct {
+	Disabled []string            `json:"disabled" yaml:"disabled"`
+	Order    map[string][]string `json:"order" yaml:"order"`
+}
+
+// Enabled tests if the converter with the given name may be used.
+func (s ConvertSettings) Enabled(name string) bool {
+	for _, disabled := range s.Disabled {
+		if disabled == name {
+			return false
+		}
+	}
+
+	return true
 }
 
 type FeatureSettings struct {
@@ -61,6 +80,7 @@ type Settings struct {
 	Features  FeatureSettings  `json:"features" yaml:"features"`
 	Import    ImportSettings   `json:"import" yaml:"import"`
 	Index     IndexSettings    `json:"index" yaml:"index"`
+	Convert   ConvertSettings  `json:"convert" yaml:"convert"`
 }
 
 // NewSettings returns a empty Settings
@@ -12,6 +12,13 @@ func TestNewSettings(t *testing.T) {
 	assert.IsType(t, new(Settings), c)
 }
 
+func TestConvertSettings_Enabled(t *testing.T) {
+	s := ConvertSettings{Disabled: []string{"darktable"}}
+
+	assert.False(t, s.Enabled("darktable"))
+	assert.True(t, s.Enabled("rawtherapee"))
+}
+
 func TestSettings_Load(t *testing.T) {
 	t.Run("existing filename", func(t *testing.T) {
 		c := NewSettings()
@@ -46,6 +46,7 @@ func NewTestParams() *Params {
 		SidecarHidden:  true,
 		DarktableBin:   "/usr/bin/darktable-cli",
 		ExifToolBin:    "/usr/bin/exiftool",
+		VideoHls:       true,
 		AssetsPath:     assetsPath,
 		CachePath:      testDataPath + "/cache",
 		OriginalsPath:  testDataPath + "/originals",
@@ -0,0 +1,55 @@
+package config
+
+import "strings"
+
+// VideoPath returns the directory for transcoded videos.
+func (c *Config) VideoPath() string {
+	return c.CachePath() + "/videos"
+}
+
+// VideoTranscode returns when videos browsers can't play are transcoded (none, lazy or index).
+func (c *Config) VideoTranscode() string {
+	if c.FFmpegBin() == "" {
+		return "none"
+	}
+
+	switch strings.ToLower(c.params.VideoTranscode) {
+	case "none":
+		return "none"
+	case "index":
+		return "index"
+	default:
+		return "lazy"
+	}
+}
+
+// VideoBitrate returns the transcoding bitrate limit in Mbit/s (1-100).
+func (c *Config) VideoBitrate() int {
+	if c.params.VideoBitrate > 100 {
+		return 100
+	}
+
+	if c.params.VideoBitrate < 1 {
+		return 8
+	}
+
+	return c.params.VideoBitrate
+}
+
+// VideoSize returns the transcoding size limit in pixels (720-7680).
+func (c *Config) VideoSize() int {
+	if c.params.VideoSize > 7680 {
+		return 7680
+	}
+
+	if c.params.VideoSize < 720 {
+		return 720
+	}
+
+	return c.params.VideoSize
+}
+
+// VideoHls returns true if videos should be streamed as cached HLS renditions, requires ffmpeg.
+func (c *Config) VideoHls() bool {
+	return c.params.VideoHls && c.FFmpegBin() != ""
+}
@@ -65,6 +65,15 @@ func NewAlbum(albumTitle, albumType string) *Album {
 	return result
 }
 
+// FirstOrCreate returns the existing album with the same slug and type or creates a new one.
+func (m *Album) FirstOrCreate() *Album {
+	if err := Db().FirstOrCreate(m, "album_slug = ? AND album_type = ?", m.AlbumSlug, m.AlbumType).Error; err != nil {
+		log.Errorf("album: %s", err)
+	}
+
+	return m
+}
+
 // SetTitle changes the album name.
 func (m *Album) SetTitle(title string) {
 	title = strings.TrimSpace(title)
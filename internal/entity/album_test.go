@@ -27,6 +27,17 @@ func TestNewAlbum(t *testing.T) {
 	})
 }
 
+func TestAlbum_FirstOrCreate(t *testing.T) {
+	t.Run("new and existing", func(t *testing.T) {
+		album := NewAlbum("Takeout Summer 2019", TypeDefault).FirstOrCreate()
+		assert.NotEmpty(t, album.ID)
+
+		existing := NewAlbum("Takeout Summer 2019", TypeDefault).FirstOrCreate()
+		assert.Equal(t, album.ID, existing.ID)
+		assert.Equal(t, album.AlbumUID, existing.AlbumUID)
+	})
+}
+
 func TestAlbum_SetName(t *testing.T) {
 	t.Run("valid name", func(t *testing.T) {
 		album := NewAlbum("initial name", TypeDefault)
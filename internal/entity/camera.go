@@ -1,8 +1,8 @@
 package entity
 
 import (
+	"errors"
 	"fmt"
-	"strings"
 	"time"
 
 	"github.com/gosimple/slug"
@@ -36,13 +36,11 @@ func CreateUnknownCamera() {
 
 // NewCamera creates a camera entity from a model name and a make name.
 func NewCamera(modelName string, makeName string) *Camera {
-	modelName = txt.Clip(modelName, txt.ClipDefault)
-	makeName = txt.Clip(makeName, txt.ClipDefault)
+	modelName = txt.Clip(NormalizeCameraModel(modelName, makeName), txt.ClipDefault)
+	makeName = txt.Clip(NormalizeCameraMake(makeName), txt.ClipDefault)
 
 	if modelName == "" {
 		return &UnknownCamera
-	} else if strings.HasPrefix(modelName, makeName) {
-		modelName = strings.TrimSpace(modelName[len(makeName):])
 	}
 
 	var cameraSlug string
@@ -77,6 +75,8 @@ func FirstOrCreateCamera(m *Camera) *Camera {
 
 	if err := Db().Where("camera_model = ? AND camera_make = ?", m.CameraModel, m.CameraMake).First(&result).Error; err == nil {
 		return &result
+	} else if err := Db().Where("id IN (SELECT camera_id FROM camera_aliases WHERE alias_slug = ?)", m.CameraSlug).First(&result).Error; err == nil {
+		return &result
 	} else if err := m.Create(); err != nil {
 		log.Errorf("camera: %s", err)
 		return nil
@@ -95,3 +95,44 @@ func (m *Camera) String() string {
 
 	return ""
 }
+
+// Merge moves all photos of other cameras to this camera and deletes them. Their slugs
+// are kept as aliases, so that new photos taken with these cameras are added to this camera.
+func (m *Camera) Merge(others []Camera) error {
+	if m.ID == 0 || m.CameraSlug == UnknownCamera.CameraSlug {
+		return errors.New("camera: can't merge into unknown camera")
+	}
+
+	tx := Db().Begin()
+
+	for _, other := range others {
+		if other.ID == m.ID {
+			continue
+		} else if other.CameraSlug == UnknownCamera.CameraSlug {
+			tx.Rollback()
+			return errors.New("camera: can't merge unknown camera")
+		}
+
+		if err := tx.Unscoped().Model(&Photo{}).Where("camera_id = ?", other.ID).UpdateColumn("camera_id", m.ID).Error; err != nil {
+			tx.Rollback()
+			return err
+		}
+
+		if err := tx.Model(&CameraAlias{}).Where("camera_id = ?", other.ID).UpdateColumn("camera_id", m.ID).Error; err != nil {
+			tx.Rollback()
+			return err
+		}
+
+		if err := tx.Save(NewCameraAlias(other.CameraSlug, m.ID)).Error; err != nil {
+			tx.Rollback()
+			return err
+		}
+
+		if err := tx.Unscoped().Delete(&other).Error; err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	return tx.Commit().Error
+}
@@ -0,0 +1,26 @@
+package entity
+
+import (
+	"time"
+)
+
+// CameraAlias maps the slug of a merged camera to the camera it was merged into.
+type CameraAlias struct {
+	AliasSlug string    `gorm:"type:varbinary(255);primary_key;auto_increment:false" json:"Slug" yaml:"Slug"`
+	CameraID  uint      `gorm:"index;" json:"CameraID" yaml:"-"`
+	CreatedAt time.Time `json:"-" yaml:"-"`
+	UpdatedAt time.Time `json:"-" yaml:"-"`
+}
+
+// TableName returns CameraAlias table identifier "camera_aliases".
+func (CameraAlias) TableName() string {
+	return "camera_aliases"
+}
+
+// NewCameraAlias returns a new camera alias.
+func NewCameraAlias(aliasSlug string, cameraID uint) *CameraAlias {
+	return &CameraAlias{
+		AliasSlug: aliasSlug,
+		CameraID:  cameraID,
+	}
+}
@@ -95,6 +95,30 @@ var CameraFixtures = CameraMap{
 		UpdatedAt:         time.Now(),
 		DeletedAt:         nil,
 	},
+	"nikon-d750": {
+		ID:                1000006,
+		CameraSlug:        "nikon-d750",
+		CameraModel:       "D750",
+		CameraMake:        "Nikon",
+		CameraType:        "",
+		CameraDescription: "",
+		CameraNotes:       "",
+		CreatedAt:         time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC),
+		UpdatedAt:         time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC),
+		DeletedAt:         nil,
+	},
+	"nikon-corporation-nikon-d750": {
+		ID:                1000007,
+		CameraSlug:        "nikon-corporation-nikon-d750",
+		CameraModel:       "NIKON D750",
+		CameraMake:        "NIKON CORPORATION",
+		CameraType:        "",
+		CameraDescription: "",
+		CameraNotes:       "",
+		CreatedAt:         time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC),
+		UpdatedAt:         time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC),
+		DeletedAt:         nil,
+	},
 }
 
 // CreateCameraFixtures inserts known entities into the database for testing.
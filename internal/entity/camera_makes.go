@@ -0,0 +1,141 @@
+package entity
+
+import (
+	"strings"
+)
+
+// CameraMakes maps lower case make names as found in Exif metadata to normalized names.
+var CameraMakes = map[string]string{
+	"apple":                       "Apple",
+	"asahi optical co.,ltd.":      "Pentax",
+	"blackberry":                  "BlackBerry",
+	"canon":                       "Canon",
+	"casio computer co.,ltd.":     "Casio",
+	"casio":                       "Casio",
+	"dji":                         "DJI",
+	"eastman kodak company":       "Kodak",
+	"kodak":                       "Kodak",
+	"fujifilm":                    "Fujifilm",
+	"fuji photo film co., ltd.":   "Fujifilm",
+	"google":                      "Google",
+	"gopro":                       "GoPro",
+	"hasselblad":                  "Hasselblad",
+	"htc":                         "HTC",
+	"huawei":                      "Huawei",
+	"konica minolta":              "Konica Minolta",
+	"konica minolta camera, inc.": "Konica Minolta",
+	"minolta co.,ltd":             "Minolta",
+	"leica":                       "Leica",
+	"leica camera ag":             "Leica",
+	"lg electronics":              "LG",
+	"lge":                         "LG",
+	"motorola":                    "Motorola",
+	"nikon":                       "Nikon",
+	"nikon corporation":           "Nikon",
+	"nokia":                       "Nokia",
+	"olympus":                     "Olympus",
+	"olympus corporation":         "Olympus",
+	"olympus imaging corp.":       "Olympus",
+	"olympus optical co.,ltd":     "Olympus",
+	"om digital solutions":        "OM System",
+	"oneplus":                     "OnePlus",
+	"panasonic":                   "Panasonic",
+	"pentax":                      "Pentax",
+	"pentax corporation":          "Pentax",
+	"ricoh":                       "Ricoh",
+	"ricoh imaging company, ltd.": "Ricoh",
+	"samsung":                     "Samsung",
+	"samsung techwin":             "Samsung",
+	"sigma":                       "Sigma",
+	"sony":                        "Sony",
+	"sony ericsson":               "Sony Ericsson",
+	"xiaomi":                      "Xiaomi",
+}
+
+// CameraModels maps lower case make and model names to normalized model names.
+var CameraModels = map[string]string{
+	"dji fc220":               "Mavic Pro",
+	"dji fc330":               "Phantom 4",
+	"dji fc2103":              "Mavic Air",
+	"dji fc6310":              "Phantom 4 Pro",
+	"dji fc7203":              "Mavic Mini",
+	"olympus e-m1markii":      "E-M1 Mark II",
+	"olympus e-m1markiii":     "E-M1 Mark III",
+	"olympus e-m5markii":      "E-M5 Mark II",
+	"olympus e-m5markiii":     "E-M5 Mark III",
+	"olympus e-m10markii":     "E-M10 Mark II",
+	"olympus e-m10markiii":    "E-M10 Mark III",
+	"olympus e-m10 mark iii":  "E-M10 Mark III",
+	"samsung sm-g920f":        "Galaxy S6",
+	"samsung sm-g930f":        "Galaxy S7",
+	"samsung sm-g950f":        "Galaxy S8",
+	"samsung sm-g960f":        "Galaxy S9",
+	"samsung sm-g973f":        "Galaxy S10",
+	"samsung sm-n950f":        "Galaxy Note 8",
+	"samsung sm-n960f":        "Galaxy Note 9",
+	"canon eos 5d mark 2":     "EOS 5D Mark II",
+	"canon eos 5d mark 3":     "EOS 5D Mark III",
+	"canon eos 5d mark 4":     "EOS 5D Mark IV",
+	"canon eos rebel t6i":     "EOS 750D",
+	"canon eos kiss x8i":      "EOS 750D",
+	"canon eos rebel t7i":     "EOS 800D",
+	"canon eos kiss x9i":      "EOS 800D",
+	"sony ilce-7m3":           "Alpha 7 III",
+	"sony ilce-7rm3":          "Alpha 7R III",
+	"sony ilce-7rm4":          "Alpha 7R IV",
+	"sony ilce-6000":          "Alpha 6000",
+	"sony ilce-6400":          "Alpha 6400",
+	"sony dsc-rx100m3":        "RX100 III",
+	"sony dsc-rx100m5":        "RX100 V",
+	"panasonic dmc-gh4":       "Lumix GH4",
+	"panasonic dc-gh5":        "Lumix GH5",
+	"google pixel 3 xl":       "Pixel 3 XL",
+	"xiaomi redmi note 8 pro": "Redmi Note 8 Pro",
+}
+
+// NormalizeCameraMake returns the normalized camera make name.
+func NormalizeCameraMake(makeName string) string {
+	makeName = strings.Join(strings.Fields(makeName), " ")
+
+	if result, ok := CameraMakes[strings.ToLower(makeName)]; ok {
+		return result
+	}
+
+	return makeName
+}
+
+// NormalizeCameraModel returns the normalized camera model name without the make prefix.
+func NormalizeCameraModel(modelName, makeName string) string {
+	modelName = strings.Join(strings.Fields(modelName), " ")
+	makeName = strings.Join(strings.Fields(makeName), " ")
+
+	if modelName == "" {
+		return ""
+	}
+
+	normalizedMake := NormalizeCameraMake(makeName)
+
+	// Remove make prefixes like "NIKON" in "NIKON D750", ignoring case.
+	prefixes := []string{makeName, normalizedMake}
+
+	if fields := strings.Fields(makeName); len(fields) > 1 {
+		prefixes = append(prefixes, fields[0])
+	}
+
+	for _, prefix := range prefixes {
+		if prefix == "" || len(modelName) <= len(prefix) {
+			continue
+		}
+
+		if strings.EqualFold(modelName[:len(prefix)], prefix) && modelName[len(prefix)] == ' ' {
+			modelName = strings.TrimSpace(modelName[len(prefix):])
+			break
+		}
+	}
+
+	if result, ok := CameraModels[strings.ToLower(strings.TrimSpace(normalizedMake+" "+modelName))]; ok {
+		return result
+	}
+
+	return modelName
+}
@@ -0,0 +1,33 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeCameraMake(t *testing.T) {
+	assert.Equal(t, "Nikon", NormalizeCameraMake("NIKON CORPORATION"))
+	assert.Equal(t, "Olympus", NormalizeCameraMake("OLYMPUS IMAGING CORP.  "))
+	assert.Equal(t, "Canon", NormalizeCameraMake("Canon"))
+	assert.Equal(t, "Foo Bar", NormalizeCameraMake("Foo  Bar"))
+	assert.Equal(t, "", NormalizeCameraMake(""))
+}
+
+func TestNormalizeCameraModel(t *testing.T) {
+	t.Run("make prefix", func(t *testing.T) {
+		assert.Equal(t, "D750", NormalizeCameraModel("NIKON D750", "NIKON CORPORATION"))
+		assert.Equal(t, "D750", NormalizeCameraModel("Nikon D750", "NIKON"))
+		assert.Equal(t, "EOS 6D", NormalizeCameraModel("Canon EOS 6D", "Canon"))
+		assert.Equal(t, "Lumix", NormalizeCameraModel("Panasonic Lumix", "Panasonic"))
+	})
+	t.Run("known model", func(t *testing.T) {
+		assert.Equal(t, "E-M5 Mark II", NormalizeCameraModel("E-M5MarkII", "OLYMPUS IMAGING CORP."))
+		assert.Equal(t, "Galaxy S8", NormalizeCameraModel("SM-G950F", "samsung"))
+	})
+	t.Run("unchanged", func(t *testing.T) {
+		assert.Equal(t, "iPhone SE", NormalizeCameraModel("iPhone SE", "Apple"))
+		assert.Equal(t, "TG-4", NormalizeCameraModel("TG-4", ""))
+		assert.Equal(t, "", NormalizeCameraModel("", "Canon"))
+	})
+}
@@ -20,6 +20,57 @@ func TestFirstOrCreateCamera(t *testing.T) {
 	})
 }
 
+func TestCamera_Merge(t *testing.T) {
+	t.Run("nikon-d750", func(t *testing.T) {
+		camera := CameraFixtures.Get("nikon-d750")
+		other := CameraFixtures.Get("nikon-corporation-nikon-d750")
+
+		if err := camera.Merge([]Camera{other}); err != nil {
+			t.Fatal(err)
+		}
+
+		result := FirstOrCreateCamera(&Camera{CameraModel: "NIKON D750", CameraMake: "NIKON CORPORATION", CameraSlug: other.CameraSlug})
+
+		if result == nil {
+			t.Fatal("result should not be nil")
+		}
+
+		assert.Equal(t, camera.ID, result.ID)
+	})
+	t.Run("archived photo", func(t *testing.T) {
+		camera := FirstOrCreateCamera(NewCamera("Merge Target", "Archive Test"))
+		other := FirstOrCreateCamera(NewCamera("Merge Source", "Archive Test"))
+		photo := &Photo{PhotoTitle: "Archived Merge Test", CameraID: other.ID}
+
+		if err := Db().Create(photo).Error; err != nil {
+			t.Fatal(err)
+		}
+
+		if err := Db().Delete(photo).Error; err != nil {
+			t.Fatal(err)
+		}
+
+		if err := camera.Merge([]Camera{*other}); err != nil {
+			t.Fatal(err)
+		}
+
+		var result Photo
+
+		if err := Db().Unscoped().First(&result, photo.ID).Error; err != nil {
+			t.Fatal(err)
+		}
+
+		assert.NotNil(t, result.DeletedAt)
+		assert.Equal(t, camera.ID, result.CameraID)
+	})
+	t.Run("unknown camera", func(t *testing.T) {
+		camera := CameraFixtures.Get("nikon-d750")
+
+		assert.Error(t, camera.Merge([]Camera{UnknownCamera}))
+		assert.Error(t, UnknownCamera.Merge([]Camera{camera}))
+	})
+}
+
 func TestNewCamera(t *testing.T) {
 	t.Run("unknown camera", func(t *testing.T) {
 		camera := NewCamera("", "Nikon")
@@ -46,6 +97,26 @@ func TestNewCamera(t *testing.T) {
 		}
 		assert.Equal(t, expected, camera)
 	})
+	t.Run("model NIKON D750 make NIKON CORPORATION", func(t *testing.T) {
+		camera := NewCamera("NIKON D750", "NIKON CORPORATION")
+
+		expected := &Camera{
+			CameraModel: "D750",
+			CameraMake:  "Nikon",
+			CameraSlug:  "nikon-d750",
+		}
+		assert.Equal(t, expected, camera)
+	})
+	t.Run("model L1D-20c make Hasselblad", func(t *testing.T) {
+		camera := NewCamera("L1D-20c", "Hasselblad")
+
+		expected := &Camera{
+			CameraModel: "L1D-20c",
+			CameraMake:  "Hasselblad",
+			CameraSlug:  "hasselblad-l1d-20c",
+		}
+		assert.Equal(t, expected, camera)
+	})
 	t.Run("model TG-4 make Unknown", func(t *testing.T) {
 		camera := NewCamera("TG-4", "")
 
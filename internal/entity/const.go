@@ -8,8 +8,10 @@ const (
 	SrcManual   = "manual"
 	SrcName     = "name"
 	SrcMeta     = "meta"
-	SrcXmp      = "xmp"
+	SrcXmp      = classify.SrcXmp
 	SrcYaml     = "yaml"
+	SrcTakeout  = "takeout"
+	SrcTrack    = "track"
 	SrcLocation = classify.SrcLocation
 	SrcImage    = classify.SrcImage
 
@@ -29,27 +29,37 @@ type Types map[string]interface{}
 
 // List of database entities and their table names.
 var Entities = Types{
-	"errors":          &Error{},
-	"accounts":        &Account{},
-	"folders":         &Folder{},
-	"files":           &File{},
-	"files_share":     &FileShare{},
-	"files_sync":      &FileSync{},
-	"photos":          &Photo{},
-	"details":         &Details{},
-	"places":          &Place{},
-	"locations":       &Location{},
-	"cameras":         &Camera{},
-	"lenses":          &Lens{},
-	"countries":       &Country{},
-	"albums":          &Album{},
-	"photos_albums":   &PhotoAlbum{},
-	"labels":          &Label{},
-	"categories":      &Category{},
-	"photos_labels":   &PhotoLabel{},
-	"keywords":        &Keyword{},
-	"photos_keywords": &PhotoKeyword{},
-	"links":           &Link{},
+	"errors":           &Error{},
+	"accounts":         &Account{},
+	"folders":          &Folder{},
+	"files":            &File{},
+	"files_share":      &FileShare{},
+	"files_sync":       &FileSync{},
+	"files_mismatch":   &FileMismatch{},
+	"files_conversion": &FileConversion{},
+	"photos":           &Photo{},
+	"details":          &Details{},
+	"places":           &Place{},
+	"locations":        &Location{},
+	"cameras":          &Camera{},
+	"camera_aliases":   &CameraAlias{},
+	"lenses":           &Lens{},
+	"lens_aliases":     &LensAlias{},
+	"countries":        &Country{},
+	"albums":           &Album{},
+	"photos_albums":    &PhotoAlbum{},
+	"labels":           &Label{},
+	"categories":       &Category{},
+	"photos_labels":    &PhotoLabel{},
+	"keywords":         &Keyword{},
+	"photos_keywords":  &PhotoKeyword{},
+	"people":           &Person{},
+	"people_aliases":   &PersonAlias{},
+	"photos_people":    &PhotoPerson{},
+	"stacks":           &Stack{},
+	"links":            &Link{},
+	"index_runs":       &IndexRun{},
+	"jobs":             &Job{},
 }
 
 // WaitForMigration waits for the database migration to be successful.
@@ -34,7 +34,15 @@ type File struct {
 	FileDuplicate   bool          `json:"Duplicate" yaml:"Duplicate,omitempty"`
 	FilePortrait    bool          `json:"Portrait" yaml:"Portrait,omitempty"`
 	FileVideo       bool          `json:"Video" yaml:"Video,omitempty"`
+	FileMotion      bool          `json:"Motion" yaml:"Motion,omitempty"`
 	FileDuration    time.Duration `json:"Duration" yaml:"Duration,omitempty"`
+	FileFPS         float64       `json:"FPS" yaml:"FPS,omitempty"`
+	FileBitrate     int64         `json:"Bitrate" yaml:"Bitrate,omitempty"`
+	FileAudioCodec  string        `gorm:"type:varbinary(32)" json:"AudioCodec" yaml:"AudioCodec,omitempty"`
+	FileRotation    int           `json:"Rotation" yaml:"Rotation,omitempty"`
+	FileTransfer    string        `gorm:"type:varbinary(32)" json:"Transfer" yaml:"Transfer,omitempty"`
+	FileHDR         bool          `json:"HDR" yaml:"HDR,omitempty"`
+	FileStreams     int           `json:"Streams" yaml:"Streams,omitempty"`
 	FileWidth       int           `json:"Width" yaml:"Width,omitempty"`
 	FileHeight      int           `json:"Height" yaml:"Height,omitempty"`
 	FileOrientation int           `json:"Orientation" yaml:"Orientation,omitempty"`
@@ -44,8 +52,11 @@ type File struct {
 	FileLuminance   string        `gorm:"type:varbinary(9);" json:"Luminance" yaml:"Luminance,omitempty"`
 	FileDiff        uint32        `json:"Diff" yaml:"Diff,omitempty"`
 	FileChroma      uint8         `json:"Chroma" yaml:"Chroma,omitempty"`
+	FilePHash       string        `gorm:"type:varbinary(16);index;" json:"PHash" yaml:"PHash,omitempty"`
 	FileNotes       string        `gorm:"type:text" json:"Notes" yaml:"Notes,omitempty"`
 	FileError       string        `gorm:"type:varbinary(512)" json:"Error" yaml:"Error,omitempty"`
+	FileQuarantine  bool          `json:"Quarantine" yaml:"Quarantine,omitempty"`
+	FileVerified    *time.Time    `json:"Verified,omitempty" yaml:"-"`
 	Share           []FileShare   `json:"-" yaml:"-"`
 	Sync            []FileSync    `json:"-" yaml:"-"`
 	Links           []Link        `gorm:"foreignkey:share_uid;association_foreignkey:file_uid" json:"Links" yaml:"-"`
@@ -0,0 +1,51 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// FileConversion represents an attempt to convert a file to JPEG, including the converter output.
+type FileConversion struct {
+	ID        uint          `gorm:"primary_key" json:"ID" yaml:"-"`
+	FileName  string        `gorm:"type:varbinary(768);index;" json:"FileName" yaml:"FileName"`
+	FileType  string        `gorm:"type:varbinary(32);" json:"FileType" yaml:"FileType"`
+	Converter string        `gorm:"type:varbinary(32);" json:"Converter" yaml:"Converter"`
+	Success   bool          `json:"Success" yaml:"Success"`
+	Error     string        `gorm:"type:varbinary(512);" json:"Error" yaml:"Error,omitempty"`
+	Output    string        `gorm:"type:text;" json:"Output" yaml:"Output,omitempty"`
+	Duration  time.Duration `json:"Duration" yaml:"Duration"`
+	CreatedAt time.Time     `json:"CreatedAt" yaml:"-"`
+}
+
+// FileConversions represents a list of conversion attempts.
+type FileConversions []FileConversion
+
+// TableName returns the entity database table name.
+func (FileConversion) TableName() string {
+	return "files_conversion"
+}
+
+// NewFileConversion creates a new entity for the result of a converter, the output is clipped if too long.
+func NewFileConversion(fileName, fileType, converter string, duration time.Duration, output string, err error) *FileConversion {
+	result := &FileConversion{
+		FileName:  fileName,
+		FileType:  fileType,
+		Converter: converter,
+		Success:   err == nil,
+		Output:    txt.Clip(output, 4096),
+		Duration:  duration,
+	}
+
+	if err != nil {
+		result.Error = txt.Clip(err.Error(), 512)
+	}
+
+	return result
+}
+
+// Create inserts a new row to the database.
+func (m *FileConversion) Create() error {
+	return Db().Create(m).Error
+}
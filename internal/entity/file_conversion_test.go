@@ -0,0 +1,37 @@
+package entity
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFileConversion_TableName(t *testing.T) {
+	m := &FileConversion{}
+	assert.Equal(t, "files_conversion", m.TableName())
+}
+
+func TestNewFileConversion(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		m := NewFileConversion("2020/IMG_1234.CR2", "raw", "darktable", time.Second, "done", nil)
+
+		assert.True(t, m.Success)
+		assert.Equal(t, "", m.Error)
+		assert.Equal(t, "done", m.Output)
+		assert.Equal(t, time.Second, m.Duration)
+	})
+	t.Run("error", func(t *testing.T) {
+		m := NewFileConversion("2020/IMG_1234.CR2", "raw", "rawtherapee", time.Second, "", errors.New("unsupported camera"))
+
+		assert.False(t, m.Success)
+		assert.Equal(t, "unsupported camera", m.Error)
+
+		if err := m.Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.NotEmpty(t, m.ID)
+	})
+}
@@ -153,6 +153,7 @@ var FileFixtures = map[string]File{
 		FileLuminance:   "DC42844C8",
 		FileDiff:        986,
 		FileChroma:      32,
+		FilePHash:       "0f1e3c78f0e1c387",
 		FileNotes:       "",
 		FileError:       "",
 		Share:           []FileShare{},
@@ -337,6 +338,13 @@ var FileFixtures = map[string]File{
 		FileSidecar:     false,
 		FileVideo:       true,
 		FileMissing:     false,
+		FileCodec:       "avc1",
+		FileFPS:         59.94,
+		FileBitrate:     45448471,
+		FileAudioCodec:  "aac",
+		FileTransfer:    "arib-std-b67",
+		FileHDR:         true,
+		FileStreams:     2,
 		FileDuplicate:   false,
 		FilePortrait:    false,
 		FileWidth:       1200,
@@ -426,6 +434,7 @@ var FileFixtures = map[string]File{
 		FileLuminance:   "DC42844C8",
 		FileDiff:        986,
 		FileChroma:      32,
+		FilePHash:       "c3c3e1f0f0e0c0c0",
 		FileNotes:       "",
 		FileError:       "",
 		Share:           []FileShare{},
@@ -465,6 +474,7 @@ var FileFixtures = map[string]File{
 		FileLuminance:   "DC42844C8",
 		FileDiff:        986,
 		FileChroma:      32,
+		FilePHash:       "c3c3e1f0f0e0c0c1",
 		FileNotes:       "",
 		FileError:       "",
 		Share:           []FileShare{},
@@ -515,6 +525,86 @@ var FileFixtures = map[string]File{
 		UpdatedIn:       0,
 		DeletedAt:       nil,
 	},
+	"IMG_0020.jpg": {
+		ID:              1000013,
+		Photo:           PhotoFixtures.Pointer("Photo20"),
+		PhotoID:         PhotoFixtures.Pointer("Photo20").ID,
+		PhotoUID:        PhotoFixtures.Pointer("Photo20").PhotoUID,
+		FileUID:         "ft2es49whhbnlqe1",
+		FileName:        "2019/07/IMG_0020.jpg",
+		OriginalName:    "",
+		FileHash:        "2cad9168fa6acc5c5c2965ddf6ec465ca42fd820",
+		FileModified:    time.Date(2019, 7, 1, 12, 0, 0, 0, time.UTC),
+		FileSize:        1548012,
+		FileType:        "jpg",
+		FileMime:        "image/jpeg",
+		FilePrimary:     true,
+		FileSidecar:     false,
+		FileVideo:       false,
+		FileMotion:      false,
+		FileMissing:     false,
+		FileDuplicate:   false,
+		FilePortrait:    true,
+		FileWidth:       3024,
+		FileHeight:      4032,
+		FileOrientation: 1,
+		FileAspectRatio: 0.75,
+		FileMainColor:   "blue",
+		FileColors:      "",
+		FileLuminance:   "",
+		FileDiff:        0,
+		FileChroma:      0,
+		FileNotes:       "",
+		FileError:       "",
+		Share:           []FileShare{},
+		Sync:            []FileSync{},
+		Links:           []Link{},
+		CreatedAt:       time.Date(2019, 7, 1, 12, 0, 0, 0, time.UTC),
+		CreatedIn:       2,
+		UpdatedAt:       time.Date(2019, 7, 1, 12, 0, 0, 0, time.UTC),
+		UpdatedIn:       0,
+		DeletedAt:       nil,
+	},
+	"IMG_0020.mov": {
+		ID:              1000014,
+		Photo:           PhotoFixtures.Pointer("Photo20"),
+		PhotoID:         PhotoFixtures.Pointer("Photo20").ID,
+		PhotoUID:        PhotoFixtures.Pointer("Photo20").PhotoUID,
+		FileUID:         "ft2es49whhbnlqe2",
+		FileName:        "2019/07/IMG_0020.mov",
+		OriginalName:    "",
+		FileHash:        "2cad9168fa6acc5c5c2965ddf6ec465ca42fd821",
+		FileModified:    time.Date(2019, 7, 1, 12, 0, 0, 0, time.UTC),
+		FileSize:        2315712,
+		FileType:        "mov",
+		FileMime:        "video/quicktime",
+		FilePrimary:     false,
+		FileSidecar:     false,
+		FileVideo:       true,
+		FileMotion:      true,
+		FileMissing:     false,
+		FileDuplicate:   false,
+		FilePortrait:    true,
+		FileWidth:       3024,
+		FileHeight:      4032,
+		FileOrientation: 1,
+		FileAspectRatio: 0.75,
+		FileMainColor:   "blue",
+		FileColors:      "",
+		FileLuminance:   "",
+		FileDiff:        0,
+		FileChroma:      0,
+		FileNotes:       "",
+		FileError:       "",
+		Share:           []FileShare{},
+		Sync:            []FileSync{},
+		Links:           []Link{},
+		CreatedAt:       time.Date(2019, 7, 1, 12, 0, 0, 0, time.UTC),
+		CreatedIn:       2,
+		UpdatedAt:       time.Date(2019, 7, 1, 12, 0, 0, 0, time.UTC),
+		UpdatedIn:       0,
+		DeletedAt:       nil,
+	},
 }
 
 var FileFixturesExampleJPG = FileFixtures["exampleFileName.jpg"]
@@ -0,0 +1,57 @@
+package entity
+
+import (
+	"time"
+)
+
+// FileMismatch represents an original file whose content no longer matches the hash stored in the index.
+type FileMismatch struct {
+	ID         uint      `gorm:"primary_key" json:"ID" yaml:"-"`
+	FileID     uint      `gorm:"index;" json:"-" yaml:"-"`
+	FileUID    string    `gorm:"type:varbinary(36);index;" json:"FileUID" yaml:"FileUID"`
+	FileName   string    `gorm:"type:varbinary(768);" json:"FileName" yaml:"FileName"`
+	FileRoot   string    `gorm:"type:varbinary(16);" json:"FileRoot" yaml:"FileRoot"`
+	FileHash   string    `gorm:"type:varbinary(128);" json:"FileHash" yaml:"FileHash"`
+	FileSize   int64     `json:"FileSize" yaml:"FileSize"`
+	ActualHash string    `gorm:"type:varbinary(128);" json:"ActualHash" yaml:"ActualHash,omitempty"`
+	ActualSize int64     `json:"ActualSize" yaml:"ActualSize,omitempty"`
+	Error      string    `gorm:"type:varbinary(512);" json:"Error" yaml:"Error,omitempty"`
+	DetectedAt time.Time `json:"DetectedAt" yaml:"DetectedAt"`
+	CreatedAt  time.Time `json:"CreatedAt" yaml:"-"`
+	UpdatedAt  time.Time `json:"UpdatedAt" yaml:"-"`
+}
+
+// FileMismatches represents a list of file mismatches.
+type FileMismatches []FileMismatch
+
+// TableName returns the entity database table name.
+func (FileMismatch) TableName() string {
+	return "files_mismatch"
+}
+
+// NewFileMismatch creates a new entity for a file whose actual hash and size differ from the index.
+func NewFileMismatch(file File, actualHash string, actualSize int64) *FileMismatch {
+	result := &FileMismatch{
+		FileID:     file.ID,
+		FileUID:    file.FileUID,
+		FileName:   file.FileName,
+		FileRoot:   file.FileRoot,
+		FileHash:   file.FileHash,
+		FileSize:   file.FileSize,
+		ActualHash: actualHash,
+		ActualSize: actualSize,
+		DetectedAt: time.Now().UTC(),
+	}
+
+	return result
+}
+
+// FirstOrCreate returns the matching entity or creates a new one, so that a
+// mismatch is only reported once per file and actual hash.
+func (m *FileMismatch) FirstOrCreate() *FileMismatch {
+	if err := Db().FirstOrCreate(m, "file_uid = ? AND actual_hash = ? AND error = ?", m.FileUID, m.ActualHash, m.Error).Error; err != nil {
+		log.Errorf("file mismatch: %s", err)
+	}
+
+	return m
+}
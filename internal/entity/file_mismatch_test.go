@@ -0,0 +1,36 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFileMismatch_TableName(t *testing.T) {
+	m := &FileMismatch{}
+	assert.Equal(t, "files_mismatch", m.TableName())
+}
+
+func TestNewFileMismatch(t *testing.T) {
+	file := FileFixturesExampleJPG
+	r := NewFileMismatch(file, "abc123", 999)
+
+	assert.IsType(t, &FileMismatch{}, r)
+	assert.Equal(t, file.FileUID, r.FileUID)
+	assert.Equal(t, file.FileName, r.FileName)
+	assert.Equal(t, file.FileHash, r.FileHash)
+	assert.Equal(t, "abc123", r.ActualHash)
+	assert.Equal(t, int64(999), r.ActualSize)
+	assert.False(t, r.DetectedAt.IsZero())
+}
+
+func TestFileMismatch_FirstOrCreate(t *testing.T) {
+	m := NewFileMismatch(FileFixturesExampleJPG, "3cad9168fa6acc5c5c2965ddf6ec465ca42fd818", 1000)
+	first := m.FirstOrCreate()
+
+	assert.NotEmpty(t, first.ID)
+
+	second := NewFileMismatch(FileFixturesExampleJPG, "3cad9168fa6acc5c5c2965ddf6ec465ca42fd818", 1000).FirstOrCreate()
+
+	assert.Equal(t, first.ID, second.ID)
+}
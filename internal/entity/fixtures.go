@@ -19,4 +19,8 @@ func CreateTestFixtures() {
 	CreateFileShareFixtures()
 	CreateFileSyncFixtures()
 	CreateLensFixtures()
+	CreatePersonFixtures()
+	CreatePhotoPersonFixtures()
+	CreateStackFixtures()
+	CreateJobFixtures()
 }
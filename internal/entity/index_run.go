@@ -0,0 +1,115 @@
+package entity
+
+import (
+	"time"
+)
+
+const (
+	IndexRunning   = "running"
+	IndexCompleted = "completed"
+	IndexCanceled  = "canceled"
+)
+
+// IndexRun represents an indexer run and its progress, so that interrupted runs can be resumed.
+type IndexRun struct {
+	ID            uint   `gorm:"primary_key"`
+	RunPath       string `gorm:"type:varbinary(768);index;"`
+	RunRescan     bool
+	RunConvert    bool
+	RunStatus     string `gorm:"type:varbinary(16);"`
+	RunCheckpoint string `gorm:"type:varbinary(768);"`
+	FilesIndexed  int
+	FoldersDone   int
+	StartedAt     time.Time
+	CompletedAt   *time.Time
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+// TableName returns the entity database table name.
+func (IndexRun) TableName() string {
+	return "index_runs"
+}
+
+// NewIndexRun creates a new entity for the given path and options.
+func NewIndexRun(path string, rescan, convert bool) *IndexRun {
+	result := &IndexRun{
+		RunPath:    path,
+		RunRescan:  rescan,
+		RunConvert: convert,
+		RunStatus:  IndexRunning,
+		StartedAt:  time.Now().UTC(),
+	}
+
+	return result
+}
+
+// Create inserts a new row to the database.
+func (m *IndexRun) Create() error {
+	return Db().Create(m).Error
+}
+
+// Save updates the existing row or inserts a new one.
+func (m *IndexRun) Save() error {
+	return Db().Save(m).Error
+}
+
+// Checkpoint stores the last completed folder and the current counters.
+func (m *IndexRun) Checkpoint(folder string, files, folders int) error {
+	m.RunCheckpoint = folder
+	m.FilesIndexed = files
+	m.FoldersDone = folders
+
+	return Db().Model(m).Updates(map[string]interface{}{
+		"run_checkpoint": m.RunCheckpoint,
+		"files_indexed":  m.FilesIndexed,
+		"folders_done":   m.FoldersDone,
+	}).Error
+}
+
+// Finish sets the final status of an index run.
+func (m *IndexRun) Finish(status string) error {
+	m.RunStatus = status
+
+	if status == IndexCompleted {
+		now := time.Now().UTC()
+		m.CompletedAt = &now
+	}
+
+	return Db().Model(m).Updates(map[string]interface{}{
+		"run_status":     m.RunStatus,
+		"completed_at":   m.CompletedAt,
+		"run_checkpoint": m.RunCheckpoint,
+		"files_indexed":  m.FilesIndexed,
+		"folders_done":   m.FoldersDone,
+	}).Error
+}
+
+// FindIndexRun returns the most recent run with the same path and options if it was interrupted,
+// or nil if there is none or it has completed.
+func FindIndexRun(path string, rescan, convert bool) *IndexRun {
+	result := IndexRun{}
+
+	if err := Db().Where("run_path = ? AND run_rescan = ? AND run_convert = ?", path, rescan, convert).
+		Order("id DESC").First(&result).Error; err != nil {
+		return nil
+	}
+
+	if result.RunStatus == IndexCompleted {
+		return nil
+	}
+
+	return &result
+}
+
+// LastIndexRun returns the most recent completed run with the given path, or nil if there is none.
+func LastIndexRun(path string) *IndexRun {
+	result := IndexRun{}
+
+	if err := Db().Where("run_path = ? AND run_status = ?", path, IndexCompleted).
+		Order("id DESC").First(&result).Error; err != nil {
+		return nil
+	}
+
+	return &result
+}
@@ -0,0 +1,133 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIndexRun_TableName(t *testing.T) {
+	run := &IndexRun{}
+	assert.Equal(t, "index_runs", run.TableName())
+}
+
+func TestNewIndexRun(t *testing.T) {
+	r := NewIndexRun("2020/vacation", true, false)
+	assert.IsType(t, &IndexRun{}, r)
+	assert.Equal(t, "2020/vacation", r.RunPath)
+	assert.True(t, r.RunRescan)
+	assert.False(t, r.RunConvert)
+	assert.Equal(t, IndexRunning, r.RunStatus)
+	assert.Nil(t, r.CompletedAt)
+}
+
+func TestIndexRun_Checkpoint(t *testing.T) {
+	run := NewIndexRun("checkpoint", false, true)
+
+	if err := run.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := run.Checkpoint("checkpoint/2019", 25, 3); err != nil {
+		t.Fatal(err)
+	}
+
+	found := FindIndexRun("checkpoint", false, true)
+
+	if found == nil {
+		t.Fatal("index run should not be nil")
+	}
+
+	assert.Equal(t, run.ID, found.ID)
+	assert.Equal(t, "checkpoint/2019", found.RunCheckpoint)
+	assert.Equal(t, 25, found.FilesIndexed)
+	assert.Equal(t, 3, found.FoldersDone)
+}
+
+func TestIndexRun_Finish(t *testing.T) {
+	t.Run("canceled", func(t *testing.T) {
+		run := NewIndexRun("finish", false, false)
+
+		if err := run.Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := run.Finish(IndexCanceled); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Nil(t, run.CompletedAt)
+
+		found := FindIndexRun("finish", false, false)
+
+		if found == nil {
+			t.Fatal("index run should not be nil")
+		}
+
+		assert.Equal(t, IndexCanceled, found.RunStatus)
+	})
+	t.Run("completed", func(t *testing.T) {
+		run := NewIndexRun("finish", false, false)
+
+		if err := run.Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := run.Finish(IndexCompleted); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.NotNil(t, run.CompletedAt)
+
+		// Older canceled runs must not be resumed after a newer run completed.
+		assert.Nil(t, FindIndexRun("finish", false, false))
+
+		next := NewIndexRun("finish", false, false)
+
+		if err := next.Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := next.Finish(IndexCanceled); err != nil {
+			t.Fatal(err)
+		}
+
+		found := FindIndexRun("finish", false, false)
+
+		if found == nil {
+			t.Fatal("index run should not be nil")
+		}
+
+		assert.Equal(t, next.ID, found.ID)
+	})
+	t.Run("not found", func(t *testing.T) {
+		assert.Nil(t, FindIndexRun("finish", true, true))
+	})
+}
+
+func TestLastIndexRun(t *testing.T) {
+	t.Run("found", func(t *testing.T) {
+		run := NewIndexRun("last", true, false)
+		run.FilesIndexed = 42
+
+		if err := run.Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := run.Finish(IndexCompleted); err != nil {
+			t.Fatal(err)
+		}
+
+		found := LastIndexRun("last")
+
+		if found == nil {
+			t.Fatal("index run should not be nil")
+		}
+
+		assert.Equal(t, run.ID, found.ID)
+		assert.Equal(t, 42, found.FilesIndexed)
+	})
+	t.Run("not found", func(t *testing.T) {
+		assert.Nil(t, LastIndexRun("last/missing"))
+	})
+}
@@ -0,0 +1,275 @@
+package entity
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/jinzhu/gorm"
+	"github.com/photoprism/photoprism/pkg/rnd"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// Job types.
+const (
+	JobIndex     = "index"
+	JobImport    = "import"
+	JobPurge     = "purge"
+	JobConvert   = "convert"
+	JobResample  = "resample"
+	JobTranscode = "transcode"
+	JobGeotag    = "geotag"
+	JobTimeshift = "timeshift"
+	JobRename    = "rename"
+)
+
+// Job states.
+const (
+	JobQueued   = "queued"
+	JobRunning  = "running"
+	JobFailed   = "failed"
+	JobDone     = "done"
+	JobCanceled = "canceled"
+)
+
+// Job priorities, jobs with a higher priority run first.
+const (
+	JobPriorityLow     = -10
+	JobPriorityDefault = 0
+	JobPriorityHigh    = 10
+)
+
+// JobRetries is the default number of times a failed job is retried.
+var JobRetries = 3
+
+// JobRetryDelay is multiplied by the number of attempts to get the delay before a failed job is retried.
+var JobRetryDelay = time.Minute
+
+// Job represents a queued background task like indexing or importing files.
+type Job struct {
+	ID          uint       `gorm:"primary_key" json:"-" yaml:"-"`
+	JobUID      string     `gorm:"type:varbinary(36);unique_index;" json:"UID" yaml:"UID"`
+	JobType     string     `gorm:"type:varbinary(16);index;" json:"Type" yaml:"Type"`
+	JobPriority int        `json:"Priority" yaml:"Priority"`
+	JobStatus   string     `gorm:"type:varbinary(16);index;" json:"Status" yaml:"Status"`
+	JobOptions  string     `gorm:"type:text;" json:"Options" yaml:"Options,omitempty"`
+	JobError    string     `gorm:"type:varbinary(512);" json:"Error" yaml:"Error,omitempty"`
+	JobAttempts int        `json:"Attempts" yaml:"Attempts"`
+	JobRetries  int        `json:"Retries" yaml:"Retries"`
+	JobCancel   bool       `json:"Cancel" yaml:"Cancel,omitempty"`
+	RunAfter    time.Time  `json:"RunAfter" yaml:"-"`
+	StartedAt   *time.Time `json:"StartedAt" yaml:"-"`
+	FinishedAt  *time.Time `json:"FinishedAt" yaml:"-"`
+	CreatedAt   time.Time  `json:"CreatedAt" yaml:"-"`
+	UpdatedAt   time.Time  `json:"UpdatedAt" yaml:"-"`
+}
+
+// Jobs represents a list of jobs.
+type Jobs []Job
+
+// TableName returns the entity database table name.
+func (Job) TableName() string {
+	return "jobs"
+}
+
+// BeforeCreate creates a random UID if needed before inserting a new row to the database.
+func (m *Job) BeforeCreate(scope *gorm.Scope) error {
+	if rnd.IsPPID(m.JobUID, 'q') {
+		return nil
+	}
+
+	return scope.SetColumn("JobUID", rnd.PPID('q'))
+}
+
+// NewJob returns a new queued job of the given type with the options serialized as JSON.
+func NewJob(jobType string, priority int, options interface{}) (*Job, error) {
+	data, err := json.Marshal(options)
+
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Job{
+		JobType:     jobType,
+		JobPriority: priority,
+		JobStatus:   JobQueued,
+		JobOptions:  string(data),
+		JobRetries:  JobRetries,
+		RunAfter:    time.Now().UTC(),
+	}
+
+	// A partial time shift can't be repeated and geotag track files are only kept
+	// while the request is waiting for the job, so these are not retried.
+	switch jobType {
+	case JobTimeshift, JobGeotag:
+		result.JobRetries = 0
+	}
+
+	return result, nil
+}
+
+// FindJob returns the job with the given UID or nil if it doesn't exist.
+func FindJob(uid string) *Job {
+	result := Job{}
+
+	if err := Db().Where("job_uid = ?", uid).First(&result).Error; err != nil {
+		return nil
+	}
+
+	return &result
+}
+
+// Create inserts a new row to the database.
+func (m *Job) Create() error {
+	return Db().Create(m).Error
+}
+
+// Enqueue adds the job to the queue. If an identical job is already queued, it is
+// returned instead and its priority is raised if needed.
+func (m *Job) Enqueue() error {
+	existing := Job{}
+
+	if err := Db().Where("job_type = ? AND job_options = ? AND job_status = ?", m.JobType, m.JobOptions, JobQueued).First(&existing).Error; err != nil {
+		return m.Create()
+	}
+
+	if m.JobPriority > existing.JobPriority {
+		if err := Db().Model(&existing).UpdateColumn("job_priority", m.JobPriority).Error; err != nil {
+			return err
+		}
+	}
+
+	*m = existing
+
+	return nil
+}
+
+// Options unmarshals the job options.
+func (m *Job) Options(v interface{}) error {
+	return json.Unmarshal([]byte(m.JobOptions), v)
+}
+
+// Finished tests if the job is done, failed or canceled.
+func (m *Job) Finished() bool {
+	switch m.JobStatus {
+	case JobDone, JobFailed, JobCanceled:
+		return true
+	default:
+		return false
+	}
+}
+
+// Claim marks a queued job as running and returns false if it was claimed by another worker first.
+func (m *Job) Claim() bool {
+	now := time.Now().UTC()
+
+	result := Db().Model(&Job{}).Where("id = ? AND job_status = ?", m.ID, JobQueued).UpdateColumns(map[string]interface{}{
+		"job_status":   JobRunning,
+		"job_attempts": gorm.Expr("job_attempts + 1"),
+		"job_error":    "",
+		"started_at":   now,
+	})
+
+	if result.Error != nil {
+		log.Errorf("job: %s", result.Error)
+		return false
+	} else if result.RowsAffected != 1 {
+		return false
+	}
+
+	m.JobStatus = JobRunning
+	m.JobAttempts++
+	m.JobError = ""
+	m.StartedAt = &now
+
+	return true
+}
+
+// CancelRequested tests if cancellation of the running job was requested.
+func (m *Job) CancelRequested() bool {
+	result := Job{}
+
+	if err := Db().Select("job_cancel").Where("id = ?", m.ID).First(&result).Error; err != nil {
+		return false
+	}
+
+	return result.JobCancel
+}
+
+// Cancel cancels a queued job, or requests cancellation if it is already running.
+func (m *Job) Cancel() error {
+	now := time.Now().UTC()
+
+	result := Db().Model(&Job{}).Where("id = ? AND job_status = ?", m.ID, JobQueued).UpdateColumns(map[string]interface{}{
+		"job_status":  JobCanceled,
+		"job_cancel":  true,
+		"finished_at": now,
+	})
+
+	if result.Error != nil {
+		return result.Error
+	} else if result.RowsAffected == 1 {
+		m.JobStatus = JobCanceled
+		m.JobCancel = true
+		m.FinishedAt = &now
+		return nil
+	}
+
+	result = Db().Model(&Job{}).Where("id = ? AND job_status = ?", m.ID, JobRunning).UpdateColumn("job_cancel", true)
+
+	if result.Error != nil {
+		return result.Error
+	} else if result.RowsAffected == 1 {
+		m.JobStatus = JobRunning
+		m.JobCancel = true
+		return nil
+	}
+
+	if job := FindJob(m.JobUID); job != nil {
+		*m = *job
+	}
+
+	return fmt.Errorf("job %s is already %s", m.JobUID, m.JobStatus)
+}
+
+// Finish updates the state of a running job. Failed jobs are queued again with a delay
+// until the number of retries is exhausted.
+func (m *Job) Finish(err error, canceled bool) error {
+	now := time.Now().UTC()
+
+	values := map[string]interface{}{
+		"job_error": "",
+	}
+
+	switch {
+	case canceled:
+		m.JobStatus = JobCanceled
+	case err == nil:
+		m.JobStatus = JobDone
+	case m.JobAttempts <= m.JobRetries:
+		m.JobStatus = JobQueued
+		m.RunAfter = now.Add(time.Duration(m.JobAttempts) * JobRetryDelay)
+		values["run_after"] = m.RunAfter
+	default:
+		m.JobStatus = JobFailed
+	}
+
+	if err != nil {
+		m.JobError = txt.Clip(err.Error(), 512)
+		values["job_error"] = m.JobError
+	}
+
+	if m.JobStatus != JobQueued {
+		m.FinishedAt = &now
+		values["finished_at"] = now
+	}
+
+	values["job_status"] = m.JobStatus
+
+	return Db().Model(&Job{}).Where("id = ?", m.ID).UpdateColumns(values).Error
+}
+
+// RequeueRunningJobs queues jobs again that were interrupted, e.g. by a restart.
+func RequeueRunningJobs() error {
+	return Db().Model(&Job{}).Where("job_status = ?", JobRunning).UpdateColumn("job_status", JobQueued).Error
+}
@@ -0,0 +1,60 @@
+package entity
+
+import (
+	"time"
+)
+
+type JobMap map[string]Job
+
+func (m JobMap) Get(name string) Job {
+	if result, ok := m[name]; ok {
+		return result
+	}
+
+	return Job{JobType: JobIndex, JobStatus: JobQueued}
+}
+
+func (m JobMap) Pointer(name string) *Job {
+	if result, ok := m[name]; ok {
+		return &result
+	}
+
+	return &Job{JobType: JobIndex, JobStatus: JobQueued}
+}
+
+var JobFixtures = JobMap{
+	"retry": {
+		ID:          1000000,
+		JobUID:      "qqcwk7ew9ss5xjb1",
+		JobType:     JobPurge,
+		JobPriority: JobPriorityLow,
+		JobStatus:   JobQueued,
+		JobOptions:  `{"Path":"/fixtures"}`,
+		JobError:    "purge: already running",
+		JobAttempts: 1,
+		JobRetries:  3,
+		RunAfter:    time.Date(2099, 7, 1, 12, 0, 0, 0, time.UTC),
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
+	},
+	"done": {
+		ID:          1000001,
+		JobUID:      "qqcwk7ew9ss5xjb2",
+		JobType:     JobIndex,
+		JobPriority: JobPriorityDefault,
+		JobStatus:   JobDone,
+		JobOptions:  `{"Path":"/","Rescan":false,"Convert":true,"Resume":false}`,
+		JobAttempts: 1,
+		JobRetries:  3,
+		RunAfter:    time.Date(2020, 7, 1, 12, 0, 0, 0, time.UTC),
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
+	},
+}
+
+// CreateJobFixtures inserts known entities into the database for testing.
+func CreateJobFixtures() {
+	for _, entity := range JobFixtures {
+		Db().Create(&entity)
+	}
+}
@@ -0,0 +1,178 @@
+package entity
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/photoprism/photoprism/pkg/rnd"
+	"github.com/stretchr/testify/assert"
+)
+
+type jobTestOptions struct {
+	Path  string
+	Force bool
+}
+
+func TestJob_TableName(t *testing.T) {
+	job := &Job{}
+	assert.Equal(t, "jobs", job.TableName())
+}
+
+func TestNewJob(t *testing.T) {
+	job, err := NewJob(JobConvert, JobPriorityHigh, jobTestOptions{Path: "2020"})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, JobConvert, job.JobType)
+	assert.Equal(t, JobPriorityHigh, job.JobPriority)
+	assert.Equal(t, JobQueued, job.JobStatus)
+	assert.Equal(t, `{"Path":"2020","Force":false}`, job.JobOptions)
+	assert.Equal(t, JobRetries, job.JobRetries)
+
+	var opt jobTestOptions
+
+	if err := job.Options(&opt); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, "2020", opt.Path)
+
+	t.Run("timeshift", func(t *testing.T) {
+		job, err := NewJob(JobTimeshift, JobPriorityDefault, jobTestOptions{})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, 0, job.JobRetries)
+	})
+}
+
+func TestFindJob(t *testing.T) {
+	t.Run("found", func(t *testing.T) {
+		job := FindJob("qqcwk7ew9ss5xjb2")
+
+		if job == nil {
+			t.Fatal("job should not be nil")
+		}
+
+		assert.Equal(t, JobIndex, job.JobType)
+		assert.True(t, job.Finished())
+	})
+	t.Run("not found", func(t *testing.T) {
+		assert.Nil(t, FindJob("qqcwk7ew9ss5xxxx"))
+	})
+}
+
+func TestJob_Enqueue(t *testing.T) {
+	first, err := NewJob(JobConvert, JobPriorityLow, jobTestOptions{Path: "enqueue"})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := first.Enqueue(); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.True(t, rnd.IsPPID(first.JobUID, 'q'))
+
+	second, err := NewJob(JobConvert, JobPriorityHigh, jobTestOptions{Path: "enqueue"})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := second.Enqueue(); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, first.JobUID, second.JobUID)
+	assert.Equal(t, JobPriorityHigh, FindJob(first.JobUID).JobPriority)
+
+	if err := first.Cancel(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestJob_Finish(t *testing.T) {
+	run := func(t *testing.T, retries int) *Job {
+		job, err := NewJob(JobResample, JobPriorityDefault, jobTestOptions{Path: t.Name()})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		job.JobRetries = retries
+
+		if err := job.Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.True(t, job.Claim())
+		assert.False(t, job.Claim())
+		assert.Equal(t, JobRunning, job.JobStatus)
+		assert.Equal(t, 1, job.JobAttempts)
+
+		return job
+	}
+
+	t.Run("done", func(t *testing.T) {
+		job := run(t, 0)
+
+		if err := job.Finish(nil, false); err != nil {
+			t.Fatal(err)
+		}
+
+		result := FindJob(job.JobUID)
+
+		assert.Equal(t, JobDone, result.JobStatus)
+		assert.NotNil(t, result.FinishedAt)
+	})
+	t.Run("retry", func(t *testing.T) {
+		job := run(t, 1)
+
+		if err := job.Finish(errors.New("already running"), false); err != nil {
+			t.Fatal(err)
+		}
+
+		result := FindJob(job.JobUID)
+
+		assert.Equal(t, JobQueued, result.JobStatus)
+		assert.Equal(t, "already running", result.JobError)
+		assert.Nil(t, result.FinishedAt)
+		assert.True(t, result.RunAfter.After(*result.StartedAt))
+	})
+	t.Run("failed", func(t *testing.T) {
+		job := run(t, 0)
+
+		if err := job.Finish(errors.New("file not found"), false); err != nil {
+			t.Fatal(err)
+		}
+
+		result := FindJob(job.JobUID)
+
+		assert.Equal(t, JobFailed, result.JobStatus)
+		assert.Equal(t, "file not found", result.JobError)
+	})
+	t.Run("canceled", func(t *testing.T) {
+		job := run(t, 0)
+
+		if err := job.Cancel(); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.True(t, job.CancelRequested())
+
+		if err := job.Finish(nil, true); err != nil {
+			t.Fatal(err)
+		}
+
+		result := FindJob(job.JobUID)
+
+		assert.Equal(t, JobCanceled, result.JobStatus)
+		assert.Error(t, result.Cancel())
+	})
+}
@@ -1,7 +1,7 @@
 package entity
 
 import (
-	"strings"
+	"errors"
 	"time"
 
 	"github.com/gosimple/slug"
@@ -39,8 +39,8 @@ func (Lens) TableName() string {
 
 // NewLens creates a new lens in database
 func NewLens(modelName string, makeName string) *Lens {
-	modelName = strings.TrimSpace(modelName)
-	makeName = strings.TrimSpace(makeName)
+	modelName = NormalizeLensModel(modelName, makeName)
+	makeName = NormalizeCameraMake(makeName)
 	lensSlug := slug.MakeLang(modelName, "en")
 
 	if modelName == "" {
@@ -71,6 +71,8 @@ func FirstOrCreateLens(m *Lens) *Lens {
 
 	if err := Db().Where("lens_slug = ?", m.LensSlug).First(&result).Error; err == nil {
 		return &result
+	} else if err := Db().Where("id IN (SELECT lens_id FROM lens_aliases WHERE alias_slug = ?)", m.LensSlug).First(&result).Error; err == nil {
+		return &result
 	} else if err := m.Create(); err != nil {
 		log.Errorf("lens: %s", err)
 		return nil
@@ -78,3 +80,44 @@ func FirstOrCreateLens(m *Lens) *Lens {
 
 	return m
 }
+
+// Merge moves all photos of other lenses to this lens and deletes them. Their slugs
+// are kept as aliases, so that new photos taken with these lenses are added to this lens.
+func (m *Lens) Merge(others []Lens) error {
+	if m.ID == 0 || m.LensSlug == UnknownLens.LensSlug {
+		return errors.New("lens: can't merge into unknown lens")
+	}
+
+	tx := Db().Begin()
+
+	for _, other := range others {
+		if other.ID == m.ID {
+			continue
+		} else if other.LensSlug == UnknownLens.LensSlug {
+			tx.Rollback()
+			return errors.New("lens: can't merge unknown lens")
+		}
+
+		if err := tx.Unscoped().Model(&Photo{}).Where("lens_id = ?", other.ID).UpdateColumn("lens_id", m.ID).Error; err != nil {
+			tx.Rollback()
+			return err
+		}
+
+		if err := tx.Model(&LensAlias{}).Where("lens_id = ?", other.ID).UpdateColumn("lens_id", m.ID).Error; err != nil {
+			tx.Rollback()
+			return err
+		}
+
+		if err := tx.Save(NewLensAlias(other.LensSlug, m.ID)).Error; err != nil {
+			tx.Rollback()
+			return err
+		}
+
+		if err := tx.Unscoped().Delete(&other).Error; err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	return tx.Commit().Error
+}
@@ -0,0 +1,26 @@
+package entity
+
+import (
+	"time"
+)
+
+// LensAlias maps the slug of a merged lens to the lens it was merged into.
+type LensAlias struct {
+	AliasSlug string    `gorm:"type:varbinary(255);primary_key;auto_increment:false" json:"Slug" yaml:"Slug"`
+	LensID    uint      `gorm:"index;" json:"LensID" yaml:"-"`
+	CreatedAt time.Time `json:"-" yaml:"-"`
+	UpdatedAt time.Time `json:"-" yaml:"-"`
+}
+
+// TableName returns LensAlias table identifier "lens_aliases".
+func (LensAlias) TableName() string {
+	return "lens_aliases"
+}
+
+// NewLensAlias returns a new lens alias.
+func NewLensAlias(aliasSlug string, lensID uint) *LensAlias {
+	return &LensAlias{
+		AliasSlug: aliasSlug,
+		LensID:    lensID,
+	}
+}
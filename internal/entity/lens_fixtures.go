@@ -35,6 +35,30 @@ var LensFixtures = LensMap{
 		UpdatedAt:       time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC),
 		DeletedAt:       nil,
 	},
+	"ef24-105mm-f-4l-is-usm": {
+		ID:              1000001,
+		LensSlug:        "ef24-105mm-f-4l-is-usm",
+		LensModel:       "EF24-105mm f/4L IS USM",
+		LensMake:        "Canon",
+		LensType:        "",
+		LensDescription: "",
+		LensNotes:       "",
+		CreatedAt:       time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC),
+		UpdatedAt:       time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC),
+		DeletedAt:       nil,
+	},
+	"24-0-105-0-mm": {
+		ID:              1000002,
+		LensSlug:        "24-0-105-0-mm",
+		LensModel:       "24.0-105.0 mm",
+		LensMake:        "",
+		LensType:        "",
+		LensDescription: "",
+		LensNotes:       "",
+		CreatedAt:       time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC),
+		UpdatedAt:       time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC),
+		DeletedAt:       nil,
+	},
 }
 
 // CreateLensFixtures inserts known entities into the database for testing.
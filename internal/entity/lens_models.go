@@ -0,0 +1,63 @@
+package entity
+
+import (
+	"regexp"
+	"strings"
+)
+
+// LensModels maps lower case lens names as found in Exif metadata to normalized names.
+var LensModels = map[string]string{
+	"ef-s18-55mm f/3.5-5.6 is ii":               "EF-S18-55mm f/3.5-5.6 IS II",
+	"ef-s 18-55mm f/3.5-5.6 is ii":              "EF-S18-55mm f/3.5-5.6 IS II",
+	"ef24-105mm f/4l is usm":                    "EF24-105mm f/4L IS USM",
+	"ef 24-105mm f/4l is usm":                   "EF24-105mm f/4L IS USM",
+	"ef50mm f/1.8 stm":                          "EF50mm f/1.8 STM",
+	"ef 50mm f/1.8 stm":                         "EF50mm f/1.8 STM",
+	"fe 24-70mm f2.8 gm":                        "FE 24-70mm f/2.8 GM",
+	"fe 28-70mm f3.5-5.6 oss":                   "FE 28-70mm f/3.5-5.6 OSS",
+	"af-s dx nikkor 18-55mm f/3.5-5.6g vr":      "AF-S DX Nikkor 18-55mm f/3.5-5.6G VR",
+	"af-s dx vr zoom-nikkor 18-55mm f/3.5-5.6g": "AF-S DX Nikkor 18-55mm f/3.5-5.6G VR",
+	"af-s nikkor 50mm f/1.8g":                   "AF-S Nikkor 50mm f/1.8G",
+	"olympus m.12-40mm f2.8":                    "M.Zuiko 12-40mm f/2.8 Pro",
+	"m.zuiko digital ed 12-40mm f2.8 pro":       "M.Zuiko 12-40mm f/2.8 Pro",
+	"xf18-55mmf2.8-4 r lm ois":                  "XF 18-55mm f/2.8-4 R LM OIS",
+	"xf 18-55mm f/2.8-4 r lm ois":               "XF 18-55mm f/2.8-4 R LM OIS",
+}
+
+var (
+	lensDecimalZero = regexp.MustCompile(`(\d+)\.0+(\D|$)`)
+	lensMillimeter  = regexp.MustCompile(`(\d) mm\b`)
+	lensAperture    = regexp.MustCompile(`(\d)mm ?[fF]/?(\d)`)
+)
+
+// NormalizeLensModel returns the normalized lens model name, so that
+// names written by different firmware versions match.
+func NormalizeLensModel(modelName, makeName string) string {
+	modelName = strings.Join(strings.Fields(modelName), " ")
+
+	if modelName == "" {
+		return ""
+	}
+
+	if result, ok := LensModels[strings.ToLower(modelName)]; ok {
+		return result
+	}
+
+	// Remove the make prefix, e.g. "Canon EF50mm f/1.8 STM".
+	if makeName = NormalizeCameraMake(makeName); makeName != "" && len(modelName) > len(makeName) {
+		if strings.EqualFold(modelName[:len(makeName)], makeName) && modelName[len(makeName)] == ' ' {
+			modelName = strings.TrimSpace(modelName[len(makeName):])
+		}
+	}
+
+	// Focal length and aperture, e.g. "18.0-55.0 mm f3.5" becomes "18-55mm f/3.5".
+	modelName = lensDecimalZero.ReplaceAllString(modelName, "$1$2")
+	modelName = lensMillimeter.ReplaceAllString(modelName, "${1}mm")
+	modelName = lensAperture.ReplaceAllString(modelName, "${1}mm f/$2")
+
+	if result, ok := LensModels[strings.ToLower(modelName)]; ok {
+		return result
+	}
+
+	return modelName
+}
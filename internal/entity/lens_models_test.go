@@ -0,0 +1,27 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeLensModel(t *testing.T) {
+	t.Run("focal length", func(t *testing.T) {
+		assert.Equal(t, "24-105mm f/4", NormalizeLensModel("24.0-105.0 mm f/4.0", ""))
+		assert.Equal(t, "18-55mm f/3.5-5.6", NormalizeLensModel("18.0-55.0 mm f/3.5-5.6", "NIKON CORPORATION"))
+	})
+	t.Run("aperture", func(t *testing.T) {
+		assert.Equal(t, "FE 24-70mm f/2.8 GM", NormalizeLensModel("FE 24-70mm F2.8 GM", "SONY"))
+		assert.Equal(t, "XF 18-55mm f/2.8-4 R LM OIS", NormalizeLensModel("XF18-55mmF2.8-4 R LM OIS", "FUJIFILM"))
+	})
+	t.Run("make prefix", func(t *testing.T) {
+		assert.Equal(t, "EF50mm f/1.8 STM", NormalizeLensModel("Canon EF50mm f/1.8 STM", "Canon"))
+		assert.Equal(t, "EF24-105mm f/4L IS USM", NormalizeLensModel("EF 24-105mm f/4L IS USM", "Canon"))
+	})
+	t.Run("unchanged", func(t *testing.T) {
+		assert.Equal(t, "F380", NormalizeLensModel("F380", "Apple"))
+		assert.Equal(t, "iPhone SE back camera 4.15mm f/2.2", NormalizeLensModel("iPhone SE back camera 4.15mm f/2.2", "Apple"))
+		assert.Equal(t, "", NormalizeLensModel("  ", ""))
+	})
+}
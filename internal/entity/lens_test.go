@@ -13,6 +13,12 @@ func TestNewLens(t *testing.T) {
 		assert.Equal(t, "Canon", lens.LensMake)
 		assert.Equal(t, "f500-99", lens.LensSlug)
 	})
+	t.Run("name 24.0-70.0 mm make SONY", func(t *testing.T) {
+		lens := NewLens("24.0-70.0 mm F2.8", "SONY")
+		assert.Equal(t, "24-70mm f/2.8", lens.LensModel)
+		assert.Equal(t, "Sony", lens.LensMake)
+		assert.Equal(t, "24-70mm-f-2-8", lens.LensSlug)
+	})
 	t.Run("name Unknown make Unknown", func(t *testing.T) {
 		lens := NewLens("", "")
 		assert.Equal(t, "Unknown", lens.LensModel)
@@ -22,6 +28,57 @@ func TestNewLens(t *testing.T) {
 	})
 }
 
+func TestLens_Merge(t *testing.T) {
+	t.Run("ef24-105mm-f-4l-is-usm", func(t *testing.T) {
+		lens := LensFixtures.Get("ef24-105mm-f-4l-is-usm")
+		other := LensFixtures.Get("24-0-105-0-mm")
+
+		if err := lens.Merge([]Lens{other}); err != nil {
+			t.Fatal(err)
+		}
+
+		result := FirstOrCreateLens(&Lens{LensModel: other.LensModel, LensSlug: other.LensSlug})
+
+		if result == nil {
+			t.Fatal("result should not be nil")
+		}
+
+		assert.Equal(t, lens.ID, result.ID)
+	})
+	t.Run("archived photo", func(t *testing.T) {
+		lens := FirstOrCreateLens(NewLens("Merge Target", "Archive Test"))
+		other := FirstOrCreateLens(NewLens("Merge Source", "Archive Test"))
+		photo := &Photo{PhotoTitle: "Archived Merge Test", LensID: other.ID}
+
+		if err := Db().Create(photo).Error; err != nil {
+			t.Fatal(err)
+		}
+
+		if err := Db().Delete(photo).Error; err != nil {
+			t.Fatal(err)
+		}
+
+		if err := lens.Merge([]Lens{*other}); err != nil {
+			t.Fatal(err)
+		}
+
+		var result Photo
+
+		if err := Db().Unscoped().First(&result, photo.ID).Error; err != nil {
+			t.Fatal(err)
+		}
+
+		assert.NotNil(t, result.DeletedAt)
+		assert.Equal(t, lens.ID, result.LensID)
+	})
+	t.Run("unknown lens", func(t *testing.T) {
+		lens := LensFixtures.Get("ef24-105mm-f-4l-is-usm")
+
+		assert.Error(t, lens.Merge([]Lens{UnknownLens}))
+		assert.Error(t, UnknownLens.Merge([]Lens{lens}))
+	})
+}
+
 func TestLens_TableName(t *testing.T) {
 	lens := NewLens("F500-99", "Canon")
 	tableName := lens.TableName()
@@ -0,0 +1,188 @@
+package entity
+
+import (
+	"errors"
+	"time"
+
+	"github.com/gosimple/slug"
+	"github.com/jinzhu/gorm"
+	"github.com/photoprism/photoprism/pkg/rnd"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// Person represents a person shown in photos, e.g. as found in face regions.
+type Person struct {
+	ID             uint       `gorm:"primary_key" json:"ID" yaml:"-"`
+	PersonUID      string     `gorm:"type:varbinary(36);unique_index;" json:"UID" yaml:"UID"`
+	PersonSlug     string     `gorm:"type:varbinary(255);unique_index;" json:"Slug" yaml:"-"`
+	PersonName     string     `gorm:"type:varchar(255);" json:"Name" yaml:"Name"`
+	PersonSrc      string     `gorm:"type:varbinary(8);" json:"Src" yaml:"Src,omitempty"`
+	PersonFavorite bool       `json:"Favorite" yaml:"Favorite,omitempty"`
+	PersonNotes    string     `gorm:"type:text;" json:"Notes" yaml:"Notes,omitempty"`
+	PhotoCount     int        `gorm:"default:1" json:"PhotoCount" yaml:"-"`
+	CreatedAt      time.Time  `json:"CreatedAt" yaml:"-"`
+	UpdatedAt      time.Time  `json:"UpdatedAt" yaml:"-"`
+	DeletedAt      *time.Time `sql:"index" json:"DeletedAt,omitempty" yaml:"-"`
+	New            bool       `gorm:"-" json:"-" yaml:"-"`
+}
+
+// TableName returns Person table identifier "people".
+func (Person) TableName() string {
+	return "people"
+}
+
+// BeforeCreate creates a random UID if needed before inserting a new row to the database.
+func (m *Person) BeforeCreate(scope *gorm.Scope) error {
+	if rnd.IsPPID(m.PersonUID, 'j') {
+		return nil
+	}
+
+	return scope.SetColumn("PersonUID", rnd.PPID('j'))
+}
+
+// AfterCreate sets the New column used for database callback
+func (m *Person) AfterCreate(scope *gorm.Scope) error {
+	m.New = true
+	return nil
+}
+
+// NewPerson returns a new person entity with a given name and source.
+func NewPerson(name, source string) *Person {
+	personName := txt.Clip(name, txt.ClipDefault)
+
+	if personName == "" {
+		personName = "Unknown"
+	}
+
+	result := &Person{
+		PersonSlug: slug.Make(txt.Clip(personName, txt.ClipSlug)),
+		PersonName: personName,
+		PersonSrc:  source,
+		PhotoCount: 1,
+	}
+
+	return result
+}
+
+// FirstOrCreate checks if the person already exists in the database, also by former
+// slugs of renamed or merged people, so that they are not created again.
+func (m *Person) FirstOrCreate() *Person {
+	result := Person{}
+
+	if err := Db().Where("person_slug = ?", m.PersonSlug).First(&result).Error; err == nil {
+		return &result
+	} else if err := Db().Where("id IN (SELECT person_id FROM people_aliases WHERE alias_slug = ?)", m.PersonSlug).First(&result).Error; err == nil {
+		return &result
+	} else if err := Db().Create(m).Error; err != nil {
+		log.Errorf("person: %s", err)
+	}
+
+	return m
+}
+
+// SetName changes the person name.
+func (m *Person) SetName(name string) error {
+	newName := txt.Clip(name, txt.ClipDefault)
+
+	if newName == "" {
+		return errors.New("person: name must not be empty")
+	}
+
+	newSlug := slug.Make(txt.Clip(newName, txt.ClipSlug))
+
+	if newSlug != m.PersonSlug {
+		if err := Db().Where("person_slug = ? AND id <> ?", newSlug, m.ID).First(&Person{}).Error; err == nil {
+			return errors.New("person: name already exists, please merge instead")
+		} else if err := Db().Where("alias_slug = ? AND person_id <> ?", newSlug, m.ID).First(&PersonAlias{}).Error; err == nil {
+			return errors.New("person: name belongs to another person, please merge instead")
+		}
+	}
+
+	m.PersonName = newName
+	m.PersonSlug = newSlug
+
+	return nil
+}
+
+// Save updates the entity in the database. The former slug of a renamed person is kept as alias.
+func (m *Person) Save() error {
+	return Db().Transaction(func(tx *gorm.DB) error {
+		former := Person{}
+
+		if m.ID > 0 && tx.Select("person_slug").Where("id = ?", m.ID).First(&former).Error == nil &&
+			former.PersonSlug != "" && former.PersonSlug != m.PersonSlug {
+			if err := tx.Save(NewPersonAlias(former.PersonSlug, m.ID)).Error; err != nil {
+				return err
+			}
+		}
+
+		return tx.Save(m).Error
+	})
+}
+
+// Merge moves all photos of other people to this person and deletes them. Their slugs
+// are kept as aliases, so that they are not created again when photos are indexed.
+func (m *Person) Merge(others []Person) error {
+	err := Db().Transaction(func(tx *gorm.DB) error {
+		for _, other := range others {
+			if other.ID == m.ID {
+				continue
+			}
+
+			var photos []PhotoPerson
+
+			if err := tx.Where("person_id = ?", other.ID).Find(&photos).Error; err != nil {
+				return err
+			}
+
+			for _, pp := range photos {
+				existing := PhotoPerson{}
+
+				// Keep the existing relation if the photo already shows this person.
+				if err := tx.Where("photo_id = ? AND person_id = ?", pp.PhotoID, m.ID).First(&existing).Error; err == nil {
+					if !existing.HasRegion() && pp.HasRegion() {
+						existing.SetRegion(pp.RegionX, pp.RegionY, pp.RegionW, pp.RegionH)
+						existing.Photo = nil
+
+						if err := tx.Save(&existing).Error; err != nil {
+							return err
+						}
+					}
+
+					continue
+				}
+
+				pp.PersonID = m.ID
+				pp.Person = nil
+
+				if err := tx.Create(&pp).Error; err != nil {
+					return err
+				}
+			}
+
+			if err := tx.Where("person_id = ?", other.ID).Delete(&PhotoPerson{}).Error; err != nil {
+				return err
+			}
+
+			if err := tx.Model(&PersonAlias{}).Where("person_id = ?", other.ID).UpdateColumn("person_id", m.ID).Error; err != nil {
+				return err
+			}
+
+			if err := tx.Save(NewPersonAlias(other.PersonSlug, m.ID)).Error; err != nil {
+				return err
+			}
+
+			if err := tx.Unscoped().Delete(&other).Error; err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return err
+	}
+
+	return UpdatePhotoCounts()
+}
@@ -0,0 +1,26 @@
+package entity
+
+import (
+	"time"
+)
+
+// PersonAlias maps the former slug of a renamed or merged person to the person it now belongs to.
+type PersonAlias struct {
+	AliasSlug string    `gorm:"type:varbinary(255);primary_key;auto_increment:false" json:"Slug" yaml:"Slug"`
+	PersonID  uint      `gorm:"index;" json:"PersonID" yaml:"-"`
+	CreatedAt time.Time `json:"-" yaml:"-"`
+	UpdatedAt time.Time `json:"-" yaml:"-"`
+}
+
+// TableName returns PersonAlias table identifier "people_aliases".
+func (PersonAlias) TableName() string {
+	return "people_aliases"
+}
+
+// NewPersonAlias returns a new person alias.
+func NewPersonAlias(aliasSlug string, personID uint) *PersonAlias {
+	return &PersonAlias{
+		AliasSlug: aliasSlug,
+		PersonID:  personID,
+	}
+}
@@ -0,0 +1,69 @@
+package entity
+
+import (
+	"time"
+)
+
+type PersonMap map[string]Person
+
+func (m PersonMap) Get(name string) Person {
+	if result, ok := m[name]; ok {
+		return result
+	}
+
+	return *NewPerson(name, SrcManual)
+}
+
+func (m PersonMap) Pointer(name string) *Person {
+	if result, ok := m[name]; ok {
+		return &result
+	}
+
+	return NewPerson(name, SrcManual)
+}
+
+var PersonFixtures = PersonMap{
+	"jane-doe": {
+		ID:             1000000,
+		PersonUID:      "jt9k3pw1wowuy3c2",
+		PersonSlug:     "jane-doe",
+		PersonName:     "Jane Doe",
+		PersonSrc:      SrcXmp,
+		PersonFavorite: true,
+		PhotoCount:     1,
+		CreatedAt:      time.Now(),
+		UpdatedAt:      time.Now(),
+		DeletedAt:      nil,
+	},
+	"john-doe": {
+		ID:             1000001,
+		PersonUID:      "jt9k3pw1wowuy3c3",
+		PersonSlug:     "john-doe",
+		PersonName:     "John Doe",
+		PersonSrc:      SrcMeta,
+		PersonFavorite: false,
+		PhotoCount:     1,
+		CreatedAt:      time.Now(),
+		UpdatedAt:      time.Now(),
+		DeletedAt:      nil,
+	},
+	"jd": {
+		ID:             1000002,
+		PersonUID:      "jt9k3pw1wowuy3c4",
+		PersonSlug:     "jd",
+		PersonName:     "JD",
+		PersonSrc:      SrcManual,
+		PersonFavorite: false,
+		PhotoCount:     1,
+		CreatedAt:      time.Now(),
+		UpdatedAt:      time.Now(),
+		DeletedAt:      nil,
+	},
+}
+
+// CreatePersonFixtures inserts known entities into the database for testing.
+func CreatePersonFixtures() {
+	for _, entity := range PersonFixtures {
+		Db().Create(&entity)
+	}
+}
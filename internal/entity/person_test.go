@@ -0,0 +1,114 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewPerson(t *testing.T) {
+	t.Run("name", func(t *testing.T) {
+		m := NewPerson("Max Mustermann", SrcXmp)
+		assert.Equal(t, "Max Mustermann", m.PersonName)
+		assert.Equal(t, "max-mustermann", m.PersonSlug)
+		assert.Equal(t, SrcXmp, m.PersonSrc)
+	})
+	t.Run("empty", func(t *testing.T) {
+		m := NewPerson("", SrcManual)
+		assert.Equal(t, "Unknown", m.PersonName)
+		assert.Equal(t, "unknown", m.PersonSlug)
+	})
+}
+
+func TestPerson_TableName(t *testing.T) {
+	assert.Equal(t, "people", Person{}.TableName())
+}
+
+func TestPerson_FirstOrCreate(t *testing.T) {
+	t.Run("existing", func(t *testing.T) {
+		m := NewPerson("Jane Doe", SrcMeta).FirstOrCreate()
+		assert.Equal(t, uint(1000000), m.ID)
+		assert.Equal(t, "jt9k3pw1wowuy3c2", m.PersonUID)
+	})
+	t.Run("new", func(t *testing.T) {
+		m := NewPerson("Erika Mustermann", SrcMeta).FirstOrCreate()
+		assert.True(t, m.New)
+		assert.Equal(t, 16, len(m.PersonUID))
+	})
+}
+
+func TestPerson_SetName(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		m := PersonFixtures.Get("jd")
+		assert.Nil(t, m.SetName("J. D."))
+		assert.Equal(t, "J. D.", m.PersonName)
+		assert.Equal(t, "j-d", m.PersonSlug)
+	})
+	t.Run("empty", func(t *testing.T) {
+		m := PersonFixtures.Get("jd")
+		assert.Error(t, m.SetName("  "))
+		assert.Equal(t, "JD", m.PersonName)
+	})
+	t.Run("exists", func(t *testing.T) {
+		m := PersonFixtures.Get("jd")
+		assert.Error(t, m.SetName("Jane Doe"))
+		assert.Equal(t, "jd", m.PersonSlug)
+	})
+	t.Run("alias", func(t *testing.T) {
+		other := NewPerson("Alias Owner", SrcManual).FirstOrCreate()
+
+		if err := Db().Save(NewPersonAlias("alias-former", other.ID)).Error; err != nil {
+			t.Fatal(err)
+		}
+
+		m := PersonFixtures.Get("jd")
+		assert.Error(t, m.SetName("Alias Former"))
+		assert.Equal(t, "jd", m.PersonSlug)
+
+		// A person may take back its own former name.
+		assert.Nil(t, other.SetName("Alias Former"))
+		assert.Equal(t, "alias-former", other.PersonSlug)
+	})
+}
+
+func TestPerson_Merge(t *testing.T) {
+	m := NewPerson("Merge Target", SrcManual).FirstOrCreate()
+	other := NewPerson("Merge Source", SrcManual).FirstOrCreate()
+
+	NewPhotoPerson(1000003, other.ID, SrcManual).FirstOrCreate()
+
+	if err := m.Merge([]Person{*other}); err != nil {
+		t.Fatal(err)
+	}
+
+	var photos []PhotoPerson
+
+	if err := Db().Where("person_id = ?", m.ID).Find(&photos).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Len(t, photos, 1)
+	assert.Equal(t, uint(1000003), photos[0].PhotoID)
+	assert.Error(t, Db().Where("id = ?", other.ID).First(&Person{}).Error)
+
+	// Merged people are not created again when indexing.
+	assert.Equal(t, m.ID, NewPerson("Merge Source", SrcMeta).FirstOrCreate().ID)
+}
+
+func TestPerson_Save(t *testing.T) {
+	t.Run("renamed", func(t *testing.T) {
+		m := NewPerson("Rename Before", SrcManual).FirstOrCreate()
+
+		if err := m.SetName("Rename After"); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := m.Save(); err != nil {
+			t.Fatal(err)
+		}
+
+		// Renamed people are not created again when indexing.
+		assert.Equal(t, m.ID, NewPerson("Rename Before", SrcMeta).FirstOrCreate().ID)
+		assert.Equal(t, m.ID, NewPerson("Rename After", SrcMeta).FirstOrCreate().ID)
+	})
+}
@@ -17,54 +17,59 @@ import (
 
 // Photo represents a photo, all its properties, and link to all its images and sidecar files.
 type Photo struct {
-	ID               uint         `gorm:"primary_key" yaml:"-"`
-	TakenAt          time.Time    `gorm:"type:datetime;index:idx_photos_taken_uid;" json:"TakenAt" yaml:"TakenAt"`
-	TakenAtLocal     time.Time    `gorm:"type:datetime;" yaml:"-"`
-	TakenSrc         string       `gorm:"type:varbinary(8);" json:"TakenSrc" yaml:"TakenSrc,omitempty"`
-	PhotoUID         string       `gorm:"type:varbinary(36);unique_index;index:idx_photos_taken_uid;" json:"UID" yaml:"UID"`
-	PhotoType        string       `gorm:"type:varbinary(8);default:'image';" json:"Type" yaml:"Type"`
-	PhotoTitle       string       `gorm:"type:varchar(255);" json:"Title" yaml:"Title"`
-	TitleSrc         string       `gorm:"type:varbinary(8);" json:"TitleSrc" yaml:"TitleSrc,omitempty"`
-	PhotoDescription string       `gorm:"type:text;" json:"Description" yaml:"Description,omitempty"`
-	DescriptionSrc   string       `gorm:"type:varbinary(8);" json:"DescriptionSrc" yaml:"DescriptionSrc,omitempty"`
-	Details          Details      `json:"Details" yaml:"Details"`
-	PhotoPath        string       `gorm:"type:varbinary(768);index;" yaml:"-"`
-	PhotoName        string       `gorm:"type:varbinary(255);" yaml:"-"`
-	PhotoFavorite    bool         `json:"Favorite" yaml:"Favorite,omitempty"`
-	PhotoPrivate     bool         `json:"Private" yaml:"Private,omitempty"`
-	TimeZone         string       `gorm:"type:varbinary(64);" json:"TimeZone" yaml:"-"`
-	PlaceUID         string       `gorm:"type:varbinary(16);index;" json:"PlaceUID" yaml:"-"`
-	LocUID           string       `gorm:"type:varbinary(16);index;" json:"LocUID" yaml:"-"`
-	LocSrc           string       `gorm:"type:varbinary(8);" json:"LocSrc" yaml:"-"`
-	PhotoLat         float32      `gorm:"type:FLOAT;index;" json:"Lat" yaml:"Lat,omitempty"`
-	PhotoLng         float32      `gorm:"type:FLOAT;index;" json:"Lng" yaml:"Lng,omitempty"`
-	PhotoAltitude    int          `json:"Altitude" yaml:"Altitude,omitempty"`
-	PhotoCountry     string       `gorm:"type:varbinary(2);index:idx_photos_country_year_month;default:'zz'" json:"Country" yaml:"-"`
-	PhotoYear        int          `gorm:"index:idx_photos_country_year_month;" json:"Year" yaml:"-"`
-	PhotoMonth       int          `gorm:"index:idx_photos_country_year_month;" json:"Month" yaml:"-"`
-	PhotoIso         int          `json:"Iso" yaml:"ISO,omitempty"`
-	PhotoExposure    string       `gorm:"type:varbinary(64);" json:"Exposure" yaml:"Exposure,omitempty"`
-	PhotoFNumber     float32      `gorm:"type:FLOAT;" json:"FNumber" yaml:"FNumber,omitempty"`
-	PhotoFocalLength int          `json:"FocalLength" yaml:"FocalLength,omitempty"`
-	PhotoQuality     int          `gorm:"type:SMALLINT" json:"Quality" yaml:"-"`
-	PhotoResolution  int          `gorm:"type:SMALLINT" json:"Resolution" yaml:"-"`
-	CameraID         uint         `gorm:"index:idx_photos_camera_lens;" json:"CameraID" yaml:"-"`
-	CameraSerial     string       `gorm:"type:varbinary(255);" json:"CameraSerial" yaml:"CameraSerial,omitempty"`
-	CameraSrc        string       `gorm:"type:varbinary(8);" json:"CameraSrc" yaml:"-"`
-	LensID           uint         `gorm:"index:idx_photos_camera_lens;" json:"LensID" yaml:"-"`
-	Camera           *Camera      `gorm:"association_autoupdate:false;association_autocreate:false" json:"Camera" yaml:"-"`
-	Lens             *Lens        `gorm:"association_autoupdate:false;association_autocreate:false" json:"Lens" yaml:"-"`
-	Location         *Location    `gorm:"foreignkey:loc_uid;association_foreignkey:loc_uid;association_autoupdate:false;association_autocreate:false" json:"Location" yaml:"-"`
-	Place            *Place       `gorm:"foreignkey:place_uid;association_foreignkey:place_uid;association_autoupdate:false;association_autocreate:false" json:"-" yaml:"-"`
-	Links            []Link       `gorm:"foreignkey:share_uid;association_foreignkey:photo_uid" json:"Links" yaml:"-"`
-	Keywords         []Keyword    `json:"-" yaml:"-"`
-	Albums           []Album      `json:"-" yaml:"-"`
-	Files            []File       `yaml:"-"`
-	Labels           []PhotoLabel `yaml:"-"`
-	CreatedAt        time.Time    `yaml:"CreatedAt,omitempty"`
-	UpdatedAt        time.Time    `yaml:"UpdatedAt,omitempty"`
-	EditedAt         *time.Time   `yaml:"EditedAt,omitempty"`
-	DeletedAt        *time.Time   `sql:"index" yaml:"DeletedAt,omitempty"`
+	ID               uint          `gorm:"primary_key" yaml:"-"`
+	TakenAt          time.Time     `gorm:"type:datetime;index:idx_photos_taken_uid;" json:"TakenAt" yaml:"TakenAt"`
+	TakenAtLocal     time.Time     `gorm:"type:datetime;" yaml:"-"`
+	TakenSrc         string        `gorm:"type:varbinary(8);" json:"TakenSrc" yaml:"TakenSrc,omitempty"`
+	PhotoUID         string        `gorm:"type:varbinary(36);unique_index;index:idx_photos_taken_uid;" json:"UID" yaml:"UID"`
+	PhotoType        string        `gorm:"type:varbinary(8);default:'image';" json:"Type" yaml:"Type"`
+	PhotoTitle       string        `gorm:"type:varchar(255);" json:"Title" yaml:"Title"`
+	TitleSrc         string        `gorm:"type:varbinary(8);" json:"TitleSrc" yaml:"TitleSrc,omitempty"`
+	PhotoDescription string        `gorm:"type:text;" json:"Description" yaml:"Description,omitempty"`
+	DescriptionSrc   string        `gorm:"type:varbinary(8);" json:"DescriptionSrc" yaml:"DescriptionSrc,omitempty"`
+	Details          Details       `json:"Details" yaml:"Details"`
+	PhotoPath        string        `gorm:"type:varbinary(768);index;" yaml:"-"`
+	PhotoName        string        `gorm:"type:varbinary(255);" yaml:"-"`
+	PhotoFavorite    bool          `json:"Favorite" yaml:"Favorite,omitempty"`
+	PhotoPrivate     bool          `json:"Private" yaml:"Private,omitempty"`
+	PhotoRating      int           `gorm:"type:SMALLINT" json:"Rating" yaml:"Rating,omitempty"`
+	TimeZone         string        `gorm:"type:varbinary(64);" json:"TimeZone" yaml:"-"`
+	PlaceUID         string        `gorm:"type:varbinary(16);index;" json:"PlaceUID" yaml:"-"`
+	LocUID           string        `gorm:"type:varbinary(16);index;" json:"LocUID" yaml:"-"`
+	LocSrc           string        `gorm:"type:varbinary(8);" json:"LocSrc" yaml:"-"`
+	PhotoLat         float32       `gorm:"type:FLOAT;index;" json:"Lat" yaml:"Lat,omitempty"`
+	PhotoLng         float32       `gorm:"type:FLOAT;index;" json:"Lng" yaml:"Lng,omitempty"`
+	PhotoAltitude    int           `json:"Altitude" yaml:"Altitude,omitempty"`
+	PhotoCountry     string        `gorm:"type:varbinary(2);index:idx_photos_country_year_month;default:'zz'" json:"Country" yaml:"-"`
+	PhotoYear        int           `gorm:"index:idx_photos_country_year_month;" json:"Year" yaml:"-"`
+	PhotoMonth       int           `gorm:"index:idx_photos_country_year_month;" json:"Month" yaml:"-"`
+	PhotoIso         int           `json:"Iso" yaml:"ISO,omitempty"`
+	PhotoExposure    string        `gorm:"type:varbinary(64);" json:"Exposure" yaml:"Exposure,omitempty"`
+	PhotoFNumber     float32       `gorm:"type:FLOAT;" json:"FNumber" yaml:"FNumber,omitempty"`
+	PhotoFocalLength int           `json:"FocalLength" yaml:"FocalLength,omitempty"`
+	PhotoBias        float32       `gorm:"type:FLOAT;" json:"Bias" yaml:"Bias,omitempty"`
+	PhotoSequence    int           `json:"Sequence" yaml:"Sequence,omitempty"`
+	PhotoQuality     int           `gorm:"type:SMALLINT" json:"Quality" yaml:"-"`
+	PhotoResolution  int           `gorm:"type:SMALLINT" json:"Resolution" yaml:"-"`
+	CameraID         uint          `gorm:"index:idx_photos_camera_lens;" json:"CameraID" yaml:"-"`
+	CameraSerial     string        `gorm:"type:varbinary(255);" json:"CameraSerial" yaml:"CameraSerial,omitempty"`
+	CameraSrc        string        `gorm:"type:varbinary(8);" json:"CameraSrc" yaml:"-"`
+	LensID           uint          `gorm:"index:idx_photos_camera_lens;" json:"LensID" yaml:"-"`
+	StackUID         string        `gorm:"type:varbinary(36);index;default:'';" json:"StackUID" yaml:"-"`
+	Camera           *Camera       `gorm:"association_autoupdate:false;association_autocreate:false" json:"Camera" yaml:"-"`
+	Lens             *Lens         `gorm:"association_autoupdate:false;association_autocreate:false" json:"Lens" yaml:"-"`
+	Location         *Location     `gorm:"foreignkey:loc_uid;association_foreignkey:loc_uid;association_autoupdate:false;association_autocreate:false" json:"Location" yaml:"-"`
+	Place            *Place        `gorm:"foreignkey:place_uid;association_foreignkey:place_uid;association_autoupdate:false;association_autocreate:false" json:"-" yaml:"-"`
+	Links            []Link        `gorm:"foreignkey:share_uid;association_foreignkey:photo_uid" json:"Links" yaml:"-"`
+	Keywords         []Keyword     `json:"-" yaml:"-"`
+	Albums           []Album       `json:"-" yaml:"-"`
+	Files            []File        `yaml:"-"`
+	Labels           []PhotoLabel  `yaml:"-"`
+	People           []PhotoPerson `yaml:"-"`
+	CreatedAt        time.Time     `yaml:"CreatedAt,omitempty"`
+	UpdatedAt        time.Time     `yaml:"UpdatedAt,omitempty"`
+	EditedAt         *time.Time    `yaml:"EditedAt,omitempty"`
+	DeletedAt        *time.Time    `sql:"index" yaml:"DeletedAt,omitempty"`
 }
 
 // SavePhotoForm saves a model in the database using form data.
@@ -517,6 +522,38 @@ func (m *Photo) SetTakenAt(taken, local time.Time, zone, source string) {
 	m.UpdateYearMonth()
 }
 
+// ShiftTakenAt corrects the time a photo was taken. The offset is added to the local time, e.g. if the
+// camera clock was set wrong. If a time zone is given, the local time is kept and the UTC time is
+// recalculated for the new zone.
+func (m *Photo) ShiftTakenAt(offset time.Duration, zone string) error {
+	if m.TakenAt.IsZero() || m.TakenAt.Year() < 1000 {
+		return fmt.Errorf("photo: %s has no date", m.PhotoUID)
+	}
+
+	if m.TakenAtLocal.IsZero() || m.TakenAtLocal.Year() < 1000 {
+		m.TakenAtLocal = m.TakenAt
+	}
+
+	if zone != "" {
+		if _, err := time.LoadLocation(zone); err != nil {
+			return fmt.Errorf("photo: unknown time zone %s", txt.Quote(zone))
+		}
+	}
+
+	m.TakenAt = m.TakenAt.Add(offset).UTC()
+	m.TakenAtLocal = m.TakenAtLocal.Add(offset)
+
+	if zone != "" {
+		m.TimeZone = zone
+		m.TakenAt = m.GetTakenAt()
+	}
+
+	m.TakenSrc = SrcManual
+	m.UpdateYearMonth()
+
+	return nil
+}
+
 // UpdateYearMonth updates internal date fields.
 func (m *Photo) UpdateYearMonth() {
 	if m.TakenAt.IsZero() || m.TakenAt.Year() < 1000 {
@@ -552,6 +589,26 @@ func (m *Photo) SetCoordinates(lat, lng float32, altitude int, source string) {
 	m.LocSrc = source
 }
 
+// SetRating changes the photo rating from -1 (rejected) to 5 stars and flags it as favorite if requested.
+func (m *Photo) SetRating(rating int, favorite bool) {
+	if rating < -1 {
+		rating = -1
+	} else if rating > 5 {
+		rating = 5
+	}
+
+	m.PhotoRating = rating
+
+	if favorite {
+		m.PhotoFavorite = true
+	}
+}
+
+// Rejected returns true if the photo was rejected while culling.
+func (m *Photo) Rejected() bool {
+	return m.PhotoRating < 0
+}
+
 // AllFilesMissing returns true, if all files for this photo are missing.
 func (m *Photo) AllFilesMissing() bool {
 	count := 0
@@ -71,5 +71,15 @@ func UpdatePhotoCounts() error {
 		return err
 	}
 
+	if err := Db().Table("people").
+		UpdateColumn("photo_count", gorm.Expr("(SELECT COUNT(*) FROM photos_people pp "+
+			"JOIN photos ph ON pp.photo_id = ph.id "+
+			"WHERE pp.person_id = people.id "+
+			"AND ph.photo_quality >= 0 "+
+			"AND ph.photo_private = 0 "+
+			"AND ph.deleted_at IS NULL)")).Error; err != nil {
+		return err
+	}
+
 	return nil
 }
@@ -218,6 +218,7 @@ var PhotoFixtures = PhotoMap{
 		CameraID:         CameraFixtures.Pointer("canon-eos-6d").ID,
 		Lens:             LensFixtures.Pointer("lens-f-380"),
 		LensID:           LensFixtures.Pointer("lens-f-380").ID,
+		StackUID:         "sqcwk7ew9ss5xgrv",
 		Links:            []Link{},
 		Keywords:         []Keyword{},
 		Albums:           []Album{},
@@ -1014,6 +1015,7 @@ var PhotoFixtures = PhotoMap{
 		CameraID:         CameraFixtures.Pointer("canon-eos-6d").ID,
 		Lens:             LensFixtures.Pointer("lens-f-380"),
 		LensID:           LensFixtures.Pointer("lens-f-380").ID,
+		StackUID:         "sqcwk7ew9ss5xgrv",
 		Links:            []Link{},
 		Keywords:         []Keyword{},
 		Albums:           []Album{},
@@ -1024,6 +1026,55 @@ var PhotoFixtures = PhotoMap{
 		EditedAt:         nil,
 		DeletedAt:        nil,
 	},
+	"Photo20": {
+		ID:               1000020,
+		PhotoUID:         "pt9jtxrexxvl0yh1",
+		TakenAt:          time.Date(2019, 7, 1, 12, 0, 0, 0, time.UTC),
+		TakenAtLocal:     time.Date(2019, 7, 1, 12, 0, 0, 0, time.UTC),
+		TakenSrc:         "meta",
+		PhotoTitle:       "Live Photo",
+		TitleSrc:         "",
+		PhotoPath:        "2019/07",
+		PhotoName:        "IMG_0020",
+		PhotoQuality:     3,
+		PhotoResolution:  2,
+		PhotoFavorite:    false,
+		PhotoPrivate:     false,
+		PhotoType:        "live",
+		PhotoLat:         0,
+		PhotoLng:         0,
+		PhotoAltitude:    0,
+		PhotoIso:         0,
+		PhotoFocalLength: 0,
+		PhotoFNumber:     0,
+		PhotoExposure:    "",
+		CameraSerial:     "",
+		CameraSrc:        "",
+		Place:            &UnknownPlace,
+		Location:         &UnknownLocation,
+		PlaceUID:         UnknownPlace.PlaceUID,
+		LocUID:           UnknownLocation.LocUID,
+		LocSrc:           "",
+		TimeZone:         "",
+		PhotoCountry:     UnknownPlace.CountryCode(),
+		PhotoYear:        2019,
+		PhotoMonth:       7,
+		Details:          DetailsFixtures.Get("lake", 1000020),
+		DescriptionSrc:   "",
+		Camera:           CameraFixtures.Pointer("canon-eos-6d"),
+		CameraID:         CameraFixtures.Pointer("canon-eos-6d").ID,
+		Lens:             LensFixtures.Pointer("lens-f-380"),
+		LensID:           LensFixtures.Pointer("lens-f-380").ID,
+		Links:            []Link{},
+		Keywords:         []Keyword{},
+		Albums:           []Album{},
+		Files:            []File{},
+		Labels:           []PhotoLabel{},
+		CreatedAt:        time.Date(2019, 7, 1, 12, 0, 0, 0, time.UTC),
+		UpdatedAt:        time.Date(2019, 7, 1, 12, 0, 0, 0, time.UTC),
+		EditedAt:         nil,
+		DeletedAt:        nil,
+	},
 }
 
 // CreatePhotoFixtures inserts known entities into the database for testing.
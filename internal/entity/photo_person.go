@@ -0,0 +1,63 @@
+package entity
+
+// PhotoPerson represents the many-to-many relation between Photo and Person,
+// including the region of the image showing the person, if known.
+// Region coordinates are relative to the image size, starting at the top left corner.
+type PhotoPerson struct {
+	PhotoID   uint    `gorm:"primary_key;auto_increment:false" json:"-" yaml:"-"`
+	PersonID  uint    `gorm:"primary_key;auto_increment:false;index" json:"-" yaml:"-"`
+	PersonSrc string  `gorm:"type:varbinary(8);" json:"Src" yaml:"Src,omitempty"`
+	RegionX   float32 `gorm:"type:FLOAT;" json:"X" yaml:"X,omitempty"`
+	RegionY   float32 `gorm:"type:FLOAT;" json:"Y" yaml:"Y,omitempty"`
+	RegionW   float32 `gorm:"type:FLOAT;" json:"W" yaml:"W,omitempty"`
+	RegionH   float32 `gorm:"type:FLOAT;" json:"H" yaml:"H,omitempty"`
+	Photo     *Photo  `gorm:"PRELOAD:false" json:"-" yaml:"-"`
+	Person    *Person `gorm:"PRELOAD:true" json:"Person" yaml:"Person"`
+}
+
+// TableName returns PhotoPerson table identifier "photos_people".
+func (PhotoPerson) TableName() string {
+	return "photos_people"
+}
+
+// NewPhotoPerson returns a new relation between a photo and a person.
+func NewPhotoPerson(photoID, personID uint, source string) *PhotoPerson {
+	result := &PhotoPerson{
+		PhotoID:   photoID,
+		PersonID:  personID,
+		PersonSrc: source,
+	}
+
+	return result
+}
+
+// HasRegion returns true if the image region showing the person is known.
+func (m *PhotoPerson) HasRegion() bool {
+	return m.RegionW > 0 && m.RegionH > 0
+}
+
+// SetRegion sets the image region showing the person.
+func (m *PhotoPerson) SetRegion(x, y, w, h float32) {
+	m.RegionX = x
+	m.RegionY = y
+	m.RegionW = w
+	m.RegionH = h
+}
+
+// FirstOrCreate checks if the PhotoPerson relation already exist in the database before the creation.
+func (m *PhotoPerson) FirstOrCreate() *PhotoPerson {
+	if err := Db().FirstOrCreate(m, "photo_id = ? AND person_id = ?", m.PhotoID, m.PersonID).Error; err != nil {
+		log.Errorf("photo person: %s", err)
+	}
+
+	return m
+}
+
+// Save saves the entity in the database and returns an error.
+func (m *PhotoPerson) Save() error {
+	if m.Photo != nil {
+		m.Photo = nil
+	}
+
+	return Db().Save(m).Error
+}
@@ -0,0 +1,36 @@
+package entity
+
+type PhotoPersonMap map[string]PhotoPerson
+
+var PhotoPersonFixtures = PhotoPersonMap{
+	"1": {
+		PhotoID:   1000000,
+		PersonID:  1000000,
+		PersonSrc: SrcXmp,
+		RegionX:   0.4,
+		RegionY:   0.25,
+		RegionW:   0.2,
+		RegionH:   0.3,
+	},
+	"2": {
+		PhotoID:   1000001,
+		PersonID:  1000001,
+		PersonSrc: SrcMeta,
+	},
+	"3": {
+		PhotoID:   1000001,
+		PersonID:  1000002,
+		PersonSrc: SrcManual,
+		RegionX:   0.1,
+		RegionY:   0.2,
+		RegionW:   0.3,
+		RegionH:   0.4,
+	},
+}
+
+// CreatePhotoPersonFixtures inserts known entities into the database for testing.
+func CreatePhotoPersonFixtures() {
+	for _, entity := range PhotoPersonFixtures {
+		Db().Create(&entity)
+	}
+}
@@ -0,0 +1,32 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewPhotoPerson(t *testing.T) {
+	m := NewPhotoPerson(1, 3, SrcXmp)
+	assert.Equal(t, uint(1), m.PhotoID)
+	assert.Equal(t, uint(3), m.PersonID)
+	assert.Equal(t, SrcXmp, m.PersonSrc)
+	assert.False(t, m.HasRegion())
+}
+
+func TestPhotoPerson_TableName(t *testing.T) {
+	assert.Equal(t, "photos_people", PhotoPerson{}.TableName())
+}
+
+func TestPhotoPerson_SetRegion(t *testing.T) {
+	m := NewPhotoPerson(1, 3, SrcXmp)
+	m.SetRegion(0.1, 0.2, 0.3, 0.4)
+	assert.True(t, m.HasRegion())
+	assert.Equal(t, float32(0.3), m.RegionW)
+}
+
+func TestPhotoPerson_FirstOrCreate(t *testing.T) {
+	m := NewPhotoPerson(1000000, 1000000, SrcMeta).FirstOrCreate()
+	assert.Equal(t, SrcXmp, m.PersonSrc)
+	assert.True(t, m.HasRegion())
+}
@@ -20,6 +20,10 @@ var (
 
 // QualityScore returns a score based on photo properties like size and metadata.
 func (m *Photo) QualityScore() (score int) {
+	if m.Rejected() {
+		return 0
+	}
+
 	if m.PhotoFavorite {
 		score += 3
 	}
@@ -28,6 +32,10 @@ func (m *Photo) QualityScore() (score int) {
 		score++
 	}
 
+	if m.PhotoRating >= 4 {
+		score++
+	}
+
 	if m.HasLatLng() {
 		score++
 	}
@@ -21,4 +21,14 @@ func TestPhoto_QualityScore(t *testing.T) {
 	t.Run("PhotoFixturePhoto15 - description with blacklist", func(t *testing.T) {
 		assert.Equal(t, 2, PhotoFixtures.Pointer("Photo15").QualityScore())
 	})
+	t.Run("rejected", func(t *testing.T) {
+		photo := PhotoFixtures.Get("Photo01")
+		photo.SetRating(-1, false)
+		assert.Equal(t, 0, photo.QualityScore())
+	})
+	t.Run("four stars", func(t *testing.T) {
+		photo := PhotoFixtures.Get("Photo06")
+		photo.SetRating(4, false)
+		assert.Equal(t, 5, photo.QualityScore())
+	})
 }
@@ -0,0 +1,68 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Actions to resolve near-duplicates.
+const (
+	ResolveArchive = "archive"
+	ResolveStack   = "stack"
+)
+
+// Stack moves the files of other photos to this photo, so that they are kept as
+// alternative versions, and archives the other photos.
+func (m *Photo) Stack(others []Photo) error {
+	if m.ID == 0 {
+		return errors.New("photo: can't stack files on unsaved photo")
+	}
+
+	tx := Db().Begin()
+
+	for _, other := range others {
+		if other.ID == m.ID {
+			continue
+		}
+
+		if err := tx.Model(&File{}).Where("photo_id = ?", other.ID).Updates(map[string]interface{}{
+			"photo_id":     m.ID,
+			"photo_uid":    m.PhotoUID,
+			"file_primary": false,
+		}).Error; err != nil {
+			tx.Rollback()
+			return err
+		}
+
+		if err := tx.Delete(&other).Error; err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	return tx.Commit().Error
+}
+
+// ResolveDuplicates keeps this photo and archives or stacks its near-duplicates.
+func (m *Photo) ResolveDuplicates(others []Photo, action string) error {
+	switch action {
+	case ResolveStack:
+		return m.Stack(others)
+	case ResolveArchive:
+		var ids []uint
+
+		for _, other := range others {
+			if other.ID != m.ID {
+				ids = append(ids, other.ID)
+			}
+		}
+
+		if len(ids) == 0 {
+			return nil
+		}
+
+		return Db().Where("id IN (?)", ids).Delete(&Photo{}).Error
+	default:
+		return fmt.Errorf("photo: unknown action %s", action)
+	}
+}
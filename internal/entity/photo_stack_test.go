@@ -0,0 +1,88 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func createStackPhoto(t *testing.T, name string) (Photo, File) {
+	photo := Photo{TakenAt: time.Date(2019, 1, 15, 0, 0, 0, 0, time.UTC), PhotoName: name}
+
+	if err := Db().Create(&photo).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	file := File{PhotoID: photo.ID, PhotoUID: photo.PhotoUID, FileName: name + ".jpg", FileType: "jpg", FilePrimary: true}
+
+	if err := Db().Create(&file).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	return photo, file
+}
+
+func TestPhoto_Stack(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		keep, _ := createStackPhoto(t, "StackKeep")
+		other, otherFile := createStackPhoto(t, "StackOther")
+
+		if err := keep.Stack([]Photo{keep, other}); err != nil {
+			t.Fatal(err)
+		}
+
+		var file File
+
+		if err := Db().First(&file, otherFile.ID).Error; err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, keep.ID, file.PhotoID)
+		assert.Equal(t, keep.PhotoUID, file.PhotoUID)
+		assert.False(t, file.FilePrimary)
+
+		var archived Photo
+
+		if err := UnscopedDb().First(&archived, other.ID).Error; err != nil {
+			t.Fatal(err)
+		}
+
+		assert.NotNil(t, archived.DeletedAt)
+	})
+	t.Run("unsaved photo", func(t *testing.T) {
+		photo := Photo{}
+		assert.Error(t, photo.Stack([]Photo{}))
+	})
+}
+
+func TestPhoto_ResolveDuplicates(t *testing.T) {
+	t.Run("archive", func(t *testing.T) {
+		keep, _ := createStackPhoto(t, "ArchiveKeep")
+		other, otherFile := createStackPhoto(t, "ArchiveOther")
+
+		if err := keep.ResolveDuplicates([]Photo{other}, ResolveArchive); err != nil {
+			t.Fatal(err)
+		}
+
+		var file File
+
+		if err := Db().First(&file, otherFile.ID).Error; err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, other.ID, file.PhotoID)
+
+		var archived Photo
+
+		if err := UnscopedDb().First(&archived, other.ID).Error; err != nil {
+			t.Fatal(err)
+		}
+
+		assert.NotNil(t, archived.DeletedAt)
+	})
+	t.Run("unknown action", func(t *testing.T) {
+		photo := PhotoFixtures.Get("Photo01")
+		assert.EqualError(t, photo.ResolveDuplicates([]Photo{}, "delete"), "photo: unknown action delete")
+	})
+}
@@ -514,6 +514,50 @@ func TestPhoto_SetTakenAt(t *testing.T) {
 	})
 }
 
+func TestPhoto_ShiftTakenAt(t *testing.T) {
+	t.Run("offset", func(t *testing.T) {
+		m := PhotoFixtures.Get("Photo15")
+		err := m.ShiftTakenAt(-2*time.Hour, "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, time.Date(2013, 11, 11, 7, 7, 18, 0, time.UTC), m.TakenAt)
+		assert.Equal(t, time.Date(2013, 11, 11, 7, 7, 18, 0, time.UTC), m.TakenAtLocal)
+		assert.Equal(t, SrcManual, m.TakenSrc)
+		assert.Equal(t, 2013, m.PhotoYear)
+		assert.Equal(t, 11, m.PhotoMonth)
+	})
+	t.Run("offset changes month", func(t *testing.T) {
+		m := PhotoFixtures.Get("Photo15")
+		err := m.ShiftTakenAt(-11*24*time.Hour, "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, 2013, m.PhotoYear)
+		assert.Equal(t, 10, m.PhotoMonth)
+	})
+	t.Run("time zone", func(t *testing.T) {
+		m := PhotoFixtures.Get("Photo15")
+		err := m.ShiftTakenAt(0, "Europe/Berlin")
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, "Europe/Berlin", m.TimeZone)
+		assert.Equal(t, time.Date(2013, 11, 11, 8, 7, 18, 0, time.UTC), m.TakenAt)
+		assert.Equal(t, time.Date(2013, 11, 11, 9, 7, 18, 0, time.UTC), m.TakenAtLocal)
+	})
+	t.Run("unknown time zone", func(t *testing.T) {
+		m := PhotoFixtures.Get("Photo15")
+		err := m.ShiftTakenAt(time.Hour, "Mars/Olympus")
+		assert.Error(t, err)
+		assert.Equal(t, time.Date(2013, 11, 11, 9, 7, 18, 0, time.UTC), m.TakenAt)
+	})
+	t.Run("no date", func(t *testing.T) {
+		m := Photo{}
+		assert.Error(t, m.ShiftTakenAt(time.Hour, ""))
+	})
+}
+
 func TestPhoto_SetCoordinates(t *testing.T) {
 	t.Run("empty coordinates", func(t *testing.T) {
 		m := PhotoFixtures.Get("Photo15")
@@ -547,6 +591,30 @@ func TestPhoto_SetCoordinates(t *testing.T) {
 	})
 }
 
+func TestPhoto_SetRating(t *testing.T) {
+	t.Run("favorite", func(t *testing.T) {
+		m := PhotoFixtures.Get("Photo15")
+		assert.False(t, m.PhotoFavorite)
+		m.SetRating(5, true)
+		assert.Equal(t, 5, m.PhotoRating)
+		assert.True(t, m.PhotoFavorite)
+		assert.False(t, m.Rejected())
+	})
+	t.Run("keep favorite", func(t *testing.T) {
+		m := PhotoFixtures.Get("Photo01")
+		assert.True(t, m.PhotoFavorite)
+		m.SetRating(2, false)
+		assert.Equal(t, 2, m.PhotoRating)
+		assert.True(t, m.PhotoFavorite)
+	})
+	t.Run("rejected", func(t *testing.T) {
+		m := PhotoFixtures.Get("Photo15")
+		m.SetRating(-3, false)
+		assert.Equal(t, -1, m.PhotoRating)
+		assert.True(t, m.Rejected())
+	})
+}
+
 func TestPhoto_Delete(t *testing.T) {
 	t.Run("not permanent", func(t *testing.T) {
 		m := PhotoFixtures.Get("Photo16")
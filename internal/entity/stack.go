@@ -0,0 +1,107 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/jinzhu/gorm"
+	"github.com/photoprism/photoprism/pkg/rnd"
+)
+
+// Types of photo stacks.
+const (
+	StackBurst   = "burst"
+	StackBracket = "bracket"
+)
+
+// Stack represents a group of photos taken in quick succession, like a burst or an exposure bracket.
+type Stack struct {
+	ID         uint      `gorm:"primary_key" json:"ID" yaml:"-"`
+	StackUID   string    `gorm:"type:varbinary(36);unique_index;" json:"UID" yaml:"UID"`
+	StackType  string    `gorm:"type:varbinary(8);" json:"Type" yaml:"Type"`
+	CoverUID   string    `gorm:"type:varbinary(36);index;" json:"CoverUID" yaml:"CoverUID"`
+	PhotoCount int       `json:"PhotoCount" yaml:"-"`
+	CreatedAt  time.Time `json:"CreatedAt" yaml:"-"`
+	UpdatedAt  time.Time `json:"UpdatedAt" yaml:"-"`
+}
+
+// TableName returns Stack table identifier "stacks".
+func (Stack) TableName() string {
+	return "stacks"
+}
+
+// BeforeCreate creates a random UID if needed before inserting a new row to the database.
+func (m *Stack) BeforeCreate(scope *gorm.Scope) error {
+	if rnd.IsPPID(m.StackUID, 's') {
+		return nil
+	}
+
+	return scope.SetColumn("StackUID", rnd.PPID('s'))
+}
+
+// NewStack returns a new stack entity of the given type.
+func NewStack(stackType string) *Stack {
+	result := &Stack{
+		StackType: stackType,
+	}
+
+	return result
+}
+
+// FindStack returns the stack with the given UID or nil if it doesn't exist.
+func FindStack(uid string) *Stack {
+	result := Stack{}
+
+	if err := Db().Where("stack_uid = ?", uid).First(&result).Error; err != nil {
+		return nil
+	}
+
+	return &result
+}
+
+// Create inserts a new row to the database.
+func (m *Stack) Create() error {
+	return Db().Create(m).Error
+}
+
+// AddPhotos adds photos to the stack and sets the cover photo if none was set yet.
+func (m *Stack) AddPhotos(photos []Photo, cover Photo) error {
+	if len(photos) == 0 {
+		return nil
+	}
+
+	var ids []uint
+
+	for _, photo := range photos {
+		ids = append(ids, photo.ID)
+	}
+
+	if err := Db().Model(&Photo{}).Where("id IN (?)", ids).UpdateColumn("stack_uid", m.StackUID).Error; err != nil {
+		return err
+	}
+
+	if m.CoverUID == "" {
+		m.CoverUID = cover.PhotoUID
+	}
+
+	if err := Db().Model(&Photo{}).Where("stack_uid = ?", m.StackUID).Count(&m.PhotoCount).Error; err != nil {
+		return err
+	}
+
+	return Db().Save(m).Error
+}
+
+// SetCover changes the photo shown for the stack in search results.
+func (m *Stack) SetCover(photoUID string) error {
+	m.CoverUID = photoUID
+
+	return Db().Model(m).UpdateColumn("cover_uid", m.CoverUID).Error
+}
+
+// Delete removes the stack, its photos are shown separately again.
+func (m *Stack) Delete() error {
+	if err := Db().Model(&Photo{}).Where("stack_uid = ?", m.StackUID).UpdateColumn("stack_uid", "").Error; err != nil {
+		return err
+	}
+
+	return Db().Delete(m).Error
+}
@@ -0,0 +1,42 @@
+package entity
+
+import (
+	"time"
+)
+
+type StackMap map[string]Stack
+
+func (m StackMap) Get(name string) Stack {
+	if result, ok := m[name]; ok {
+		return result
+	}
+
+	return *NewStack(StackBurst)
+}
+
+func (m StackMap) Pointer(name string) *Stack {
+	if result, ok := m[name]; ok {
+		return &result
+	}
+
+	return NewStack(StackBurst)
+}
+
+var StackFixtures = StackMap{
+	"burst": {
+		ID:         1000000,
+		StackUID:   "sqcwk7ew9ss5xgrv",
+		StackType:  StackBurst,
+		CoverUID:   "pt9jtdre2lvl0yh0",
+		PhotoCount: 2,
+		CreatedAt:  time.Now(),
+		UpdatedAt:  time.Now(),
+	},
+}
+
+// CreateStackFixtures inserts known entities into the database for testing.
+func CreateStackFixtures() {
+	for _, entity := range StackFixtures {
+		Db().Create(&entity)
+	}
+}
@@ -0,0 +1,91 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStack_TableName(t *testing.T) {
+	stack := &Stack{}
+	assert.Equal(t, "stacks", stack.TableName())
+}
+
+func TestNewStack(t *testing.T) {
+	r := NewStack(StackBracket)
+	assert.IsType(t, &Stack{}, r)
+	assert.Equal(t, StackBracket, r.StackType)
+	assert.Equal(t, "", r.CoverUID)
+}
+
+func TestFindStack(t *testing.T) {
+	t.Run("found", func(t *testing.T) {
+		r := FindStack("sqcwk7ew9ss5xgrv")
+
+		if r == nil {
+			t.Fatal("stack should not be nil")
+		}
+
+		assert.Equal(t, "pt9jtdre2lvl0yh0", r.CoverUID)
+	})
+	t.Run("not found", func(t *testing.T) {
+		assert.Nil(t, FindStack("xxx"))
+	})
+}
+
+func TestStack_AddPhotos(t *testing.T) {
+	var photos []Photo
+
+	for i := 0; i < 3; i++ {
+		photo := Photo{TakenAt: time.Date(2019, 1, 15, 0, 0, i, 0, time.UTC), PhotoName: "StackPhoto"}
+
+		if err := Db().Create(&photo).Error; err != nil {
+			t.Fatal(err)
+		}
+
+		photos = append(photos, photo)
+	}
+
+	stack := NewStack(StackBurst)
+
+	if err := stack.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.True(t, len(stack.StackUID) == 16)
+
+	if err := stack.AddPhotos(photos[:2], photos[1]); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, 2, stack.PhotoCount)
+	assert.Equal(t, photos[1].PhotoUID, stack.CoverUID)
+
+	if err := stack.AddPhotos(photos[2:], photos[2]); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, 3, stack.PhotoCount)
+	assert.Equal(t, photos[1].PhotoUID, stack.CoverUID)
+
+	if err := stack.SetCover(photos[0].PhotoUID); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, photos[0].PhotoUID, FindStack(stack.StackUID).CoverUID)
+
+	if err := stack.Delete(); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Nil(t, FindStack(stack.StackUID))
+
+	count := 0
+
+	if err := Db().Model(&Photo{}).Where("stack_uid = ?", stack.StackUID).Count(&count).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, 0, count)
+}
@@ -0,0 +1,6 @@
+package form
+
+// CameraMerge represents a form to merge cameras into one.
+type CameraMerge struct {
+	Cameras []string `json:"cameras"`
+}
@@ -0,0 +1,15 @@
+package form
+
+// DuplicateSearch represents search form fields for "/api/v1/duplicates".
+type DuplicateSearch struct {
+	Distance int `form:"distance"`
+	Count    int `form:"count" binding:"required"`
+	Offset   int `form:"offset"`
+}
+
+// DuplicateResolve represents a request to archive or stack near-duplicates of a photo.
+type DuplicateResolve struct {
+	Photos   []string `json:"photos"`
+	Action   string   `json:"action"`
+	Distance int      `json:"distance"`
+}
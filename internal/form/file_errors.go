@@ -0,0 +1,7 @@
+package form
+
+// FileErrorSearch represents search form fields for "/api/v1/errors/files".
+type FileErrorSearch struct {
+	Count  int `form:"count" binding:"required"`
+	Offset int `form:"offset"`
+}
@@ -0,0 +1,7 @@
+package form
+
+type GeotagOptions struct {
+	Offset string `form:"offset"`
+	MaxGap string `form:"maxgap"`
+	Dry    bool   `form:"dry"`
+}
@@ -3,4 +3,5 @@ package form
 type ImportOptions struct {
 	Path string `json:"path"`
 	Move bool   `json:"move"`
+	Dry  bool   `json:"dry"`
 }
@@ -4,4 +4,5 @@ type IndexOptions struct {
 	Path    string `json:"path"`
 	Convert bool   `json:"convert"`
 	Rescan  bool   `json:"rescan"`
+	Resume  bool   `json:"resume"`
 }
@@ -0,0 +1,9 @@
+package form
+
+// JobSearch represents search form fields for "/api/v1/jobs".
+type JobSearch struct {
+	Type   string `form:"type"`
+	Status string `form:"status"`
+	Count  int    `form:"count" binding:"required"`
+	Offset int    `form:"offset"`
+}
@@ -0,0 +1,6 @@
+package form
+
+// LensMerge represents a form to merge lenses into one.
+type LensMerge struct {
+	Lenses []string `json:"lenses"`
+}
@@ -0,0 +1,13 @@
+package form
+
+// Person represents a person edit form.
+type Person struct {
+	PersonName     string `json:"Name"`
+	PersonFavorite bool   `json:"Favorite"`
+	PersonNotes    string `json:"Notes"`
+}
+
+// PersonMerge represents a form to merge people into one.
+type PersonMerge struct {
+	People []string `json:"people"`
+}
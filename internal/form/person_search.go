@@ -0,0 +1,28 @@
+package form
+
+// PersonSearch represents search form fields for "/api/v1/people".
+type PersonSearch struct {
+	Query    string `form:"q"`
+	ID       string `form:"id"`
+	Name     string `form:"name"`
+	Favorite bool   `form:"favorite"`
+	Count    int    `form:"count" binding:"required" serialize:"-"`
+	Offset   int    `form:"offset" serialize:"-"`
+	Order    string `form:"order" serialize:"-"`
+}
+
+func (f *PersonSearch) GetQuery() string {
+	return f.Query
+}
+
+func (f *PersonSearch) SetQuery(q string) {
+	f.Query = q
+}
+
+func (f *PersonSearch) ParseQueryString() error {
+	return ParseQueryString(f)
+}
+
+func NewPersonSearch(query string) PersonSearch {
+	return PersonSearch{Query: query}
+}
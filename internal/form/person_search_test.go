@@ -0,0 +1,36 @@
+package form
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPersonSearchForm(t *testing.T) {
+	form := &PersonSearch{}
+
+	assert.IsType(t, new(PersonSearch), form)
+}
+
+func TestParseQueryStringPerson(t *testing.T) {
+	t.Run("valid query", func(t *testing.T) {
+		form := &PersonSearch{Query: "name:jane favorite:true count:10 query:\"jane doe\""}
+
+		err := form.ParseQueryString()
+
+		if err != nil {
+			t.Fatal("err should be nil")
+		}
+
+		assert.Equal(t, "jane", form.Name)
+		assert.Equal(t, true, form.Favorite)
+		assert.Equal(t, 10, form.Count)
+		assert.Equal(t, "jane doe", form.Query)
+	})
+}
+
+func TestNewPersonSearch(t *testing.T) {
+	r := NewPersonSearch("john")
+	assert.IsType(t, PersonSearch{}, r)
+	assert.Equal(t, "john", r.Query)
+}
@@ -15,6 +15,11 @@ type PhotoSearch struct {
 	Title     string    `form:"title"`
 	Hash      string    `form:"hash"`
 	Video     bool      `form:"video"`
+	Live      bool      `form:"live"`
+	Codec     string    `form:"codec"`
+	Audio     string    `form:"audio"`
+	Fps       float32   `form:"fps"`
+	Hdr       bool      `form:"hdr"`
 	Photo     bool      `form:"photo"`
 	Duplicate bool      `form:"duplicate"`
 	Archived  bool      `form:"archived"`
@@ -31,6 +36,7 @@ type PhotoSearch struct {
 	Location  bool      `form:"location"`
 	Album     string    `form:"album"`
 	Label     string    `form:"label"`
+	Person    string    `form:"person"`
 	Country   string    `form:"country"`
 	Year      int       `form:"year"`
 	Month     int       `form:"month"`
@@ -45,6 +51,8 @@ type PhotoSearch struct {
 	Public    bool      `form:"public"`
 	Private   bool      `form:"private"`
 	Safe      bool      `form:"safe"`
+	Stack     string    `form:"stack"`
+	Stacks    string    `form:"stacks"`
 	Count     int       `form:"count" binding:"required" serialize:"-"`
 	Offset    int       `form:"offset" serialize:"-"`
 	Order     string    `form:"order" serialize:"-"`
@@ -0,0 +1,8 @@
+package form
+
+type Timeshift struct {
+	Selection
+	Offset   string `json:"offset"`
+	TimeZone string `json:"timezone"`
+	Yaml     bool   `json:"yaml"`
+}
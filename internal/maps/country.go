@@ -0,0 +1,28 @@
+package maps
+
+import "strings"
+
+// CountryCode returns the ISO 3166-1 alpha-2 code for a country name or code, or an empty string if unknown.
+func CountryCode(s string) string {
+	s = strings.TrimSpace(s)
+
+	if s == "" {
+		return ""
+	}
+
+	if code := strings.ToLower(s); len(code) == 2 {
+		if _, ok := CountryNames[code]; ok {
+			return code
+		}
+	} else if code, ok := CountryAlpha3[strings.ToUpper(s)]; ok {
+		return code
+	}
+
+	for code, name := range CountryNames {
+		if strings.EqualFold(name, s) {
+			return code
+		}
+	}
+
+	return ""
+}
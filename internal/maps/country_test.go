@@ -0,0 +1,18 @@
+package maps
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCountryCode(t *testing.T) {
+	assert.Equal(t, "de", CountryCode("Germany"))
+	assert.Equal(t, "de", CountryCode("DE"))
+	assert.Equal(t, "fr", CountryCode(" france "))
+	assert.Equal(t, "de", CountryCode("DEU"))
+	assert.Equal(t, "gb", CountryCode("gbr"))
+	assert.Equal(t, "", CountryCode("XYZ"))
+	assert.Equal(t, "", CountryCode(""))
+	assert.Equal(t, "", CountryCode("Atlantis"))
+}
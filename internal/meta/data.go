@@ -7,42 +7,61 @@ import (
 
 // Data represents image meta data.
 type Data struct {
-	UniqueID     string        `meta:"ImageUniqueID"`
-	TakenAt      time.Time     `meta:"DateTimeOriginal,CreateDate,MediaCreateDate,DateTimeDigitized,DateTime"`
-	TakenAtLocal time.Time     `meta:"DateTimeOriginal,CreateDate,MediaCreateDate,DateTimeDigitized,DateTime"`
-	TimeZone     string        `meta:"-"`
-	Duration     time.Duration `meta:"Duration,MediaDuration,TrackDuration"`
-	Codec        string        `meta:"CompressorID,Compression"`
-	Title        string        `meta:"Title"`
-	Subject      string        `meta:"Subject,PersonInImage"`
-	Keywords     string        `meta:"Keywords"`
-	Comment      string        `meta:"-"`
-	Artist       string        `meta:"Artist,Creator"`
-	Description  string        `meta:"Description"`
-	Copyright    string        `meta:"Rights,Copyright"`
-	CameraMake   string        `meta:"CameraMake,Make"`
-	CameraModel  string        `meta:"CameraModel,Model"`
-	CameraOwner  string        `meta:"OwnerName"`
-	CameraSerial string        `meta:"SerialNumber"`
-	LensMake     string        `meta:"LensMake"`
-	LensModel    string        `meta:"Lens,LensModel"`
-	Flash        bool          `meta:"-"`
-	FocalLength  int           `meta:"-"`
-	Exposure     string        `meta:"ExposureTime"`
-	Aperture     float32       `meta:"ApertureValue"`
-	FNumber      float32       `meta:"FNumber"`
-	Iso          int           `meta:"ISO"`
-	GPSPosition  string        `meta:"GPSPosition"`
-	GPSLatitude  string        `meta:"GPSLatitude"`
-	GPSLongitude string        `meta:"GPSLongitude"`
-	Lat          float32       `meta:"-"`
-	Lng          float32       `meta:"-"`
-	Altitude     int           `meta:"GlobalAltitude"`
-	Width        int           `meta:"PixelXDimension,ImageWidth,ExifImageWidth,SourceImageWidth"`
-	Height       int           `meta:"PixelYDimension,ImageHeight,ImageLength,ExifImageHeight,SourceImageHeight"`
-	Orientation  int           `meta:"-"`
-	Rotation     int           `meta:"Rotation"`
-	All          map[string]string
+	UniqueID      string        `meta:"ImageUniqueID"`
+	ContentID     string        `meta:"ContentIdentifier,MediaGroupUUID"`
+	TakenAt       time.Time     `meta:"DateTimeOriginal,CreateDate,MediaCreateDate,DateTimeDigitized,DateTime"`
+	TakenAtLocal  time.Time     `meta:"DateTimeOriginal,CreateDate,MediaCreateDate,DateTimeDigitized,DateTime"`
+	TimeZone      string        `meta:"-"`
+	Duration      time.Duration `meta:"Duration,MediaDuration,TrackDuration"`
+	Codec         string        `meta:"CompressorID,Compression"`
+	AudioCodec    string        `meta:"AudioFormat"`
+	FrameRate     float64       `meta:"VideoFrameRate"`
+	Bitrate       int64         `meta:"-"`
+	Streams       int           `meta:"-"`
+	ColorTransfer string        `meta:"-"`
+	Title         string        `meta:"Title"`
+	Subject       string        `meta:"Subject,PersonInImage"`
+	Keywords      string        `meta:"Keywords"`
+	Labels        []string      `meta:"-"`
+	People        []string      `meta:"-"`
+	Regions       Regions       `meta:"-"`
+	Comment       string        `meta:"-"`
+	Artist        string        `meta:"Artist,Creator"`
+	Description   string        `meta:"Description"`
+	Copyright     string        `meta:"Rights,Copyright"`
+	CameraMake    string        `meta:"CameraMake,Make"`
+	CameraModel   string        `meta:"CameraModel,Model"`
+	CameraOwner   string        `meta:"OwnerName"`
+	CameraSerial  string        `meta:"SerialNumber"`
+	LensMake      string        `meta:"LensMake"`
+	LensModel     string        `meta:"Lens,LensModel"`
+	Flash         bool          `meta:"-"`
+	FocalLength   int           `meta:"-"`
+	Exposure      string        `meta:"ExposureTime"`
+	ExposureBias  float32       `meta:"-"`
+	Sequence      int           `meta:"SequenceNumber"`
+	Aperture      float32       `meta:"ApertureValue"`
+	FNumber       float32       `meta:"FNumber"`
+	Iso           int           `meta:"ISO"`
+	GPSPosition   string        `meta:"GPSPosition"`
+	GPSLatitude   string        `meta:"GPSLatitude"`
+	GPSLongitude  string        `meta:"GPSLongitude"`
+	Lat           float32       `meta:"-"`
+	Lng           float32       `meta:"-"`
+	Altitude      int           `meta:"GlobalAltitude"`
+	Sublocation   string        `meta:"Sub-location"`
+	City          string        `meta:"City"`
+	State         string        `meta:"Province-State,State"`
+	Country       string        `meta:"Country-PrimaryLocationName,Country"`
+	CountryCode   string        `meta:"Country-PrimaryLocationCode,CountryCode"`
+	Width         int           `meta:"PixelXDimension,ImageWidth,ExifImageWidth,SourceImageWidth"`
+	Height        int           `meta:"PixelYDimension,ImageHeight,ImageLength,ExifImageHeight,SourceImageHeight"`
+	Orientation   int           `meta:"-"`
+	Rotation      int           `meta:"Rotation"`
+	Rating        int           `meta:"Rating"`
+	ColorLabel    string        `meta:"Label"`
+	Pick          int           `meta:"Pick"`
+	All           map[string]string
 }
 
 // AspectRatio returns the aspect ratio based on width and height.
@@ -64,6 +83,27 @@ func (data Data) Portrait() bool {
 	return data.Width < data.Height
 }
 
+// Favorite returns true if the picture was rated with 5 stars or flagged as pick.
+func (data Data) Favorite() bool {
+	return data.Rating >= 5 || data.Pick > 0
+}
+
+// Rejected returns true if the picture was rejected while culling.
+func (data Data) Rejected() bool {
+	return data.Rating < 0 || data.Pick < 0
+}
+
+// Stars returns the rating from -1 (rejected) to 5.
+func (data Data) Stars() int {
+	if data.Rejected() {
+		return -1
+	} else if data.Rating > 5 {
+		return 5
+	}
+
+	return data.Rating
+}
+
 // Megapixels returns the resolution in megapixels.
 func (data Data) Megapixels() int {
 	return int(math.Round(float64(data.Width*data.Height) / 1000000))
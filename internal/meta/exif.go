@@ -210,6 +210,10 @@ func (data *Data) Exif(fileName string) (err error) {
 		data.Exposure = value
 	}
 
+	if value, ok := tags["ExposureBiasValue"]; ok {
+		data.ExposureBias = ExposureBias(value)
+	}
+
 	if value, ok := tags["FNumber"]; ok {
 		values := strings.Split(value, "/")
 
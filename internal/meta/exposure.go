@@ -0,0 +1,40 @@
+package meta
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+// ExposureBias parses an exposure bias value like "-2/3", "+1" or "0.7 EV" and returns it in EV.
+func ExposureBias(s string) float32 {
+	s = strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(s), "EV"))
+
+	if s == "" {
+		return 0
+	}
+
+	var result float64
+
+	if values := strings.Split(s, "/"); len(values) == 2 {
+		number, err := strconv.ParseFloat(values[0], 64)
+
+		if err != nil {
+			return 0
+		}
+
+		denom, err := strconv.ParseFloat(values[1], 64)
+
+		if err != nil || denom == 0 {
+			return 0
+		}
+
+		result = number / denom
+	} else if f, err := strconv.ParseFloat(s, 64); err == nil {
+		result = f
+	} else {
+		return 0
+	}
+
+	return float32(math.Round(result*100) / 100)
+}
@@ -0,0 +1,27 @@
+package meta
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExposureBias(t *testing.T) {
+	t.Run("fraction", func(t *testing.T) {
+		assert.Equal(t, float32(-0.67), ExposureBias("-2/3"))
+		assert.Equal(t, float32(0.33), ExposureBias("+1/3"))
+	})
+	t.Run("decimal", func(t *testing.T) {
+		assert.Equal(t, float32(0.7), ExposureBias("0.7 EV"))
+		assert.Equal(t, float32(-2), ExposureBias("-2"))
+	})
+	t.Run("zero", func(t *testing.T) {
+		assert.Equal(t, float32(0), ExposureBias("0/1"))
+		assert.Equal(t, float32(0), ExposureBias("0"))
+	})
+	t.Run("invalid", func(t *testing.T) {
+		assert.Equal(t, float32(0), ExposureBias(""))
+		assert.Equal(t, float32(0), ExposureBias("1/0"))
+		assert.Equal(t, float32(0), ExposureBias("foo"))
+	})
+}
@@ -0,0 +1,170 @@
+package meta
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/tidwall/gjson"
+)
+
+const (
+	TransferPQ  = "smpte2084"
+	TransferHLG = "arib-std-b67"
+)
+
+// FFprobe parses the JSON output of "ffprobe -print_format json -show_format -show_streams" and returns a Data struct.
+func FFprobe(b []byte) (data Data, err error) {
+	err = data.FFprobe(b)
+
+	return data, err
+}
+
+// FFprobe adds video stream metadata found in ffprobe JSON output, values already set are kept.
+func (data *Data) FFprobe(b []byte) (err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = fmt.Errorf("%s (ffprobe metadata)", e)
+		}
+	}()
+
+	j := gjson.ParseBytes(b)
+
+	if !j.IsObject() || !j.Get("streams").IsArray() {
+		return fmt.Errorf("no streams found (ffprobe)")
+	}
+
+	streams := j.Get("streams").Array()
+
+	data.Streams = len(streams)
+
+	if n := int(j.Get("format.nb_streams").Int()); n > data.Streams {
+		data.Streams = n
+	}
+
+	if data.Bitrate == 0 {
+		data.Bitrate = j.Get("format.bit_rate").Int()
+	}
+
+	if data.Duration == 0 {
+		data.Duration = secondsDuration(j.Get("format.duration").String())
+	}
+
+	var video, audio gjson.Result
+
+	for _, s := range streams {
+		switch s.Get("codec_type").String() {
+		case "video":
+			// Cover art is stored as a video stream with a single frame.
+			if !video.Exists() && s.Get("disposition.attached_pic").Int() == 0 {
+				video = s
+			}
+		case "audio":
+			if !audio.Exists() {
+				audio = s
+			}
+		}
+	}
+
+	if video.Exists() {
+		if data.Codec == "" {
+			if tag := video.Get("codec_tag_string").String(); tag != "" && !strings.HasPrefix(tag, "[") {
+				data.Codec = tag
+			} else {
+				data.Codec = video.Get("codec_name").String()
+			}
+		}
+
+		if data.FrameRate == 0 {
+			data.FrameRate = frameRate(video.Get("avg_frame_rate").String())
+		}
+
+		if data.FrameRate == 0 {
+			data.FrameRate = frameRate(video.Get("r_frame_rate").String())
+		}
+
+		if data.Bitrate == 0 {
+			data.Bitrate = video.Get("bit_rate").Int()
+		}
+
+		if data.Duration == 0 {
+			data.Duration = secondsDuration(video.Get("duration").String())
+		}
+
+		if data.Rotation == 0 {
+			if r := video.Get("tags.rotate"); r.Exists() {
+				data.Rotation = int(r.Int())
+			} else if r := video.Get(`side_data_list.#(side_data_type=="Display Matrix").rotation`); r.Exists() {
+				// The display matrix rotation is counter-clockwise.
+				data.Rotation = -int(r.Int())
+			}
+
+			data.Rotation = (data.Rotation%360 + 360) % 360
+		}
+
+		// Width and height are returned as stored, so they must be swapped for portrait videos.
+		if data.Width == 0 || data.Height == 0 {
+			data.Width = int(video.Get("width").Int())
+			data.Height = int(video.Get("height").Int())
+
+			if data.Rotation == 90 || data.Rotation == 270 {
+				data.Width, data.Height = data.Height, data.Width
+			}
+		}
+
+		if data.ColorTransfer == "" {
+			data.ColorTransfer = video.Get("color_transfer").String()
+		}
+	}
+
+	if audio.Exists() && data.AudioCodec == "" {
+		data.AudioCodec = audio.Get("codec_name").String()
+	}
+
+	return nil
+}
+
+// HDR returns true if the video uses a high dynamic range transfer function.
+func (data Data) HDR() bool {
+	switch data.ColorTransfer {
+	case TransferPQ, TransferHLG:
+		return true
+	default:
+		return false
+	}
+}
+
+// frameRate parses a rational frame rate like "30000/1001".
+func frameRate(s string) float64 {
+	values := strings.Split(s, "/")
+
+	n, err := strconv.ParseFloat(values[0], 64)
+
+	if err != nil || n <= 0 {
+		return 0
+	}
+
+	if len(values) < 2 {
+		return n
+	}
+
+	d, err := strconv.ParseFloat(values[1], 64)
+
+	if err != nil || d <= 0 {
+		return 0
+	}
+
+	return n / d
+}
+
+// secondsDuration parses a duration in seconds like "3.503000".
+func secondsDuration(s string) time.Duration {
+	sec, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+
+	if err != nil || sec <= 0 {
+		return 0
+	}
+
+	return time.Duration(sec * float64(time.Second)).Round(time.Millisecond)
+}
@@ -0,0 +1,65 @@
+package meta
+
+import (
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFFprobe(t *testing.T) {
+	t.Run("ffprobe.json", func(t *testing.T) {
+		b, err := ioutil.ReadFile("testdata/ffprobe.json")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		data, err := FFprobe(b)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "hvc1", data.Codec)
+		assert.Equal(t, "aac", data.AudioCodec)
+		assert.Equal(t, 2160, data.Width)
+		assert.Equal(t, 3840, data.Height)
+		assert.InDelta(t, 59.94, data.FrameRate, 0.01)
+		assert.Equal(t, int64(45448471), data.Bitrate)
+		assert.Equal(t, 3, data.Streams)
+		assert.Equal(t, 90, data.Rotation)
+		assert.Equal(t, time.Duration(4517)*time.Millisecond, data.Duration)
+		assert.Equal(t, TransferHLG, data.ColorTransfer)
+		assert.True(t, data.HDR())
+	})
+	t.Run("keep existing values", func(t *testing.T) {
+		b, err := ioutil.ReadFile("testdata/ffprobe.json")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		data := Data{Codec: CodecAvc1, Width: 1920, Height: 1080}
+
+		if err := data.FFprobe(b); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, CodecAvc1, data.Codec)
+		assert.Equal(t, 1920, data.Width)
+		assert.Equal(t, 1080, data.Height)
+	})
+	t.Run("invalid", func(t *testing.T) {
+		_, err := FFprobe([]byte(`{"format": {}}`))
+
+		assert.Error(t, err)
+	})
+}
+
+func TestData_HDR(t *testing.T) {
+	assert.True(t, Data{ColorTransfer: TransferPQ}.HDR())
+	assert.False(t, Data{ColorTransfer: "bt709"}.HDR())
+	assert.False(t, Data{}.HDR())
+}
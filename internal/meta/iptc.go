@@ -0,0 +1,305 @@
+package meta
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// IPTC-IIM dataset numbers in the application record (2), see https://www.iptc.org/std/IIM/4.2/specification/IIMV4.2.pdf
+const (
+	IptcObjectName    = 5
+	IptcKeywords      = 25
+	IptcByline        = 80
+	IptcCity          = 90
+	IptcSublocation   = 92
+	IptcState         = 95
+	IptcCountryCode   = 100
+	IptcCountry       = 101
+	IptcCopyright     = 116
+	IptcCaption       = 120
+	iptcCodedCharset  = 90
+	iptcEnvelopeRec   = 1
+	iptcApplication   = 2
+	iptcTagMarker     = 0x1c
+	iptcResourceID    = 0x0404
+	photoshopHeader   = "Photoshop 3.0\x00"
+	photoshopResource = "8BIM"
+)
+
+var iptcCharsetUtf8 = []byte{0x1b, 0x25, 0x47}
+
+// IptcRecords maps IPTC-IIM application record datasets to their values.
+type IptcRecords map[int][]string
+
+// First returns the first value of a dataset.
+func (r IptcRecords) First(dataset int) string {
+	if values, ok := r[dataset]; ok && len(values) > 0 {
+		return values[0]
+	}
+
+	return ""
+}
+
+// IPTC parses the IPTC-IIM block of a JPEG file and returns a Data struct.
+func IPTC(fileName string) (data Data, err error) {
+	err = data.IPTC(fileName)
+
+	return data, err
+}
+
+// IPTC parses the IPTC-IIM block of a JPEG file (APP13). Values found override
+// those read from Exif, as IPTC is usually the place where agencies and editors
+// keep captions and keywords up to date.
+func (data *Data) IPTC(fileName string) (err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = fmt.Errorf("%s (iptc metadata)", e)
+		}
+	}()
+
+	records, err := ReadIptc(fileName)
+
+	if err != nil {
+		return err
+	}
+
+	if len(records) == 0 {
+		return fmt.Errorf("no iptc data in %s", txt.Quote(filepath.Base(fileName)))
+	}
+
+	if v := records.First(IptcObjectName); v != "" {
+		data.Title = v
+	}
+
+	if v := records.First(IptcCaption); v != "" {
+		data.Description = v
+	}
+
+	if v := records.First(IptcByline); v != "" {
+		data.Artist = v
+	}
+
+	if v := records.First(IptcCopyright); v != "" {
+		data.Copyright = v
+	}
+
+	if keywords := records[IptcKeywords]; len(keywords) > 0 {
+		if data.Keywords != "" {
+			keywords = append([]string{data.Keywords}, keywords...)
+		}
+
+		data.Keywords = strings.Join(keywords, ", ")
+	}
+
+	if v := records.First(IptcSublocation); v != "" {
+		data.Sublocation = v
+	}
+
+	if v := records.First(IptcCity); v != "" {
+		data.City = v
+	}
+
+	if v := records.First(IptcState); v != "" {
+		data.State = v
+	}
+
+	if v := records.First(IptcCountry); v != "" {
+		data.Country = v
+	}
+
+	if v := records.First(IptcCountryCode); v != "" {
+		data.CountryCode = v
+	}
+
+	return nil
+}
+
+// ReadIptc returns the IPTC-IIM application records embedded in a JPEG file.
+func ReadIptc(fileName string) (IptcRecords, error) {
+	f, err := os.Open(fileName)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer f.Close()
+
+	block, err := jpegApp13(bufio.NewReader(f))
+
+	if err != nil {
+		return nil, fmt.Errorf("%s in %s (iptc)", err, txt.Quote(filepath.Base(fileName)))
+	}
+
+	if len(block) == 0 {
+		return IptcRecords{}, nil
+	}
+
+	iptc, err := photoshopIptc(block)
+
+	if err != nil {
+		return nil, fmt.Errorf("%s in %s (iptc)", err, txt.Quote(filepath.Base(fileName)))
+	}
+
+	return ParseIptc(iptc)
+}
+
+// jpegApp13 returns the Photoshop APP13 segment payload of a JPEG image.
+func jpegApp13(r *bufio.Reader) ([]byte, error) {
+	soi := make([]byte, 2)
+
+	if _, err := io.ReadFull(r, soi); err != nil {
+		return nil, err
+	}
+
+	if soi[0] != 0xFF || soi[1] != 0xD8 {
+		return nil, errors.New("not a jpeg")
+	}
+
+	for {
+		marker := make([]byte, 2)
+
+		if _, err := io.ReadFull(r, marker); err != nil {
+			return nil, err
+		}
+
+		if marker[0] != 0xFF {
+			return nil, errors.New("invalid jpeg marker")
+		}
+
+		// Start of scan or end of image: no more metadata.
+		if marker[1] == 0xDA || marker[1] == 0xD9 {
+			return nil, nil
+		}
+
+		var length uint16
+
+		if err := binary.Read(r, binary.BigEndian, &length); err != nil {
+			return nil, err
+		}
+
+		if length < 2 {
+			return nil, errors.New("invalid jpeg segment length")
+		}
+
+		payload := make([]byte, int(length)-2)
+
+		if _, err := io.ReadFull(r, payload); err != nil {
+			return nil, err
+		}
+
+		if marker[1] == 0xED && bytes.HasPrefix(payload, []byte(photoshopHeader)) {
+			return payload[len(photoshopHeader):], nil
+		}
+	}
+}
+
+// photoshopIptc returns the IPTC-IIM resource contained in Photoshop image resource blocks.
+func photoshopIptc(b []byte) ([]byte, error) {
+	for len(b) >= 12 && string(b[:4]) == photoshopResource {
+		id := binary.BigEndian.Uint16(b[4:6])
+
+		// Pascal string name padded to an even length.
+		nameLen := 1 + int(b[6])
+		nameLen += nameLen % 2
+
+		if len(b) < 6+nameLen+4 {
+			return nil, errors.New("truncated photoshop resource header")
+		}
+
+		size := int(binary.BigEndian.Uint32(b[6+nameLen : 10+nameLen]))
+		start := 10 + nameLen
+
+		if size < 0 || len(b) < start+size {
+			return nil, errors.New("truncated photoshop resource")
+		}
+
+		if id == iptcResourceID {
+			return b[start : start+size], nil
+		}
+
+		// Resource data is padded to an even length.
+		next := start + size + size%2
+
+		if next > len(b) {
+			return nil, errors.New("truncated photoshop resource")
+		}
+
+		b = b[next:]
+	}
+
+	return nil, nil
+}
+
+// ParseIptc parses IPTC-IIM datasets and returns the values of the application record.
+func ParseIptc(b []byte) (IptcRecords, error) {
+	result := make(IptcRecords)
+	utf8Charset := false
+
+	for len(b) >= 5 {
+		if b[0] != iptcTagMarker {
+			return result, errors.New("invalid iptc tag marker")
+		}
+
+		record, dataset := int(b[1]), int(b[2])
+		size := int(binary.BigEndian.Uint16(b[3:5]))
+		b = b[5:]
+
+		// Extended datasets are not used for text values.
+		if size&0x8000 != 0 {
+			return result, errors.New("extended iptc datasets not supported")
+		}
+
+		if len(b) < size {
+			return result, errors.New("truncated iptc dataset")
+		}
+
+		value := b[:size]
+		b = b[size:]
+
+		switch record {
+		case iptcEnvelopeRec:
+			if dataset == iptcCodedCharset && bytes.Equal(value, iptcCharsetUtf8) {
+				utf8Charset = true
+			}
+		case iptcApplication:
+			if dataset == 0 {
+				continue
+			}
+
+			s := iptcString(value, utf8Charset)
+
+			if s == "" {
+				continue
+			}
+
+			result[dataset] = append(result[dataset], s)
+		}
+	}
+
+	return result, nil
+}
+
+// iptcString decodes a dataset value, assuming ISO 8859-1 for non UTF-8 text.
+func iptcString(b []byte, isUtf8 bool) string {
+	if isUtf8 || utf8.Valid(b) {
+		return SanitizeString(string(b))
+	}
+
+	runes := make([]rune, len(b))
+
+	for i, c := range b {
+		runes[i] = rune(c)
+	}
+
+	return SanitizeString(string(runes))
+}
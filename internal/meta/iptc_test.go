@@ -0,0 +1,115 @@
+package meta
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIPTC(t *testing.T) {
+	t.Run("photoshop.jpg", func(t *testing.T) {
+		data, err := IPTC("testdata/photoshop.jpg")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "Night Shift / Berlin / 2020", data.Title)
+		assert.Equal(t, "Example file for development", data.Description)
+		assert.Equal(t, "Michael Mayer", data.Artist)
+		assert.Equal(t, "This is a legal notice", data.Copyright)
+		assert.Equal(t, "desk, coffee, compuer", data.Keywords)
+		assert.Equal(t, "", data.City)
+	})
+
+	t.Run("ladybug.jpg", func(t *testing.T) {
+		data, err := IPTC("testdata/ladybug.jpg")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "Ladybug", data.Title)
+		assert.Equal(t, "Photographer: TMB", data.Artist)
+		assert.Equal(t, "Ladybug", data.Keywords)
+	})
+
+	t.Run("iptc-agency.jpg", func(t *testing.T) {
+		data, err := IPTC("testdata/iptc-agency.jpg")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "Brandenburger Tor", data.Title)
+		assert.Equal(t, "Tourists in front of the gate", data.Description)
+		assert.Equal(t, "landmark, gate", data.Keywords)
+		assert.Equal(t, "Jane Doe", data.Artist)
+		assert.Equal(t, "© 2020 Example Agency", data.Copyright)
+		assert.Equal(t, "Pariser Platz", data.Sublocation)
+		assert.Equal(t, "Berlin", data.City)
+		assert.Equal(t, "Berlin", data.State)
+		assert.Equal(t, "Germany", data.Country)
+		assert.Equal(t, "DEU", data.CountryCode)
+	})
+
+	t.Run("image-2011.jpg", func(t *testing.T) {
+		_, err := IPTC("testdata/image-2011.jpg")
+
+		assert.Error(t, err)
+	})
+
+	t.Run("no-exif-data.jpg", func(t *testing.T) {
+		_, err := IPTC("testdata/no-exif-data.jpg")
+
+		assert.Error(t, err)
+	})
+}
+
+func TestParseIptc(t *testing.T) {
+	t.Run("latin1", func(t *testing.T) {
+		records, err := ParseIptc([]byte{0x1c, 2, 90, 0, 5, 'K', 0xf6, 'l', 'n', ' '})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "Köln", records.First(IptcCity))
+	})
+
+	t.Run("truncated", func(t *testing.T) {
+		_, err := ParseIptc([]byte{0x1c, 2, 90, 0, 10, 'B'})
+
+		assert.Error(t, err)
+	})
+}
+
+func TestPhotoshopIptc(t *testing.T) {
+	t.Run("iptc", func(t *testing.T) {
+		b := []byte{'8', 'B', 'I', 'M', 0x04, 0x04, 0, 0, 0, 0, 0, 3, 0x1c, 2, 0}
+
+		iptc, err := photoshopIptc(b)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, []byte{0x1c, 2, 0}, iptc)
+	})
+
+	t.Run("missing padding", func(t *testing.T) {
+		b := []byte{'8', 'B', 'I', 'M', 0x03, 0xed, 0, 0, 0, 0, 0, 3, 1, 2, 3}
+
+		_, err := photoshopIptc(b)
+
+		assert.EqualError(t, err, "truncated photoshop resource")
+	})
+
+	t.Run("truncated", func(t *testing.T) {
+		b := []byte{'8', 'B', 'I', 'M', 0x04, 0x04, 0, 0, 0, 0, 0, 9, 1, 2, 3}
+
+		_, err := photoshopIptc(b)
+
+		assert.EqualError(t, err, "truncated photoshop resource")
+	})
+}
@@ -102,6 +102,25 @@ func (data *Data) JSON(fileName string) (err error) {
 		}
 	}
 
+	// Exposure bias may be a fraction like "+2/3".
+	if value, ok := jsonValues["ExposureCompensation"]; ok {
+		data.ExposureBias = ExposureBias(value.String())
+	}
+
+	// Names and face regions of people shown.
+	doc := gjson.ParseBytes(jsonString)
+
+	if doc.IsArray() {
+		doc = doc.Get("0")
+	}
+
+	for _, name := range jsonList(doc.Get("PersonInImage")) {
+		data.AddPeople(name.String())
+	}
+
+	data.Regions = append(data.Regions, jsonRegions(doc)...)
+	data.AddPeople(data.Regions.Faces().Names()...)
+
 	// Calculate latitude and longitude if exists.
 	if data.GPSPosition != "" {
 		data.Lat, data.Lng = GpsToLatLng(data.GPSPosition)
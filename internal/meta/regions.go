@@ -0,0 +1,206 @@
+package meta
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/tidwall/gjson"
+)
+
+const (
+	RegionTypeFace = "Face"
+)
+
+// Region represents a named image area, e.g. a face. Coordinates are relative to
+// the image size, starting with the top left corner.
+type Region struct {
+	Name string
+	Type string
+	X    float32
+	Y    float32
+	W    float32
+	H    float32
+}
+
+// Regions represents a list of image regions.
+type Regions []Region
+
+// Faces returns face regions only.
+func (r Regions) Faces() (result Regions) {
+	for _, region := range r {
+		if strings.EqualFold(region.Type, RegionTypeFace) {
+			result = append(result, region)
+		}
+	}
+
+	return result
+}
+
+// Names returns the unique names of regions.
+func (r Regions) Names() (result []string) {
+	for _, region := range r {
+		result = appendName(result, region.Name)
+	}
+
+	return result
+}
+
+// Person returns the region of a person, if any.
+func (r Regions) Person(name string) (Region, bool) {
+	for _, region := range r {
+		if strings.EqualFold(region.Name, name) {
+			return region, true
+		}
+	}
+
+	return Region{}, false
+}
+
+// AddPeople adds names of people shown, without duplicates.
+func (data *Data) AddPeople(names ...string) {
+	for _, name := range names {
+		data.People = appendName(data.People, name)
+	}
+}
+
+// appendName appends a name if it is not empty and not in the list yet.
+func appendName(names []string, name string) []string {
+	name = SanitizeString(name)
+
+	if name == "" {
+		return names
+	}
+
+	for _, n := range names {
+		if strings.EqualFold(n, name) {
+			return names
+		}
+	}
+
+	return append(names, name)
+}
+
+// mwgRegion returns a region with MWG area coordinates, which refer to the region center.
+func mwgRegion(name, regionType string, x, y, w, h float64) (Region, bool) {
+	if name == "" || w <= 0 || h <= 0 {
+		return Region{}, false
+	}
+
+	if regionType == "" {
+		regionType = RegionTypeFace
+	}
+
+	return Region{
+		Name: name,
+		Type: regionType,
+		X:    float32(x - w/2),
+		Y:    float32(y - h/2),
+		W:    float32(w),
+		H:    float32(h),
+	}, true
+}
+
+// mpRegion returns a region based on a Microsoft Photo rectangle string, e.g. "0.1, 0.2, 0.3, 0.4".
+func mpRegion(name, rect string) (Region, bool) {
+	values := strings.Split(rect, ",")
+
+	if name == "" || len(values) != 4 {
+		return Region{}, false
+	}
+
+	var f [4]float64
+
+	for i, v := range values {
+		n, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+
+		if err != nil {
+			return Region{}, false
+		}
+
+		f[i] = n
+	}
+
+	if f[2] <= 0 || f[3] <= 0 {
+		return Region{}, false
+	}
+
+	return Region{
+		Name: name,
+		Type: RegionTypeFace,
+		X:    float32(f[0]),
+		Y:    float32(f[1]),
+		W:    float32(f[2]),
+		H:    float32(f[3]),
+	}, true
+}
+
+// jsonRegions returns face regions found in Exiftool JSON, supporting both
+// structured (-struct) and flattened output.
+func jsonRegions(j gjson.Result) (result Regions) {
+	if list := j.Get("RegionInfo.RegionList"); list.IsArray() {
+		for _, r := range list.Array() {
+			if region, ok := mwgRegion(
+				r.Get("Name").String(),
+				r.Get("Type").String(),
+				r.Get("Area.X").Float(),
+				r.Get("Area.Y").Float(),
+				r.Get("Area.W").Float(),
+				r.Get("Area.H").Float()); ok {
+				result = append(result, region)
+			}
+		}
+	} else if names := jsonList(j.Get("RegionName")); len(names) > 0 {
+		types := jsonList(j.Get("RegionType"))
+		x, y := jsonList(j.Get("RegionAreaX")), jsonList(j.Get("RegionAreaY"))
+		w, h := jsonList(j.Get("RegionAreaW")), jsonList(j.Get("RegionAreaH"))
+
+		for i, name := range names {
+			if i >= len(x) || i >= len(y) || i >= len(w) || i >= len(h) {
+				break
+			}
+
+			regionType := ""
+
+			if i < len(types) {
+				regionType = types[i].String()
+			}
+
+			if region, ok := mwgRegion(name.String(), regionType, x[i].Float(), y[i].Float(), w[i].Float(), h[i].Float()); ok {
+				result = append(result, region)
+			}
+		}
+	}
+
+	if list := j.Get("RegionInfoMP.Regions"); list.IsArray() {
+		for _, r := range list.Array() {
+			if region, ok := mpRegion(r.Get("PersonDisplayName").String(), r.Get("Rectangle").String()); ok {
+				result = append(result, region)
+			}
+		}
+	} else if names := jsonList(j.Get("RegionPersonDisplayName")); len(names) > 0 {
+		rects := jsonList(j.Get("RegionRectangle"))
+
+		for i, name := range names {
+			if i >= len(rects) {
+				break
+			}
+
+			if region, ok := mpRegion(name.String(), rects[i].String()); ok {
+				result = append(result, region)
+			}
+		}
+	}
+
+	return result
+}
+
+// jsonList returns a value as list, so that single values and arrays can be handled the same way.
+func jsonList(r gjson.Result) []gjson.Result {
+	if !r.Exists() {
+		return nil
+	} else if r.IsArray() {
+		return r.Array()
+	}
+
+	return []gjson.Result{r}
+}
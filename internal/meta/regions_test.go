@@ -0,0 +1,84 @@
+package meta
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegions_Faces(t *testing.T) {
+	regions := Regions{
+		{Name: "Jane Doe", Type: RegionTypeFace},
+		{Name: "Bello", Type: "Pet"},
+	}
+
+	assert.Equal(t, []string{"Jane Doe"}, regions.Faces().Names())
+	assert.Equal(t, []string{"Jane Doe", "Bello"}, regions.Names())
+}
+
+func TestRegions_Person(t *testing.T) {
+	regions := Regions{{Name: "Jane Doe", Type: RegionTypeFace, X: 0.1}}
+
+	t.Run("found", func(t *testing.T) {
+		r, ok := regions.Person("jane doe")
+		assert.True(t, ok)
+		assert.Equal(t, float32(0.1), r.X)
+	})
+	t.Run("not found", func(t *testing.T) {
+		_, ok := regions.Person("John Doe")
+		assert.False(t, ok)
+	})
+}
+
+func TestData_AddPeople(t *testing.T) {
+	data := Data{}
+	data.AddPeople("Jane Doe", " ", "jane doe", "John Doe")
+	assert.Equal(t, []string{"Jane Doe", "John Doe"}, data.People)
+}
+
+func TestXMP_Regions(t *testing.T) {
+	t.Run("people-mwg.xmp", func(t *testing.T) {
+		data, err := XMP("testdata/people-mwg.xmp")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, []string{"Jane Doe", "Max Mustermann", "John Doe"}, data.People)
+		assert.Len(t, data.Regions, 3)
+		assert.Equal(t, "Jane Doe", data.Regions[0].Name)
+		assert.InDelta(t, 0.4, data.Regions[0].X, 0.0001)
+		assert.InDelta(t, 0.25, data.Regions[0].Y, 0.0001)
+		assert.InDelta(t, 0.2, data.Regions[0].W, 0.0001)
+		assert.InDelta(t, 0.3, data.Regions[0].H, 0.0001)
+		assert.Equal(t, "John Doe", data.Regions[1].Name)
+		assert.InDelta(t, 0.15, data.Regions[1].X, 0.0001)
+		assert.Equal(t, "Pet", data.Regions[2].Type)
+	})
+	t.Run("people-mp.xmp", func(t *testing.T) {
+		data, err := XMP("testdata/people-mp.xmp")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, []string{"John Doe", "Jane Doe"}, data.People)
+		assert.Len(t, data.Regions, 2)
+		assert.InDelta(t, 0.1, data.Regions[0].X, 0.0001)
+		assert.InDelta(t, 0.4, data.Regions[0].H, 0.0001)
+		assert.InDelta(t, 0.25, data.Regions[1].W, 0.0001)
+	})
+}
+
+func TestJSON_Regions(t *testing.T) {
+	data, err := JSON("testdata/people.json")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, []string{"Jane Doe", "Max Mustermann", "John Doe"}, data.People)
+	assert.Len(t, data.Regions, 2)
+	assert.InDelta(t, 0.4, data.Regions[0].X, 0.0001)
+	assert.InDelta(t, 0.2, data.Regions[1].H, 0.0001)
+}
@@ -0,0 +1,157 @@
+package meta
+
+import (
+	"fmt"
+	"io/ioutil"
+	"math"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/photoprism/photoprism/pkg/txt"
+	"github.com/tidwall/gjson"
+	"gopkg.in/ugjka/go-tz.v2/tz"
+)
+
+// TakeoutAlbum represents album metadata as found in Google Takeout exports.
+type TakeoutAlbum struct {
+	Title       string
+	Description string
+	Date        time.Time
+}
+
+// IsTakeout returns true if the file is a Google Takeout json sidecar file.
+func IsTakeout(fileName string) bool {
+	b, err := ioutil.ReadFile(fileName)
+
+	if err != nil {
+		return false
+	}
+
+	return gjson.GetBytes(b, "photoTakenTime").Exists()
+}
+
+// Takeout parses a Google Takeout json sidecar file and returns a Data struct.
+func Takeout(fileName string) (data Data, err error) {
+	err = data.Takeout(fileName)
+
+	return data, err
+}
+
+// Takeout parses a Google Takeout json sidecar file and returns a Data struct.
+func (data *Data) Takeout(fileName string) (err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = fmt.Errorf("%s (takeout metadata)", e)
+		}
+	}()
+
+	b, err := ioutil.ReadFile(fileName)
+
+	if err != nil {
+		log.Warnf("meta: %s", err.Error())
+		return fmt.Errorf("can't read %s (takeout)", txt.Quote(filepath.Base(fileName)))
+	}
+
+	j := gjson.ParseBytes(b)
+
+	if !j.Get("photoTakenTime").Exists() {
+		return fmt.Errorf("%s is not a takeout sidecar file", txt.Quote(filepath.Base(fileName)))
+	}
+
+	if ts := j.Get("photoTakenTime.timestamp").Int(); ts > 0 {
+		data.TakenAt = time.Unix(ts, 0).UTC()
+		data.TakenAtLocal = data.TakenAt
+	}
+
+	if s := strings.TrimSpace(j.Get("description").String()); s != "" {
+		data.Description = s
+	}
+
+	// Location as set in Google Photos, falls back to the coordinates found in Exif.
+	for _, key := range []string{"geoData", "geoDataExif"} {
+		lat, lng := j.Get(key+".latitude").Float(), j.Get(key+".longitude").Float()
+
+		if lat == 0 && lng == 0 {
+			continue
+		}
+
+		data.Lat = float32(lat)
+		data.Lng = float32(lng)
+		data.Altitude = int(math.Round(j.Get(key + ".altitude").Float()))
+
+		break
+	}
+
+	var people []string
+
+	for _, p := range j.Get("people.#.name").Array() {
+		if name := strings.TrimSpace(p.String()); name != "" {
+			people = append(people, name)
+		}
+	}
+
+	if len(people) > 0 {
+		data.Subject = strings.Join(people, ", ")
+		data.AddPeople(people...)
+	}
+
+	// Favorites are treated like picked photos.
+	if j.Get("favorited").Bool() {
+		data.Pick = 1
+	}
+
+	// Set time zone and local time based on the location.
+	if data.Lat != 0 && data.Lng != 0 && !data.TakenAt.IsZero() {
+		zones, err := tz.GetZone(tz.Point{
+			Lat: float64(data.Lat),
+			Lon: float64(data.Lng),
+		})
+
+		if err == nil && len(zones) > 0 {
+			data.TimeZone = zones[0]
+		}
+
+		if loc, err := time.LoadLocation(data.TimeZone); err != nil {
+			log.Warnf("meta: unknown time zone %s", data.TimeZone)
+		} else {
+			t := data.TakenAt.In(loc)
+			data.TakenAtLocal = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), 0, time.UTC)
+		}
+	}
+
+	return nil
+}
+
+// ReadTakeoutAlbum parses the metadata file of a Google Takeout album folder.
+func ReadTakeoutAlbum(fileName string) (result TakeoutAlbum, err error) {
+	b, err := ioutil.ReadFile(fileName)
+
+	if err != nil {
+		return result, err
+	}
+
+	j := gjson.ParseBytes(b)
+
+	if j.Get("photoTakenTime").Exists() {
+		return result, fmt.Errorf("%s is not a takeout album file", txt.Quote(filepath.Base(fileName)))
+	}
+
+	// Older exports nest album properties in "albumData".
+	if a := j.Get("albumData"); a.Exists() {
+		j = a
+	}
+
+	result.Title = strings.TrimSpace(j.Get("title").String())
+	result.Description = strings.TrimSpace(j.Get("description").String())
+
+	if ts := j.Get("date.timestamp").Int(); ts > 0 {
+		result.Date = time.Unix(ts, 0).UTC()
+	}
+
+	if result.Title == "" {
+		return result, fmt.Errorf("no album title in %s (takeout)", txt.Quote(filepath.Base(fileName)))
+	}
+
+	return result, nil
+}
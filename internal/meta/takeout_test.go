@@ -0,0 +1,60 @@
+package meta
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsTakeout(t *testing.T) {
+	assert.True(t, IsTakeout("testdata/takeout.json"))
+	assert.False(t, IsTakeout("testdata/takeout-album.json"))
+	assert.False(t, IsTakeout("testdata/gopher-telegram.json"))
+	assert.False(t, IsTakeout("testdata/no-such-file.json"))
+}
+
+func TestTakeout(t *testing.T) {
+	t.Run("takeout.json", func(t *testing.T) {
+		data, err := Takeout("testdata/takeout.json")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "2019-06-14T08:15:27Z", data.TakenAt.Format("2006-01-02T15:04:05Z"))
+		assert.Equal(t, "2019-06-14T10:15:27Z", data.TakenAtLocal.Format("2006-01-02T15:04:05Z"))
+		assert.Equal(t, "Europe/Berlin", data.TimeZone)
+		assert.Equal(t, "Picnic at the lake", data.Description)
+		assert.Equal(t, float32(52.4596), data.Lat)
+		assert.Equal(t, float32(13.3218), data.Lng)
+		assert.Equal(t, 50, data.Altitude)
+		assert.Equal(t, "Jane Doe, John Doe", data.Subject)
+		assert.True(t, data.Favorite())
+	})
+
+	t.Run("exiftool", func(t *testing.T) {
+		_, err := Takeout("testdata/gopher-telegram.json")
+
+		assert.Error(t, err)
+	})
+}
+
+func TestReadTakeoutAlbum(t *testing.T) {
+	t.Run("takeout-album.json", func(t *testing.T) {
+		album, err := ReadTakeoutAlbum("testdata/takeout-album.json")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "Summer 2019", album.Title)
+		assert.Equal(t, "Holidays with friends", album.Description)
+		assert.Equal(t, 2019, album.Date.Year())
+	})
+
+	t.Run("takeout.json", func(t *testing.T) {
+		_, err := ReadTakeoutAlbum("testdata/takeout.json")
+
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,278 @@
+package meta
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+const (
+	GpxExt = ".gpx"
+	KmlExt = ".kml"
+)
+
+// TrackPoint represents a position recorded by a GPS logger.
+type TrackPoint struct {
+	Time     time.Time
+	Lat      float64
+	Lng      float64
+	Altitude float64
+}
+
+// Track represents GPS track points sorted by time.
+type Track []TrackPoint
+
+func (t Track) Len() int           { return len(t) }
+func (t Track) Swap(i, j int)      { t[i], t[j] = t[j], t[i] }
+func (t Track) Less(i, j int) bool { return t[i].Time.Before(t[j].Time) }
+
+// Start returns the time of the first track point.
+func (t Track) Start() time.Time {
+	if len(t) == 0 {
+		return time.Time{}
+	}
+
+	return t[0].Time
+}
+
+// End returns the time of the last track point.
+func (t Track) End() time.Time {
+	if len(t) == 0 {
+		return time.Time{}
+	}
+
+	return t[len(t)-1].Time
+}
+
+// Position returns the interpolated position at a given time. Positions are only returned
+// if a track point was recorded within maxGap, so that gaps in a track are not bridged.
+func (t Track) Position(at time.Time, maxGap time.Duration) (result TrackPoint, ok bool) {
+	if len(t) == 0 || at.IsZero() {
+		return result, false
+	}
+
+	i := sort.Search(len(t), func(i int) bool { return !t[i].Time.Before(at) })
+
+	if i < len(t) && t[i].Time.Equal(at) {
+		return t[i], true
+	}
+
+	// Before the first or after the last point.
+	if i == 0 {
+		return t[0], t[0].Time.Sub(at) <= maxGap
+	} else if i == len(t) {
+		return t[i-1], at.Sub(t[i-1].Time) <= maxGap
+	}
+
+	prev, next := t[i-1], t[i]
+
+	if next.Time.Sub(prev.Time) > maxGap {
+		if d := at.Sub(prev.Time); d <= maxGap && d <= next.Time.Sub(at) {
+			return prev, true
+		} else if next.Time.Sub(at) <= maxGap {
+			return next, true
+		}
+
+		return result, false
+	}
+
+	f := float64(at.Sub(prev.Time)) / float64(next.Time.Sub(prev.Time))
+
+	result = TrackPoint{
+		Time:     at,
+		Lat:      prev.Lat + (next.Lat-prev.Lat)*f,
+		Lng:      prev.Lng + (next.Lng-prev.Lng)*f,
+		Altitude: prev.Altitude + (next.Altitude-prev.Altitude)*f,
+	}
+
+	return result, true
+}
+
+// IsTrack returns true if the file extension is supported by ReadTrack.
+func IsTrack(fileName string) bool {
+	switch strings.ToLower(filepath.Ext(fileName)) {
+	case GpxExt, KmlExt:
+		return true
+	default:
+		return false
+	}
+}
+
+// ReadTrack parses a GPX or KML file and returns the track points sorted by time.
+func ReadTrack(fileName string) (result Track, err error) {
+	b, err := ioutil.ReadFile(fileName)
+
+	if err != nil {
+		return result, err
+	}
+
+	switch strings.ToLower(filepath.Ext(fileName)) {
+	case GpxExt:
+		result, err = ParseGpx(b)
+	case KmlExt:
+		result, err = ParseKml(b)
+	default:
+		return result, fmt.Errorf("%s is not a gpx or kml file", txt.Quote(filepath.Base(fileName)))
+	}
+
+	if err != nil {
+		return result, fmt.Errorf("%s in %s (track)", err, txt.Quote(filepath.Base(fileName)))
+	}
+
+	if len(result) == 0 {
+		return result, fmt.Errorf("no track points in %s", txt.Quote(filepath.Base(fileName)))
+	}
+
+	return result, nil
+}
+
+type gpxPoint struct {
+	Lat  float64 `xml:"lat,attr"`
+	Lon  float64 `xml:"lon,attr"`
+	Ele  float64 `xml:"ele"`
+	Time string  `xml:"time"`
+}
+
+type gpxDocument struct {
+	Waypoints []gpxPoint `xml:"wpt"`
+	Routes    []struct {
+		Points []gpxPoint `xml:"rtept"`
+	} `xml:"rte"`
+	Tracks []struct {
+		Segments []struct {
+			Points []gpxPoint `xml:"trkpt"`
+		} `xml:"trkseg"`
+	} `xml:"trk"`
+}
+
+// ParseGpx parses GPX track, route and waypoints that have a timestamp.
+func ParseGpx(b []byte) (result Track, err error) {
+	doc := gpxDocument{}
+
+	if err := xml.Unmarshal(b, &doc); err != nil {
+		return result, err
+	}
+
+	points := doc.Waypoints
+
+	for _, rte := range doc.Routes {
+		points = append(points, rte.Points...)
+	}
+
+	for _, trk := range doc.Tracks {
+		for _, seg := range trk.Segments {
+			points = append(points, seg.Points...)
+		}
+	}
+
+	for _, p := range points {
+		t, err := time.Parse(time.RFC3339, strings.TrimSpace(p.Time))
+
+		if err != nil || (p.Lat == 0 && p.Lon == 0) {
+			continue
+		}
+
+		result = append(result, TrackPoint{Time: t.UTC(), Lat: p.Lat, Lng: p.Lon, Altitude: p.Ele})
+	}
+
+	sort.Stable(result)
+
+	return result, nil
+}
+
+type kmlTrack struct {
+	When  []string `xml:"when"`
+	Coord []string `xml:"coord"`
+}
+
+type kmlPlacemark struct {
+	When        string     `xml:"TimeStamp>when"`
+	Coordinates string     `xml:"Point>coordinates"`
+	Tracks      []kmlTrack `xml:"Track"`
+	MultiTracks []kmlTrack `xml:"MultiTrack>Track"`
+}
+
+// ParseKml parses KML placemarks with a timestamp and gx:Track elements.
+func ParseKml(b []byte) (result Track, err error) {
+	d := xml.NewDecoder(bytes.NewReader(b))
+
+	for {
+		token, err := d.Token()
+
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return result, err
+		}
+
+		start, ok := token.(xml.StartElement)
+
+		if !ok || start.Name.Local != "Placemark" {
+			continue
+		}
+
+		p := kmlPlacemark{}
+
+		if err := d.DecodeElement(&p, &start); err != nil {
+			return result, err
+		}
+
+		if t, err := time.Parse(time.RFC3339, strings.TrimSpace(p.When)); err == nil {
+			if lat, lng, alt, ok := kmlCoordinates(p.Coordinates, ","); ok {
+				result = append(result, TrackPoint{Time: t.UTC(), Lat: lat, Lng: lng, Altitude: alt})
+			}
+		}
+
+		for _, trk := range append(p.Tracks, p.MultiTracks...) {
+			for i := 0; i < len(trk.When) && i < len(trk.Coord); i++ {
+				t, err := time.Parse(time.RFC3339, strings.TrimSpace(trk.When[i]))
+
+				if err != nil {
+					continue
+				}
+
+				if lat, lng, alt, ok := kmlCoordinates(trk.Coord[i], " "); ok {
+					result = append(result, TrackPoint{Time: t.UTC(), Lat: lat, Lng: lng, Altitude: alt})
+				}
+			}
+		}
+	}
+
+	sort.Stable(result)
+
+	return result, nil
+}
+
+// kmlCoordinates parses KML coordinates in longitude, latitude, altitude order.
+func kmlCoordinates(s, sep string) (lat, lng, alt float64, ok bool) {
+	values := strings.Split(strings.TrimSpace(s), sep)
+
+	if len(values) < 2 {
+		return lat, lng, alt, false
+	}
+
+	var err error
+
+	if lng, err = strconv.ParseFloat(strings.TrimSpace(values[0]), 64); err != nil {
+		return lat, lng, alt, false
+	}
+
+	if lat, err = strconv.ParseFloat(strings.TrimSpace(values[1]), 64); err != nil {
+		return lat, lng, alt, false
+	}
+
+	if len(values) > 2 {
+		alt, _ = strconv.ParseFloat(strings.TrimSpace(values[2]), 64)
+	}
+
+	return lat, lng, alt, lat != 0 || lng != 0
+}
@@ -0,0 +1,100 @@
+package meta
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsTrack(t *testing.T) {
+	assert.True(t, IsTrack("testdata/track.gpx"))
+	assert.True(t, IsTrack("testdata/TRACK.KML"))
+	assert.False(t, IsTrack("testdata/takeout.json"))
+}
+
+func TestReadTrack(t *testing.T) {
+	t.Run("track.gpx", func(t *testing.T) {
+		track, err := ReadTrack("testdata/track.gpx")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, track, 3)
+		assert.Equal(t, time.Date(2020, 5, 1, 10, 0, 0, 0, time.UTC), track.Start())
+		assert.Equal(t, time.Date(2020, 5, 1, 12, 0, 0, 0, time.UTC), track.End())
+		assert.Equal(t, 52.52, track[0].Lat)
+		assert.Equal(t, 13.4, track[0].Lng)
+		assert.Equal(t, float64(40), track[0].Altitude)
+	})
+	t.Run("track.kml", func(t *testing.T) {
+		track, err := ReadTrack("testdata/track.kml")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, track, 3)
+		assert.Equal(t, time.Date(2020, 5, 1, 9, 55, 0, 0, time.UTC), track.Start())
+		assert.Equal(t, 52.51, track[0].Lat)
+		assert.Equal(t, 13.39, track[0].Lng)
+		assert.Equal(t, 52.53, track[2].Lat)
+		assert.Equal(t, float64(50), track[2].Altitude)
+	})
+	t.Run("takeout.json", func(t *testing.T) {
+		_, err := ReadTrack("testdata/takeout.json")
+
+		assert.Error(t, err)
+	})
+	t.Run("no-such-file.gpx", func(t *testing.T) {
+		_, err := ReadTrack("testdata/no-such-file.gpx")
+
+		assert.Error(t, err)
+	})
+}
+
+func TestTrack_Position(t *testing.T) {
+	track, err := ReadTrack("testdata/track.gpx")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("exact", func(t *testing.T) {
+		p, ok := track.Position(time.Date(2020, 5, 1, 10, 10, 0, 0, time.UTC), time.Minute)
+
+		assert.True(t, ok)
+		assert.Equal(t, 52.53, p.Lat)
+	})
+	t.Run("interpolated", func(t *testing.T) {
+		p, ok := track.Position(time.Date(2020, 5, 1, 10, 5, 0, 0, time.UTC), time.Hour)
+
+		assert.True(t, ok)
+		assert.InDelta(t, 52.525, p.Lat, 0.00001)
+		assert.InDelta(t, 13.405, p.Lng, 0.00001)
+		assert.InDelta(t, 45, p.Altitude, 0.00001)
+	})
+	t.Run("gap", func(t *testing.T) {
+		_, ok := track.Position(time.Date(2020, 5, 1, 11, 0, 0, 0, time.UTC), time.Hour/2)
+
+		assert.False(t, ok)
+	})
+	t.Run("near gap start", func(t *testing.T) {
+		p, ok := track.Position(time.Date(2020, 5, 1, 10, 15, 0, 0, time.UTC), time.Hour/2)
+
+		assert.True(t, ok)
+		assert.Equal(t, 52.53, p.Lat)
+	})
+	t.Run("before start", func(t *testing.T) {
+		_, ok := track.Position(time.Date(2020, 5, 1, 9, 0, 0, 0, time.UTC), time.Hour/2)
+
+		assert.False(t, ok)
+	})
+	t.Run("after end", func(t *testing.T) {
+		p, ok := track.Position(time.Date(2020, 5, 1, 12, 5, 0, 0, time.UTC), time.Hour/2)
+
+		assert.True(t, ok)
+		assert.Equal(t, 52.54, p.Lat)
+	})
+}
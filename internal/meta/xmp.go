@@ -3,6 +3,7 @@ package meta
 import (
 	"fmt"
 	"path/filepath"
+	"strings"
 
 	"github.com/photoprism/photoprism/pkg/txt"
 )
@@ -56,5 +57,36 @@ func (data *Data) XMP(fileName string) (err error) {
 		data.LensModel = doc.LensModel()
 	}
 
+	if rating := doc.Rating(); rating != 0 {
+		data.Rating = rating
+	}
+
+	if doc.ColorLabel() != "" {
+		data.ColorLabel = doc.ColorLabel()
+	}
+
+	if pick := doc.Pick(); pick != 0 {
+		data.Pick = pick
+	}
+
+	if keywords := doc.Keywords(); len(keywords) > 0 {
+		if data.Keywords != "" {
+			keywords = append([]string{data.Keywords}, keywords...)
+		}
+
+		data.Keywords = strings.Join(keywords, ", ")
+	}
+
+	if labels := doc.HierarchicalSubject(); len(labels) > 0 {
+		data.Labels = append(data.Labels, labels...)
+	}
+
+	if regions := doc.Regions(); len(regions) > 0 {
+		data.Regions = append(data.Regions, regions...)
+	}
+
+	data.AddPeople(doc.People()...)
+	data.AddPeople(data.Regions.Faces().Names()...)
+
 	return nil
 }
@@ -3,6 +3,8 @@ package meta
 import (
 	"encoding/xml"
 	"io/ioutil"
+	"strconv"
+	"strings"
 )
 
 // XmpDocument represents an XMP sidecar file.
@@ -28,11 +30,18 @@ type XmpDocument struct {
 			XmpRights       string `xml:"xmpRights,attr" json:"xmprights,omitempty"`
 			Iptc4xmpCore    string `xml:"Iptc4xmpCore,attr" json:"iptc4xmpcore,omitempty"`
 			Iptc4xmpExt     string `xml:"Iptc4xmpExt,attr" json:"iptc4xmpext,omitempty"`
+			XmpDM           string `xml:"xmpDM,attr" json:"xmpdm,omitempty"`
+			Lr              string `xml:"lr,attr" json:"lr,omitempty"`
+			RatingAttr      string `xml:"Rating,attr" json:"ratingattr,omitempty"`
+			LabelAttr       string `xml:"Label,attr" json:"labelattr,omitempty"`
+			PickAttr        string `xml:"pick,attr" json:"pickattr,omitempty"`
 			CreatorTool     string `xml:"CreatorTool"`     // ELE-L29 10.0.0.168(C431E2...
 			ModifyDate      string `xml:"ModifyDate"`      // 2020-01-01T17:28:23.89961...
 			CreateDate      string `xml:"CreateDate"`      // 2020-01-01T17:28:23
 			MetadataDate    string `xml:"MetadataDate"`    // 2020-01-01T17:28:23.89961...
 			Rating          string `xml:"Rating"`          // 4
+			Label           string `xml:"Label"`           // Red
+			Pick            string `xml:"pick"`            // 1
 			Lens            string `xml:"Lens"`            // HUAWEI P30 Rear Main Came...
 			LensModel       string `xml:"LensModel"`       // HUAWEI P30 Rear Main Came...
 			DateCreated     string `xml:"DateCreated"`     // 2020-01-01T17:28:25.72962...
@@ -76,6 +85,13 @@ type XmpDocument struct {
 					Li   []string `xml:"li"` // desk, coffee, computer
 				} `xml:"Bag" json:"bag,omitempty"`
 			} `xml:"subject" json:"subject,omitempty"`
+			HierarchicalSubject struct {
+				Text string `xml:",chardata" json:"text,omitempty"`
+				Bag  struct {
+					Text string   `xml:",chardata" json:"text,omitempty"`
+					Li   []string `xml:"li"` // Animals|Birds|Owl
+				} `xml:"Bag" json:"bag,omitempty"`
+			} `xml:"hierarchicalSubject" json:"hierarchicalsubject,omitempty"`
 			Rights struct {
 				Text string `xml:",chardata" json:"text,omitempty"`
 				Alt  struct {
@@ -184,14 +200,95 @@ type XmpDocument struct {
 			PersonInImage struct {
 				Text string `xml:",chardata" json:"text,omitempty"`
 				Bag  struct {
-					Text string `xml:",chardata" json:"text,omitempty"`
-					Li   string `xml:"li"` // Gopher
+					Text string   `xml:",chardata" json:"text,omitempty"`
+					Li   []string `xml:"li"` // Gopher
 				} `xml:"Bag" json:"bag,omitempty"`
 			} `xml:"PersonInImage" json:"personinimage,omitempty"`
+			Regions struct {
+				RegionList struct {
+					Bag struct {
+						Li []struct {
+							xmpMwgRegion
+							Description xmpMwgRegion `xml:"Description" json:"description,omitempty"`
+						} `xml:"li" json:"li,omitempty"`
+					} `xml:"Bag" json:"bag,omitempty"`
+				} `xml:"RegionList" json:"regionlist,omitempty"`
+			} `xml:"Regions" json:"regions,omitempty"`
+			RegionInfo struct {
+				Regions struct {
+					Bag struct {
+						Li []struct {
+							xmpMPRegion
+							Description xmpMPRegion `xml:"Description" json:"description,omitempty"`
+						} `xml:"li" json:"li,omitempty"`
+					} `xml:"Bag" json:"bag,omitempty"`
+				} `xml:"Regions" json:"regions,omitempty"`
+			} `xml:"RegionInfo" json:"regioninfo,omitempty"`
 		} `xml:"Description" json:"description,omitempty"`
 	} `xml:"RDF" json:"rdf,omitempty"`
 }
 
+// xmpMwgRegion represents a Metadata Working Group image region.
+type xmpMwgRegion struct {
+	NameAttr string `xml:"Name,attr" json:"nameattr,omitempty"`
+	TypeAttr string `xml:"Type,attr" json:"typeattr,omitempty"`
+	Name     string `xml:"Name" json:"name,omitempty"`
+	Type     string `xml:"Type" json:"type,omitempty"`
+	Area     struct {
+		X string `xml:"x,attr" json:"x,omitempty"`
+		Y string `xml:"y,attr" json:"y,omitempty"`
+		W string `xml:"w,attr" json:"w,omitempty"`
+		H string `xml:"h,attr" json:"h,omitempty"`
+	} `xml:"Area" json:"area,omitempty"`
+}
+
+// Region returns the region with coordinates starting at the top left corner.
+func (r xmpMwgRegion) Region() (Region, bool) {
+	name, regionType := r.Name, r.Type
+
+	if name == "" {
+		name = r.NameAttr
+	}
+
+	if regionType == "" {
+		regionType = r.TypeAttr
+	}
+
+	x, errX := strconv.ParseFloat(r.Area.X, 64)
+	y, errY := strconv.ParseFloat(r.Area.Y, 64)
+	w, errW := strconv.ParseFloat(r.Area.W, 64)
+	h, errH := strconv.ParseFloat(r.Area.H, 64)
+
+	if errX != nil || errY != nil || errW != nil || errH != nil {
+		return Region{}, false
+	}
+
+	return mwgRegion(strings.TrimSpace(name), strings.TrimSpace(regionType), x, y, w, h)
+}
+
+// xmpMPRegion represents a Microsoft Photo face region.
+type xmpMPRegion struct {
+	RectangleAttr         string `xml:"Rectangle,attr" json:"rectangleattr,omitempty"`
+	PersonDisplayNameAttr string `xml:"PersonDisplayName,attr" json:"persondisplaynameattr,omitempty"`
+	Rectangle             string `xml:"Rectangle" json:"rectangle,omitempty"`
+	PersonDisplayName     string `xml:"PersonDisplayName" json:"persondisplayname,omitempty"`
+}
+
+// Region returns the region with coordinates starting at the top left corner.
+func (r xmpMPRegion) Region() (Region, bool) {
+	name, rect := r.PersonDisplayName, r.Rectangle
+
+	if name == "" {
+		name = r.PersonDisplayNameAttr
+	}
+
+	if rect == "" {
+		rect = r.RectangleAttr
+	}
+
+	return mpRegion(strings.TrimSpace(name), rect)
+}
+
 func (doc *XmpDocument) Load(filename string) error {
 	data, err := ioutil.ReadFile(filename)
 
@@ -229,3 +326,99 @@ func (doc *XmpDocument) CameraModel() string {
 func (doc *XmpDocument) LensModel() string {
 	return doc.RDF.Description.LensModel
 }
+
+// Rating returns the star rating from -1 (rejected) to 5.
+func (doc *XmpDocument) Rating() int {
+	rating := doc.RDF.Description.Rating
+
+	if rating == "" {
+		rating = doc.RDF.Description.RatingAttr
+	}
+
+	if i, err := strconv.Atoi(strings.TrimSpace(rating)); err == nil {
+		return i
+	}
+
+	return 0
+}
+
+// ColorLabel returns the color label name, e.g. Red.
+func (doc *XmpDocument) ColorLabel() string {
+	if doc.RDF.Description.Label != "" {
+		return strings.TrimSpace(doc.RDF.Description.Label)
+	}
+
+	return strings.TrimSpace(doc.RDF.Description.LabelAttr)
+}
+
+// Pick returns 1 if the picture was picked, -1 if rejected, and 0 otherwise.
+func (doc *XmpDocument) Pick() int {
+	pick := doc.RDF.Description.Pick
+
+	if pick == "" {
+		pick = doc.RDF.Description.PickAttr
+	}
+
+	if i, err := strconv.Atoi(strings.TrimSpace(pick)); err == nil {
+		return i
+	}
+
+	return 0
+}
+
+// Keywords returns the dc:subject keywords.
+func (doc *XmpDocument) Keywords() (result []string) {
+	for _, w := range doc.RDF.Description.Subject.Bag.Li {
+		if w = strings.TrimSpace(w); w != "" {
+			result = append(result, w)
+		}
+	}
+
+	return result
+}
+
+// HierarchicalSubject returns the hierarchical keywords as used by Lightroom and darktable.
+func (doc *XmpDocument) HierarchicalSubject() (result []string) {
+	for _, w := range doc.RDF.Description.HierarchicalSubject.Bag.Li {
+		// Skip tags automatically added by darktable.
+		if strings.HasPrefix(w, "darktable|") {
+			continue
+		}
+
+		if w = strings.TrimSpace(w); w != "" {
+			result = append(result, w)
+		}
+	}
+
+	return result
+}
+
+// People returns the names of people shown as found in Iptc4xmpExt:PersonInImage.
+func (doc *XmpDocument) People() (result []string) {
+	for _, name := range doc.RDF.Description.PersonInImage.Bag.Li {
+		result = appendName(result, name)
+	}
+
+	return result
+}
+
+// Regions returns MWG and Microsoft Photo image regions.
+func (doc *XmpDocument) Regions() (result Regions) {
+	for _, li := range doc.RDF.Description.Regions.RegionList.Bag.Li {
+		if r, ok := li.Description.Region(); ok {
+			result = append(result, r)
+		} else if r, ok := li.xmpMwgRegion.Region(); ok {
+			result = append(result, r)
+		}
+	}
+
+	for _, li := range doc.RDF.Description.RegionInfo.Regions.Bag.Li {
+		if r, ok := li.Description.Region(); ok {
+			result = append(result, r)
+		} else if r, ok := li.xmpMPRegion.Region(); ok {
+			result = append(result, r)
+		}
+	}
+
+	return result
+}
@@ -21,6 +21,9 @@ func TestXMP(t *testing.T) {
 		assert.Equal(t, "HUAWEI", data.CameraMake)
 		assert.Equal(t, "ELE-L29", data.CameraModel)
 		assert.Equal(t, "HUAWEI P30 Rear Main Camera", data.LensModel)
+		assert.Equal(t, 4, data.Rating)
+		assert.Equal(t, "desk, coffee, computer", data.Keywords)
+		assert.Equal(t, []string{"Gopher"}, data.People)
 	})
 
 	t.Run("canon_eos_6d", func(t *testing.T) {
@@ -55,4 +58,36 @@ func TestXMP(t *testing.T) {
 		assert.Equal(t, "iPhone 7 back camera 3.99mm f/1.8", data.LensModel)
 	})
 
+	t.Run("lightroom", func(t *testing.T) {
+		data, err := XMP("testdata/lightroom.xmp")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "Owl at Night", data.Title)
+		assert.Equal(t, 5, data.Rating)
+		assert.Equal(t, "Red", data.ColorLabel)
+		assert.Equal(t, 1, data.Pick)
+		assert.Equal(t, "owl, night", data.Keywords)
+		assert.Equal(t, []string{"Animals|Birds|Owl", "Places|Germany|Berlin"}, data.Labels)
+		assert.True(t, data.Favorite())
+		assert.False(t, data.Rejected())
+	})
+
+	t.Run("darktable", func(t *testing.T) {
+		data, err := XMP("testdata/darktable.xmp")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, -1, data.Rating)
+		assert.Equal(t, "", data.ColorLabel)
+		assert.Equal(t, 0, data.Pick)
+		assert.Equal(t, "blurry", data.Keywords)
+		assert.Empty(t, data.Labels)
+		assert.False(t, data.Favorite())
+		assert.True(t, data.Rejected())
+	})
 }
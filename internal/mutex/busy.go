@@ -7,6 +7,8 @@ import (
 
 type Busy struct {
 	busy     bool
+	held     bool
+	depth    int
 	canceled bool
 	mutex    sync.Mutex
 }
@@ -18,7 +20,46 @@ func (b *Busy) Busy() bool {
 	return b.busy
 }
 
+// Hold acquires the lock on behalf of a runner, e.g. the job queue, so that workers it
+// starts can share it while everybody else has to wait until Release is called.
+func (b *Busy) Hold() error {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if b.busy {
+		return errors.New("already running")
+	}
+
+	b.busy = true
+	b.held = true
+	b.depth = 0
+	b.canceled = false
+
+	return nil
+}
+
+// Release gives up a lock acquired with Hold.
+func (b *Busy) Release() {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.busy = false
+	b.held = false
+	b.depth = 0
+	b.canceled = false
+}
+
+// Start acquires the lock, or shares it if it is held by a runner.
 func (b *Busy) Start() error {
+	return b.start(true)
+}
+
+// StartExclusive acquires the lock without sharing it, so it fails while a runner holds it.
+func (b *Busy) StartExclusive() error {
+	return b.start(false)
+}
+
+func (b *Busy) start(share bool) error {
 	b.mutex.Lock()
 	defer b.mutex.Unlock()
 
@@ -26,6 +67,11 @@ func (b *Busy) Start() error {
 		return errors.New("still running")
 	}
 
+	if b.held && share {
+		b.depth++
+		return nil
+	}
+
 	if b.busy {
 		return errors.New("already running")
 	}
@@ -40,6 +86,18 @@ func (b *Busy) Stop() {
 	b.mutex.Lock()
 	defer b.mutex.Unlock()
 
+	if b.held {
+		if b.depth > 0 {
+			b.depth--
+		}
+
+		if b.depth == 0 {
+			b.canceled = false
+		}
+
+		return
+	}
+
 	b.busy = false
 	b.canceled = false
 }
@@ -48,7 +106,8 @@ func (b *Busy) Cancel() {
 	b.mutex.Lock()
 	defer b.mutex.Unlock()
 
-	if b.busy {
+	// Nothing runs while a runner holds the lock between two workers.
+	if b.busy && (!b.held || b.depth > 0) {
 		b.canceled = true
 	}
 }
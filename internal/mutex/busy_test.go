@@ -21,3 +21,38 @@ func TestBusy_Busy(t *testing.T) {
 	assert.False(t, b.Canceled())
 	assert.False(t, b.Busy())
 }
+
+func TestBusy_Hold(t *testing.T) {
+	t.Run("shared", func(t *testing.T) {
+		b := Busy{}
+
+		assert.Nil(t, b.Hold())
+		assert.True(t, b.Busy())
+		assert.Error(t, b.Hold())
+		assert.Error(t, b.StartExclusive())
+		b.Cancel()
+		assert.False(t, b.Canceled())
+		assert.Nil(t, b.Start())
+		b.Cancel()
+		assert.True(t, b.Canceled())
+		assert.Error(t, b.Start())
+		b.Stop()
+		assert.False(t, b.Canceled())
+		assert.True(t, b.Busy())
+		assert.Nil(t, b.Start())
+		b.Stop()
+		b.Release()
+		assert.False(t, b.Busy())
+	})
+	t.Run("exclusive", func(t *testing.T) {
+		b := Busy{}
+
+		assert.Nil(t, b.StartExclusive())
+		assert.Error(t, b.Hold())
+		assert.Error(t, b.Start())
+		b.Stop()
+		assert.False(t, b.Busy())
+		assert.Nil(t, b.Hold())
+		b.Release()
+	})
+}
@@ -9,4 +9,7 @@ var (
 	Worker = Busy{}
 	Sync   = Busy{}
 	Share  = Busy{}
+	Watch  = Busy{}
+	Verify = Busy{}
+	Jobs   = Busy{}
 )
@@ -9,17 +9,20 @@ import (
 	"os/exec"
 	"path/filepath"
 	"runtime"
+	"strings"
 	"sync"
+	"time"
 
 	"github.com/karrick/godirwalk"
 	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/event"
 	"github.com/photoprism/photoprism/internal/mutex"
-	"github.com/photoprism/photoprism/internal/thumb"
 	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/txt"
 )
 
-// Convert represents a converter that can convert RAW/HEIF images to JPEG.
+// Convert represents a converter that can convert RAW, HEIF and other images as well as videos to JPEG.
 type Convert struct {
 	conf     *config.Config
 	cmdMutex sync.Mutex
@@ -58,6 +61,9 @@ func (c *Convert) Start(path string) error {
 		log.Infof("convert: %s", err)
 	}
 
+	progress := NewProgress("convert", 0)
+	progress.Count(path, IsConvertType, nil)
+
 	ignore.Log = func(fileName string) {
 		log.Infof(`convert: ignored "%s"`, fs.RelativeName(fileName, path))
 	}
@@ -90,8 +96,9 @@ func (c *Convert) Start(path string) error {
 			done[fileName] = true
 
 			jobs <- ConvertJob{
-				image:   mf,
-				convert: c,
+				image:    mf,
+				convert:  c,
+				progress: progress,
 			}
 
 			return nil
@@ -103,35 +110,9 @@ func (c *Convert) Start(path string) error {
 	close(jobs)
 	wg.Wait()
 
-	return err
-}
-
-// ConvertCommand returns the command for converting files to JPEG, depending on the format.
-func (c *Convert) ConvertCommand(mf *MediaFile, jpegName string, xmpName string) (result *exec.Cmd, useMutex bool, err error) {
-	if mf.IsRaw() {
-		if c.conf.SipsBin() != "" {
-			result = exec.Command(c.conf.SipsBin(), "-s", "format", "jpeg", "--out", jpegName, mf.FileName())
-		} else if c.conf.DarktableBin() != "" {
-			// Only one instance of darktable-cli allowed due to locking
-			useMutex = true
-
-			if xmpName != "" {
-				result = exec.Command(c.conf.DarktableBin(), mf.FileName(), xmpName, jpegName)
-			} else {
-				result = exec.Command(c.conf.DarktableBin(), mf.FileName(), jpegName)
-			}
-		} else {
-			return nil, useMutex, fmt.Errorf("convert: no raw to jpeg converter installed (%s)", mf.Base(c.conf.Settings().Index.Group))
-		}
-	} else if mf.IsVideo() {
-		result = exec.Command(c.conf.FFmpegBin(), "-i", mf.FileName(), "-ss", "00:00:00.001", "-vframes", "1", jpegName)
-	} else if mf.IsHEIF() {
-		result = exec.Command(c.conf.HeifConvertBin(), mf.FileName(), jpegName)
-	} else {
-		return nil, useMutex, fmt.Errorf("convert: file type not supported for conversion (%s)", mf.FileType())
-	}
+	progress.Finish()
 
-	return result, useMutex, nil
+	return err
 }
 
 // ToJson uses exiftool to export metadata to a json file.
@@ -233,29 +214,60 @@ func (c *Convert) ToJpeg(image *MediaFile, hidden bool) (*MediaFile, error) {
 		"xmpName":  filepath.Base(xmpName),
 	})
 
-	if image.IsImageOther() {
-		_, err = thumb.Jpeg(image.FileName(), jpegName)
+	converters := c.Converters(image)
 
-		if err != nil {
-			return nil, err
+	if len(converters) == 0 {
+		return nil, fmt.Errorf("convert: no %s to jpeg converter installed (%s)", image.FileType(), image.Base(c.conf.Settings().Index.Group))
+	}
+
+	var errs []string
+
+	// Try converters one after another until a JPEG was created.
+	for _, conv := range converters {
+		start := time.Now()
+		output, err := c.run(conv, image, jpegName, xmpName)
+
+		if err == nil && !fs.FileExists(jpegName) {
+			err = fmt.Errorf("%s did not create a jpeg", conv.Name)
+		}
+
+		conversion := entity.NewFileConversion(fileName, string(image.FileType()), conv.Name, time.Since(start), output, err)
+
+		if err := conversion.Create(); err != nil {
+			log.Errorf("convert: %s", err)
+		}
+
+		if err == nil {
+			return NewMediaFile(jpegName)
+		}
+
+		// Remove incomplete output before trying the next converter.
+		if fs.FileExists(jpegName) {
+			_ = os.Remove(jpegName)
 		}
 
-		return NewMediaFile(jpegName)
+		log.Warnf("convert: %s failed for %s (%s)", conv.Name, txt.Quote(fileName), strings.TrimSpace(err.Error()))
+
+		errs = append(errs, fmt.Sprintf("%s: %s", conv.Name, strings.TrimSpace(err.Error())))
 	}
 
-	cmd, useMutex, err := c.ConvertCommand(image, jpegName, xmpName)
+	return nil, fmt.Errorf("convert: %s", strings.Join(errs, ", "))
+}
 
-	if err != nil {
-		return nil, err
+// run converts a media file with the given converter and returns its output.
+func (c *Convert) run(conv Converter, mf *MediaFile, jpegName, xmpName string) (string, error) {
+	if conv.Func != nil {
+		return "", conv.Func(c, mf, jpegName)
 	}
 
+	cmd := exec.Command(conv.Bin(c.conf), conv.Args(c, mf, jpegName, xmpName)...)
+
 	// Unclear if this is really necessary here, but safe is safe.
 	runtime.LockOSThread()
 	defer runtime.UnlockOSThread()
 
-	if useMutex {
+	if conv.Mutex {
 		// Make sure only one command is executed at a time.
-		// See https://photo.stackexchange.com/questions/105969/darktable-cli-fails-because-of-locked-database-file
 		c.cmdMutex.Lock()
 		defer c.cmdMutex.Unlock()
 	}
@@ -269,11 +281,11 @@ func (c *Convert) ToJpeg(image *MediaFile, hidden bool) (*MediaFile, error) {
 	// Run convert command.
 	if err := cmd.Run(); err != nil {
 		if stderr.String() != "" {
-			return nil, errors.New(stderr.String())
+			return out.String() + stderr.String(), errors.New(stderr.String())
 		} else {
-			return nil, err
+			return out.String(), err
 		}
 	}
 
-	return NewMediaFile(jpegName)
+	return out.String() + stderr.String(), nil
 }
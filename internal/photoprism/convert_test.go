@@ -1,7 +1,9 @@
 package photoprism
 
 import (
+	"io/ioutil"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/photoprism/photoprism/internal/config"
@@ -117,6 +119,42 @@ func TestConvert_ToJpeg(t *testing.T) {
 
 		assert.Equal(t, "Canon EOS 6D", infoRaw.CameraModel)
 	})
+
+	t.Run("convert_test.webp", func(t *testing.T) {
+		fileName := filepath.Join(conf.ImportPath(), "convert_test.webp")
+		outputName := filepath.Join(conf.ImportPath(), "convert_test.jpg")
+
+		data, err := ioutil.ReadFile("../thumb/testdata/example.webp")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if err := ioutil.WriteFile(fileName, data, 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		defer os.Remove(fileName)
+		defer os.Remove(outputName)
+
+		mf, err := NewMediaFile(fileName)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.True(t, mf.IsWebP())
+		assert.True(t, mf.IsImageOther())
+
+		jpegFile, err := convert.ToJpeg(mf, false)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, outputName, jpegFile.FileName())
+		assert.True(t, jpegFile.IsJpeg())
+	})
 }
 
 func TestConvert_ToJson(t *testing.T) {
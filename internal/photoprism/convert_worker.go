@@ -1,17 +1,26 @@
 package photoprism
 
-import "strings"
+import (
+	"path/filepath"
+	"strings"
+)
 
 type ConvertJob struct {
-	image   *MediaFile
-	convert *Convert
+	image    *MediaFile
+	convert  *Convert
+	progress *Progress
 }
 
 func ConvertWorker(jobs <-chan ConvertJob) {
 	for job := range jobs {
+		fileName := job.image.RelativeName(job.convert.conf.OriginalsPath())
+		size := job.image.FileSize()
+
 		if _, err := job.convert.ToJpeg(job.image, job.convert.conf.JpegHidden()); err != nil {
-			fileName := job.image.RelativeName(job.convert.conf.OriginalsPath())
 			log.Errorf("convert: could not create jpeg for %s (%s)", fileName, strings.TrimSpace(err.Error()))
+			job.progress.Error()
 		}
+
+		job.progress.Processed(1, size, filepath.Dir(fileName))
 	}
 }
@@ -0,0 +1,168 @@
+package photoprism
+
+import (
+	"sort"
+	"strconv"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/thumb"
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+// ConvertFunc converts a media file to JPEG in-process.
+type ConvertFunc func(c *Convert, mf *MediaFile, jpegName string) error
+
+// ConvertArgs returns the command arguments to convert a media file to JPEG, xmpName may be empty.
+type ConvertArgs func(c *Convert, mf *MediaFile, jpegName, xmpName string) []string
+
+// Converter represents a tool that creates JPEGs from the supported file types.
+// Converters with a higher priority are tried first, until one succeeds.
+type Converter struct {
+	Name     string
+	Types    []fs.FileType
+	Priority int
+	Mutex    bool
+	Bin      func(conf *config.Config) string
+	Args     ConvertArgs
+	Func     ConvertFunc
+}
+
+// imageOtherTypes contains the file types of images that can be decoded in-process.
+var imageOtherTypes = []fs.FileType{fs.TypePng, fs.TypeGif, fs.TypeTiff, fs.TypeBitmap, fs.TypeWebP}
+
+// Converters contains the known converters by name, use the convert settings to disable them or change their order.
+var Converters = map[string]Converter{
+	"go": {
+		Name:     "go",
+		Types:    imageOtherTypes,
+		Priority: 100,
+		Func: func(c *Convert, mf *MediaFile, jpegName string) error {
+			_, err := thumb.Jpeg(mf.FileName(), jpegName, mf.Orientation())
+			return err
+		},
+	},
+	"sips": {
+		Name:     "sips",
+		Types:    []fs.FileType{fs.TypeRaw, fs.TypeHEIF},
+		Priority: 90,
+		Bin:      (*config.Config).SipsBin,
+		Args: func(c *Convert, mf *MediaFile, jpegName, xmpName string) []string {
+			return []string{"-s", "format", "jpeg", "--out", jpegName, mf.FileName()}
+		},
+	},
+	"darktable": {
+		Name:     "darktable",
+		Types:    []fs.FileType{fs.TypeRaw},
+		Priority: 80,
+		// Only one instance of darktable-cli allowed due to locking, see
+		// https://photo.stackexchange.com/questions/105969/darktable-cli-fails-because-of-locked-database-file
+		Mutex: true,
+		Bin:   (*config.Config).DarktableBin,
+		Args: func(c *Convert, mf *MediaFile, jpegName, xmpName string) []string {
+			if xmpName != "" {
+				return []string{mf.FileName(), xmpName, jpegName}
+			}
+
+			return []string{mf.FileName(), jpegName}
+		},
+	},
+	"rawtherapee": {
+		Name:     "rawtherapee",
+		Types:    []fs.FileType{fs.TypeRaw},
+		Priority: 70,
+		Bin:      (*config.Config).RawTherapeeBin,
+		Args: func(c *Convert, mf *MediaFile, jpegName, xmpName string) []string {
+			return []string{"-o", jpegName, "-j" + strconv.Itoa(c.conf.JpegQuality()), "-Y", "-c", mf.FileName()}
+		},
+	},
+	"heif-convert": {
+		Name:     "heif-convert",
+		Types:    []fs.FileType{fs.TypeHEIF},
+		Priority: 95,
+		Bin:      (*config.Config).HeifConvertBin,
+		Args: func(c *Convert, mf *MediaFile, jpegName, xmpName string) []string {
+			return []string{mf.FileName(), jpegName}
+		},
+	},
+	"ffmpeg": {
+		Name:     "ffmpeg",
+		Types:    []fs.FileType{fs.TypeMov, fs.TypeMP4, fs.TypeAvi, fs.TypeMkv},
+		Priority: 90,
+		Bin:      (*config.Config).FFmpegBin,
+		Args: func(c *Convert, mf *MediaFile, jpegName, xmpName string) []string {
+			return []string{"-i", mf.FileName(), "-ss", "00:00:00.001", "-vframes", "1", jpegName}
+		},
+	},
+	"preview": {
+		Name:  "preview",
+		Types: []fs.FileType{fs.TypeRaw},
+		// Fallback if no RAW converter is installed, add it to the convert order settings to use it first.
+		Priority: 1,
+		Func:     (*Convert).RawPreview,
+	},
+	"imagemagick": {
+		Name:     "imagemagick",
+		Types:    append([]fs.FileType{fs.TypeRaw, fs.TypeHEIF}, imageOtherTypes...),
+		Priority: 10,
+		Bin:      (*config.Config).ImageMagickBin,
+		Args: func(c *Convert, mf *MediaFile, jpegName, xmpName string) []string {
+			// Only the first frame or page is converted, e.g. of animated GIFs.
+			return []string{mf.FileName() + "[0]", "-auto-orient", "-quality", strconv.Itoa(c.conf.JpegQuality()), jpegName}
+		},
+	},
+}
+
+// Supports tests if the converter supports the file type.
+func (conv Converter) Supports(fileType fs.FileType) bool {
+	for _, t := range conv.Types {
+		if t == fileType {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Available tests if the converter runs in-process, or if its executable was found.
+func (conv Converter) Available(conf *config.Config) bool {
+	if conv.Func != nil {
+		return true
+	}
+
+	return conv.Bin != nil && conv.Args != nil && conv.Bin(conf) != ""
+}
+
+// Converters returns the enabled and available converters for a media file in the order they should be tried:
+// converters listed in the settings for the file type first, then all others by priority.
+func (c *Convert) Converters(mf *MediaFile) (result []Converter) {
+	fileType := mf.FileType()
+	settings := c.conf.Settings().Convert
+	order := make(map[string]int)
+
+	for i, name := range settings.Order[string(fileType)] {
+		order[name] = i + 1
+	}
+
+	for _, conv := range Converters {
+		if conv.Supports(fileType) && settings.Enabled(conv.Name) && conv.Available(c.conf) {
+			result = append(result, conv)
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		a, b := order[result[i].Name], order[result[j].Name]
+
+		switch {
+		case a > 0 && b > 0:
+			return a < b
+		case a > 0 || b > 0:
+			return a > 0
+		case result[i].Priority != result[j].Priority:
+			return result[i].Priority > result[j].Priority
+		default:
+			return result[i].Name < result[j].Name
+		}
+	})
+
+	return result
+}
@@ -0,0 +1,133 @@
+package photoprism
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConverter_Supports(t *testing.T) {
+	assert.True(t, Converters["darktable"].Supports(fs.TypeRaw))
+	assert.False(t, Converters["darktable"].Supports(fs.TypeHEIF))
+	assert.True(t, Converters["go"].Supports(fs.TypeWebP))
+	assert.True(t, Converters["imagemagick"].Supports(fs.TypeHEIF))
+}
+
+func TestConverter_Available(t *testing.T) {
+	conf := config.TestConfig()
+
+	assert.True(t, Converters["go"].Available(conf))
+	assert.False(t, Converter{Name: "missing"}.Available(conf))
+	assert.False(t, Converter{Name: "missing", Bin: func(conf *config.Config) string { return "" }, Args: Converters["ffmpeg"].Args}.Available(conf))
+}
+
+func TestConvert_Converters(t *testing.T) {
+	conf := config.TestConfig()
+	convert := NewConvert(conf)
+	settings := conf.Settings()
+
+	defer func() {
+		settings.Convert = config.ConvertSettings{}
+	}()
+
+	t.Run("png", func(t *testing.T) {
+		mf, err := NewMediaFile("../thumb/testdata/example.png")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		result := convert.Converters(mf)
+
+		if len(result) == 0 {
+			t.Fatal("result should not be empty")
+		}
+
+		assert.Equal(t, "go", result[0].Name)
+
+		settings.Convert = config.ConvertSettings{Disabled: []string{"go"}}
+
+		for _, conv := range convert.Converters(mf) {
+			assert.NotEqual(t, "go", conv.Name)
+		}
+
+		settings.Convert = config.ConvertSettings{}
+	})
+	t.Run("raw", func(t *testing.T) {
+		mf, err := NewMediaFile(conf.ExamplesPath() + "/canon_eos_6d.dng")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		result := convert.Converters(mf)
+
+		for i := 1; i < len(result); i++ {
+			assert.GreaterOrEqual(t, result[i-1].Priority, result[i].Priority)
+		}
+
+		if len(result) < 2 {
+			t.Skip("at least two raw converters required")
+		}
+
+		last := result[len(result)-1].Name
+
+		settings.Convert = config.ConvertSettings{Order: map[string][]string{"raw": {last}}}
+
+		assert.Equal(t, last, convert.Converters(mf)[0].Name)
+	})
+}
+
+func TestConvert_ToJpeg_Fallback(t *testing.T) {
+	conf := config.TestConfig()
+	convert := NewConvert(conf)
+
+	Converters["broken"] = Converter{
+		Name:     "broken",
+		Types:    []fs.FileType{fs.TypePng},
+		Priority: 1000,
+		Func: func(c *Convert, mf *MediaFile, jpegName string) error {
+			return errors.New("unsupported format")
+		},
+	}
+
+	defer delete(Converters, "broken")
+
+	fileName := filepath.Join(conf.ImportPath(), "converter_test.png")
+	outputName := filepath.Join(conf.ImportPath(), "converter_test.jpg")
+
+	data, err := ioutil.ReadFile("../thumb/testdata/example.png")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := ioutil.WriteFile(fileName, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.Remove(fileName)
+	defer os.Remove(outputName)
+
+	mf, err := NewMediaFile(fileName)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, "broken", convert.Converters(mf)[0].Name)
+
+	jpegFile, err := convert.ToJpeg(mf, false)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, outputName, jpegFile.FileName())
+}
@@ -0,0 +1,137 @@
+package photoprism
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/meta"
+	"github.com/photoprism/photoprism/internal/mutex"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// Geotag represents a worker that sets the location of photos based on GPS tracks.
+type Geotag struct {
+	conf *config.Config
+}
+
+// NewGeotag returns a new geotag worker.
+func NewGeotag(conf *config.Config) *Geotag {
+	instance := &Geotag{
+		conf: conf,
+	}
+
+	return instance
+}
+
+// Start sets the coordinates of photos without location taken while the tracks were recorded
+// and returns the UIDs of matched photos.
+func (g *Geotag) Start(opt GeotagOptions) (tagged []string, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("geotag: %s [panic]", r)
+			log.Error(err.Error())
+		}
+	}()
+
+	if len(opt.Tracks) == 0 {
+		return tagged, errors.New("geotag: no track files")
+	}
+
+	if opt.MaxGap <= 0 {
+		opt.MaxGap = GeotagDefaultMaxGap
+	}
+
+	var track meta.Track
+
+	for _, fileName := range opt.Tracks {
+		points, err := meta.ReadTrack(fileName)
+
+		if err != nil {
+			return tagged, fmt.Errorf("geotag: %s", err.Error())
+		}
+
+		log.Infof("geotag: found %d track points in %s", len(points), txt.Quote(filepath.Base(fileName)))
+
+		track = append(track, points...)
+	}
+
+	sort.Stable(track)
+
+	// Dry runs don't change anything, so they don't have to wait for other workers.
+	if !opt.Dry {
+		if err := mutex.Worker.Start(); err != nil {
+			err = fmt.Errorf("geotag: %s", err.Error())
+			event.Error(err.Error())
+			return tagged, err
+		}
+
+		defer mutex.Worker.Stop()
+	}
+
+	// Photos are matched in track time, so the offset is subtracted from the time range.
+	from := track.Start().Add(-opt.MaxGap - opt.Offset)
+	to := track.End().Add(opt.MaxGap - opt.Offset)
+
+	photos, err := query.PhotosWithoutLocation(from, to)
+
+	if err != nil {
+		return tagged, err
+	}
+
+	for _, photo := range photos {
+		if !opt.Dry && mutex.Worker.Canceled() {
+			return tagged, errors.New("geotag canceled")
+		}
+
+		p, ok := track.Position(photo.TakenAt.Add(opt.Offset), opt.MaxGap)
+
+		if !ok {
+			continue
+		}
+
+		if opt.Dry {
+			log.Infof("geotag: photo %s would be tagged with %f, %f", photo.PhotoUID, p.Lat, p.Lng)
+			tagged = append(tagged, photo.PhotoUID)
+			continue
+		}
+
+		if err := g.tag(photo, p); err != nil {
+			log.Errorf("geotag: %s (%s)", err, photo.PhotoUID)
+			continue
+		}
+
+		log.Infof("geotag: tagged photo %s with %f, %f", photo.PhotoUID, p.Lat, p.Lng)
+
+		tagged = append(tagged, photo.PhotoUID)
+	}
+
+	return tagged, nil
+}
+
+// tag sets the coordinates of a photo and updates its location.
+func (g *Geotag) tag(photo entity.Photo, p meta.TrackPoint) error {
+	photo.SetCoordinates(float32(p.Lat), float32(p.Lng), int(math.Round(p.Altitude)), entity.SrcTrack)
+
+	if photo.LocSrc != entity.SrcTrack {
+		return fmt.Errorf("location source is %s", txt.Quote(photo.LocSrc))
+	}
+
+	keywords, labels := photo.UpdateLocation(g.conf.GeoCodingApi())
+
+	photo.AddLabels(labels)
+
+	w := txt.Keywords(photo.Details.Keywords)
+	w = append(w, keywords...)
+
+	photo.Details.Keywords = strings.Join(txt.UniqueWords(w), ", ")
+
+	return photo.Save()
+}
@@ -0,0 +1,13 @@
+package photoprism
+
+import "time"
+
+// GeotagDefaultMaxGap is the default maximum time between a photo and the nearest track point.
+const GeotagDefaultMaxGap = 5 * time.Minute
+
+type GeotagOptions struct {
+	Tracks []string      // GPX or KML file names
+	Offset time.Duration // Added to the time a photo was taken, e.g. to correct the camera clock
+	MaxGap time.Duration // Maximum time between a photo and the nearest track point
+	Dry    bool
+}
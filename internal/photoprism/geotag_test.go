@@ -0,0 +1,115 @@
+package photoprism
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGeotag_Start(t *testing.T) {
+	conf := config.TestConfig()
+
+	t.Run("no tracks", func(t *testing.T) {
+		g := NewGeotag(conf)
+
+		_, err := g.Start(GeotagOptions{})
+
+		assert.Error(t, err)
+	})
+	t.Run("missing track", func(t *testing.T) {
+		g := NewGeotag(conf)
+
+		_, err := g.Start(GeotagOptions{Tracks: []string{"testdata/no-such-track.gpx"}})
+
+		assert.Error(t, err)
+	})
+	t.Run("no photos", func(t *testing.T) {
+		g := NewGeotag(conf)
+
+		tagged, err := g.Start(GeotagOptions{Tracks: []string{"../meta/testdata/track.gpx"}, Dry: true})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Empty(t, tagged)
+	})
+	t.Run("dry", func(t *testing.T) {
+		dir, err := ioutil.TempDir("", "geotag")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		defer os.RemoveAll(dir)
+
+		// The fixtures contain photos without location taken at 2016-11-11 09:07:18 UTC.
+		track := filepath.Join(dir, "track.gpx")
+		gpx := `<?xml version="1.0" encoding="UTF-8"?>
+<gpx version="1.1" creator="PhotoPrism" xmlns="http://www.topografix.com/GPX/1/1">
+  <trk><trkseg>
+    <trkpt lat="52.5200" lon="13.4000"><time>2016-11-11T09:00:00Z</time></trkpt>
+    <trkpt lat="52.5300" lon="13.4100"><time>2016-11-11T09:10:00Z</time></trkpt>
+  </trkseg></trk>
+</gpx>`
+
+		if err := ioutil.WriteFile(track, []byte(gpx), os.ModePerm); err != nil {
+			t.Fatal(err)
+		}
+
+		expected := []string{"pt9jtdre2lvl0y15", "pt9jtdre2lvl0y16", "pt9jtdre2lvl0y17", "pt9jtdre2lvl0y18", "pt9jtdre2lvl0y19", "pt9jtdre2lvl0y20", "pt9jtdre2lvl0y21"}
+		before := geotagFileTimes(t, conf.OriginalsPath())
+
+		g := NewGeotag(conf)
+
+		tagged, err := g.Start(GeotagOptions{Tracks: []string{track}, Dry: true})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.ElementsMatch(t, expected, tagged)
+
+		for _, uid := range expected {
+			photo, err := query.PhotoByUID(uid)
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Equal(t, float32(0), photo.PhotoLat)
+			assert.Equal(t, float32(0), photo.PhotoLng)
+			assert.NotEqual(t, entity.SrcTrack, photo.LocSrc)
+		}
+
+		assert.Equal(t, before, geotagFileTimes(t, conf.OriginalsPath()))
+	})
+}
+
+// geotagFileTimes returns the modification times of all files in a path.
+func geotagFileTimes(t *testing.T, path string) map[string]time.Time {
+	result := make(map[string]time.Time)
+
+	if err := filepath.Walk(path, func(fileName string, info os.FileInfo, err error) error {
+		if err != nil {
+			return nil
+		}
+
+		if !info.IsDir() {
+			result[fileName] = info.ModTime()
+		}
+
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	return result
+}
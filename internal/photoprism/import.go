@@ -3,11 +3,14 @@ package photoprism
 import (
 	"errors"
 	"fmt"
+	"io/ioutil"
 	"os"
 	"path/filepath"
 	"runtime"
 	"sort"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/karrick/godirwalk"
 	"github.com/photoprism/photoprism/internal/config"
@@ -47,27 +50,57 @@ func (imp *Import) thumbPath() string {
 }
 
 // Start imports media files from a directory and converts/indexes them as needed.
-func (imp *Import) Start(opt ImportOptions) map[string]bool {
+func (imp *Import) Start(opt ImportOptions) (map[string]bool, error) {
+	if opt.Dry {
+		return imp.start(opt, NewImportPlan())
+	}
+
+	return imp.start(opt, nil)
+}
+
+// Plan returns what an import would do without changing any files, like a dry run.
+func (imp *Import) Plan(opt ImportOptions) (*ImportPlan, error) {
+	plan := NewImportPlan()
+
+	opt.Dry = true
+
+	if _, err := imp.start(opt, plan); err != nil {
+		return nil, err
+	}
+
+	return plan, nil
+}
+
+// start walks the import path and sends import jobs to the workers, which only add
+// files to the plan in dry runs.
+func (imp *Import) start(opt ImportOptions, plan *ImportPlan) (map[string]bool, error) {
+	start := time.Now()
 	var directories []string
+	var archives []string
+	var tempDirs []string
 	done := make(map[string]bool)
 	ind := imp.index
 	importPath := opt.Path
 
 	if !fs.PathExists(importPath) {
-		event.Error(fmt.Sprintf("import: %s does not exist", importPath))
-		return done
+		return done, fmt.Errorf("import: %s does not exist", importPath)
 	}
 
-	if err := mutex.Worker.Start(); err != nil {
-		event.Error(fmt.Sprintf("import: %s", err.Error()))
-		return done
+	lock := mutex.Worker.Start
+
+	// Dry runs are requested directly, so they must not share the lock with queued jobs.
+	if opt.Dry {
+		lock = mutex.Worker.StartExclusive
+	}
+
+	if err := lock(); err != nil {
+		return done, fmt.Errorf("import: %s", err.Error())
 	}
 
 	defer mutex.Worker.Stop()
 
 	if err := ind.tensorFlow.Init(); err != nil {
-		log.Errorf("import: %s", err.Error())
-		return done
+		return done, fmt.Errorf("import: %s", err.Error())
 	}
 
 	jobs := make(chan ImportJob)
@@ -85,6 +118,15 @@ func (imp *Import) Start(opt ImportOptions) map[string]bool {
 
 	indexOpt := IndexOptionsAll()
 	ignore := fs.NewIgnoreList(fs.IgnoreFile, true, false)
+	takeoutAlbums := make(map[string]string)
+
+	// Dry runs don't report progress, the total grows when files in archives are processed.
+	var progress *Progress
+
+	if !opt.Dry {
+		progress = NewProgress("import", 0)
+		progress.Count(importPath, IsMediaType, nil)
+	}
 
 	if err := ignore.Dir(importPath); err != nil {
 		log.Infof("import: %s", err)
@@ -94,85 +136,204 @@ func (imp *Import) Start(opt ImportOptions) map[string]bool {
 		log.Infof(`import: ignored "%s"`, fs.RelativeName(fileName, importPath))
 	}
 
-	err := godirwalk.Walk(importPath, &godirwalk.Options{
-		Callback: func(fileName string, info *godirwalk.Dirent) error {
-			defer func() {
-				if err := recover(); err != nil {
-					log.Errorf("import: %s [panic]", err)
+	// Counts files in an archive that could not be imported, nil when walking the import path.
+	walk := func(walkPath string, failed *int32) error {
+		walkOpt := opt
+		walkOpt.Path = walkPath
+
+		return godirwalk.Walk(walkPath, &godirwalk.Options{
+			Callback: func(fileName string, info *godirwalk.Dirent) error {
+				defer func() {
+					if err := recover(); err != nil {
+						log.Errorf("import: %s [panic]", err)
+					}
+				}()
+
+				if mutex.Worker.Canceled() {
+					return errors.New("import canceled")
 				}
-			}()
 
-			if mutex.Worker.Canceled() {
-				return errors.New("import canceled")
-			}
+				isDir := info.IsDir()
+				isSymlink := info.IsSymlink()
+
+				if isDir && walkPath == importPath {
+					if fileName != importPath {
+						directories = append(directories, fileName)
+					}
+				}
+
+				if skip, result := fs.SkipWalk(fileName, isDir, isSymlink, done, ignore); skip {
+					if isDir && result != filepath.SkipDir && walkPath == importPath && !opt.Dry {
+						folder := entity.NewFolder(entity.RootImport, fs.RelativeName(fileName, imp.conf.ImportPath()), nil)
 
-			isDir := info.IsDir()
-			isSymlink := info.IsSymlink()
+						if err := folder.Create(); err == nil {
+							log.Infof("import: added folder /%s", folder.Path)
+						}
+					}
 
-			if isDir {
-				if fileName != importPath {
-					directories = append(directories, fileName)
+					return result
 				}
-			}
 
-			if skip, result := fs.SkipWalk(fileName, isDir, isSymlink, done, ignore); skip {
-				if isDir && result != filepath.SkipDir {
-					folder := entity.NewFolder(entity.RootImport, fs.RelativeName(fileName, imp.conf.ImportPath()), nil)
+				// Archives are extracted after the import path has been walked, nested archives are skipped.
+				if fs.IsArchive(fileName) {
+					if walkPath == importPath {
+						archives = append(archives, fileName)
+					}
+
+					done[fileName] = true
+
+					return nil
+				}
+
+				mf, err := NewMediaFile(fileName)
 
-					if err := folder.Create(); err == nil {
-						log.Infof("import: added folder /%s", folder.Path)
+				if err != nil {
+					if failed != nil {
+						atomic.AddInt32(failed, 1)
 					}
+
+					return nil
+				} else if !mf.IsMedia() {
+					return nil
 				}
 
-				return result
-			}
+				related, err := mf.RelatedFiles(imp.conf.Settings().Index.Group)
 
-			mf, err := NewMediaFile(fileName)
+				if err != nil {
+					event.Error(fmt.Sprintf("import: %s", err.Error()))
 
-			if err != nil || !mf.IsMedia() {
-				return nil
-			}
+					if failed != nil {
+						atomic.AddInt32(failed, 1)
+					}
 
-			related, err := mf.RelatedFiles(imp.conf.Settings().Index.Group)
+					return nil
+				}
 
-			if err != nil {
-				event.Error(fmt.Sprintf("import: %s", err.Error()))
+				var files MediaFiles
 
-				return nil
-			}
+				for _, f := range related.Files {
+					if done[f.FileName()] {
+						continue
+					}
+
+					files = append(files, f)
+					done[f.FileName()] = true
+				}
+
+				done[fileName] = true
+
+				related.Files = files
 
-			var files MediaFiles
+				// Google Takeout exports contain album folders.
+				var albumTitle string
 
-			for _, f := range related.Files {
-				if done[f.FileName()] {
-					continue
+				if TakeoutSidecar(fileName) != "" {
+					dir := filepath.Dir(fileName)
+
+					if title, ok := takeoutAlbums[dir]; ok {
+						albumTitle = title
+					} else {
+						albumTitle = TakeoutAlbumTitle(dir, walkPath)
+						takeoutAlbums[dir] = albumTitle
+					}
 				}
 
-				files = append(files, f)
-				done[f.FileName()] = true
-			}
+				jobs <- ImportJob{
+					FileName:  fileName,
+					Album:     albumTitle,
+					Related:   related,
+					IndexOpt:  indexOpt,
+					ImportOpt: walkOpt,
+					Imp:       imp,
+					Plan:      plan,
+					Progress:  progress,
+					Failed:    failed,
+				}
 
-			done[fileName] = true
+				return nil
+			},
+			Unsorted:            false,
+			FollowSymbolicLinks: true,
+		})
+	}
 
-			related.Files = files
+	err := walk(importPath, nil)
 
-			jobs <- ImportJob{
-				FileName:  fileName,
-				Related:   related,
-				IndexOpt:  indexOpt,
-				ImportOpt: opt,
-				Imp:       imp,
-			}
+	// Archives are only removed if all their files were imported.
+	extracted := make(map[string]*int32)
 
-			return nil
-		},
-		Unsorted:            false,
-		FollowSymbolicLinks: true,
-	})
+	for _, archive := range archives {
+		if err != nil {
+			break
+		}
+
+		// Dry runs don't extract archives, their files are imported when the import actually runs.
+		if opt.Dry {
+			plan.Add(ImportPlanFile{Name: fs.RelativeName(archive, importPath), Action: PlanArchive})
+			log.Infof("import: %s would be extracted and imported", txt.Quote(fs.RelativeName(archive, importPath)))
+			continue
+		}
+
+		tempDir, extractErr := imp.extractArchive(archive, importPath)
+
+		if tempDir != "" {
+			tempDirs = append(tempDirs, tempDir)
+		}
+
+		if extractErr != nil {
+			event.Error(fmt.Sprintf("import: %s", extractErr.Error()))
+			continue
+		}
+
+		failed := new(int32)
+		extracted[archive] = failed
+
+		err = walk(tempDir, failed)
+	}
 
 	close(jobs)
 	wg.Wait()
 
+	progress.Finish()
+
+	for _, tempDir := range tempDirs {
+		if err := os.RemoveAll(tempDir); err != nil {
+			log.Errorf("import: could not remove temporary folder %s (%s)", txt.Quote(tempDir), err)
+		}
+	}
+
+	// Canceled or failed imports keep archives and don't update counts, so that jobs are retried or canceled.
+	if err != nil {
+		return done, err
+	}
+
+	if opt.Dry {
+		for _, fileName := range append(ignore.Ignored(), ignore.Hidden()...) {
+			if fs.FileExists(fileName) {
+				plan.Add(ImportPlanFile{Name: fs.RelativeName(fileName, importPath), Action: PlanIgnored})
+			}
+		}
+
+		plan.Sort()
+
+		log.Infof("import: dry run found %d new, %d duplicate, %d existing and %d ignored files, %d archives",
+			plan.Count(PlanNew), plan.Count(PlanDuplicate), plan.Count(PlanExists), plan.Count(PlanIgnored), plan.Count(PlanArchive))
+
+		return done, nil
+	}
+
+	if opt.RemoveArchives {
+		for archive, failed := range extracted {
+			if n := atomic.LoadInt32(failed); n > 0 {
+				log.Warnf("import: kept archive %s, %d files could not be imported", txt.Quote(fs.RelativeName(archive, importPath)), n)
+			} else if err := os.Remove(archive); err != nil {
+				log.Errorf("import: could not delete archive %s (%s)", txt.Quote(fs.RelativeName(archive, importPath)), err)
+			} else {
+				log.Infof("import: deleted archive %s", txt.Quote(fs.RelativeName(archive, importPath)))
+			}
+		}
+	}
+
 	sort.Slice(directories, func(i, j int) bool {
 		return len(directories[i]) > len(directories[j])
 	})
@@ -203,19 +364,46 @@ func (imp *Import) Start(opt ImportOptions) map[string]bool {
 		}
 	}
 
-	if err != nil {
-		log.Error(err.Error())
-	}
-
 	if len(done) > 0 {
 		if err := entity.UpdatePhotoCounts(); err != nil {
 			log.Errorf("import: %s", err)
 		}
+
+		if _, err := DetectStacks(start); err != nil {
+			log.Errorf("import: %s", err)
+		}
 	}
 
 	runtime.GC()
 
-	return done
+	return done, nil
+}
+
+// extractArchive extracts an archive to a new temporary folder and returns its name.
+func (imp *Import) extractArchive(fileName, importPath string) (string, error) {
+	tempPath := filepath.Join(imp.conf.TempPath(), "import")
+
+	if err := os.MkdirAll(tempPath, os.ModePerm); err != nil {
+		return "", err
+	}
+
+	tempDir, err := ioutil.TempDir(tempPath, "archive")
+
+	if err != nil {
+		return "", err
+	}
+
+	relName := fs.RelativeName(fileName, importPath)
+
+	fileNames, err := fs.Extract(fileName, tempDir)
+
+	if err != nil {
+		return tempDir, fmt.Errorf("%s in %s", err, txt.Quote(relName))
+	}
+
+	log.Infof("import: extracted %d files from %s", len(fileNames), txt.Quote(relName))
+
+	return tempDir, nil
 }
 
 // Cancel stops the current import operation.
@@ -223,11 +411,38 @@ func (imp *Import) Cancel() {
 	mutex.Worker.Cancel()
 }
 
+// pathTemplate returns the configured destination path template, or the default template if invalid.
+func (imp *Import) pathTemplate() PathTemplate {
+	tpl, err := NewPathTemplate(imp.conf.Settings().Import.Template)
+
+	if err != nil {
+		log.Warnf("import: %s, using default template %s", err, txt.Quote(DefaultPathTemplate))
+	}
+
+	return tpl
+}
+
 // DestinationFilename returns the destination filename of a MediaFile to be imported.
 func (imp *Import) DestinationFilename(mainFile *MediaFile, mediaFile *MediaFile) (string, error) {
-	fileName := mainFile.CanonicalName()
+	return imp.destinationFilename(imp.destinationName(mainFile), mediaFile, fs.FileExists)
+}
+
+// destinationName renders the path template for a main file, so that related files can share the result.
+func (imp *Import) destinationName(mainFile *MediaFile) string {
+	tpl := imp.pathTemplate()
+	geoApi := ""
+
+	if tpl.UsesPlace() {
+		geoApi = imp.conf.GeoCodingApi()
+	}
+
+	return tpl.Render(mainFile.PathValues(geoApi))
+}
+
+// destinationFilename returns the destination filename of a MediaFile based on the rendered template name,
+// a sequence number is appended while exists reports that the file name is taken.
+func (imp *Import) destinationFilename(name string, mediaFile *MediaFile, exists func(fileName string) bool) (string, error) {
 	fileExtension := mediaFile.Extension()
-	dateCreated := mainFile.DateCreated()
 
 	if !mediaFile.IsSidecar() {
 		if f, err := entity.FirstFileByHash(mediaFile.Hash()); err == nil {
@@ -240,14 +455,14 @@ func (imp *Import) DestinationFilename(mainFile *MediaFile, mediaFile *MediaFile
 		}
 	}
 
-	//	Mon Jan 2 15:04:05 -0700 MST 2006
-	pathName := filepath.Join(imp.originalsPath(), dateCreated.Format("2006/01"))
+	fileName := filepath.Base(name)
+	pathName := filepath.Join(imp.originalsPath(), filepath.Dir(name))
 
 	iteration := 0
 
 	result := filepath.Join(pathName, fileName+fileExtension)
 
-	for fs.FileExists(result) {
+	for exists(result) {
 		if mediaFile.Hash() == fs.Hash(result) {
 			return result, fmt.Errorf("%s already exists", txt.Quote(fs.RelativeName(result, imp.originalsPath())))
 		}
@@ -6,6 +6,8 @@ type ImportOptions struct {
 	RemoveDotFiles         bool
 	RemoveExistingFiles    bool
 	RemoveEmptyDirectories bool
+	RemoveArchives         bool
+	Dry                    bool
 }
 
 // ImportOptionsCopy returns import options for copying files to originals (read-only).
@@ -16,6 +18,7 @@ func ImportOptionsCopy(path string) ImportOptions {
 		RemoveDotFiles:         false,
 		RemoveExistingFiles:    false,
 		RemoveEmptyDirectories: false,
+		RemoveArchives:         false,
 	}
 
 	return result
@@ -29,6 +32,7 @@ func ImportOptionsMove(path string) ImportOptions {
 		RemoveDotFiles:         true,
 		RemoveExistingFiles:    true,
 		RemoveEmptyDirectories: true,
+		RemoveArchives:         true,
 	}
 
 	return result
@@ -12,6 +12,7 @@ func TestImportOptionsCopy(t *testing.T) {
 	assert.Equal(t, false, result.RemoveDotFiles)
 	assert.Equal(t, false, result.RemoveExistingFiles)
 	assert.Equal(t, false, result.RemoveEmptyDirectories)
+	assert.Equal(t, false, result.RemoveArchives)
 }
 
 func TestImportOptionsMove(t *testing.T) {
@@ -21,4 +22,5 @@ func TestImportOptionsMove(t *testing.T) {
 	assert.Equal(t, true, result.RemoveDotFiles)
 	assert.Equal(t, true, result.RemoveExistingFiles)
 	assert.Equal(t, true, result.RemoveEmptyDirectories)
+	assert.Equal(t, true, result.RemoveArchives)
 }
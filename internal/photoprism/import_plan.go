@@ -0,0 +1,138 @@
+package photoprism
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+const (
+	PlanNew       = "new"
+	PlanDuplicate = "duplicate"
+	PlanExists    = "exists"
+	PlanIgnored   = "ignored"
+	PlanArchive   = "archive"
+)
+
+// ImportPlanFile describes what an import would do with a single file.
+type ImportPlanFile struct {
+	Name        string `json:"Name"`
+	Action      string `json:"Action"`
+	Destination string `json:"Destination,omitempty"`
+	Duplicate   string `json:"Duplicate,omitempty"`
+}
+
+// ImportPlan contains the result of an import dry run.
+type ImportPlan struct {
+	mutex        sync.Mutex
+	planned      map[string]string
+	destinations map[string]bool
+	Files        []ImportPlanFile `json:"Files"`
+}
+
+// NewImportPlan returns a new, empty import plan.
+func NewImportPlan() *ImportPlan {
+	return &ImportPlan{planned: make(map[string]string), destinations: make(map[string]bool), Files: []ImportPlanFile{}}
+}
+
+// Add adds a file to the plan.
+func (p *ImportPlan) Add(file ImportPlanFile) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	p.Files = append(p.Files, file)
+}
+
+// Count returns the number of files with the given action.
+func (p *ImportPlan) Count(action string) (count int) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	for _, f := range p.Files {
+		if f.Action == action {
+			count++
+		}
+	}
+
+	return count
+}
+
+// Sort sorts the planned files by name.
+func (p *ImportPlan) Sort() {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	sort.Slice(p.Files, func(i, j int) bool {
+		return p.Files[i].Name < p.Files[j].Name
+	})
+}
+
+// reserve returns the destination of an identical file planned before, if any. Otherwise, it finds
+// a destination for a new file that is neither taken by an existing file nor by other files in the plan.
+func (p *ImportPlan) reserve(hash string, find func(exists func(fileName string) bool) (string, error)) (destination string, duplicate bool, err error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if existing, ok := p.planned[hash]; ok {
+		return existing, true, nil
+	}
+
+	destination, err = find(func(fileName string) bool {
+		return p.destinations[fileName] || fs.FileExists(fileName)
+	})
+
+	if err != nil {
+		return destination, false, err
+	}
+
+	p.planned[hash] = destination
+	p.destinations[destination] = true
+
+	return destination, false, nil
+}
+
+// planFiles adds related files to the plan without copying, moving or indexing them.
+func (imp *Import) planFiles(plan *ImportPlan, related RelatedFiles, importPath string) {
+	destinationName := imp.destinationName(related.Main)
+
+	for _, f := range related.Files {
+		file := ImportPlanFile{Name: f.RelativeName(importPath)}
+
+		if !f.IsSidecar() {
+			if existing, err := entity.FirstFileByHash(f.Hash()); err == nil {
+				file.Action = PlanDuplicate
+				file.Duplicate = existing.FileName
+				plan.Add(file)
+				log.Infof("import: %s would be skipped, identical to %s", txt.Quote(file.Name), txt.Quote(file.Duplicate))
+				continue
+			}
+		}
+
+		destinationFilename, duplicate, err := plan.reserve(f.Hash(), func(exists func(fileName string) bool) (string, error) {
+			return imp.destinationFilename(destinationName, f, exists)
+		})
+
+		if err != nil {
+			file.Action = PlanExists
+			file.Duplicate = fs.RelativeName(destinationFilename, imp.originalsPath())
+			plan.Add(file)
+			log.Infof("import: %s would be skipped, %s", txt.Quote(file.Name), err)
+			continue
+		} else if duplicate {
+			file.Action = PlanDuplicate
+			file.Duplicate = fs.RelativeName(destinationFilename, imp.originalsPath())
+			plan.Add(file)
+			log.Infof("import: %s would be skipped, identical to %s", txt.Quote(file.Name), txt.Quote(file.Duplicate))
+			continue
+		}
+
+		file.Destination = fs.RelativeName(destinationFilename, imp.originalsPath())
+
+		file.Action = PlanNew
+		plan.Add(file)
+		log.Infof("import: %s would be imported as %s", txt.Quote(file.Name), txt.Quote(file.Destination))
+	}
+}
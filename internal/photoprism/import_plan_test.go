@@ -0,0 +1,78 @@
+package photoprism
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestImportPlan_Count(t *testing.T) {
+	plan := NewImportPlan()
+
+	plan.Add(ImportPlanFile{Name: "b.jpg", Action: PlanNew, Destination: "2020/01/b.jpg"})
+	plan.Add(ImportPlanFile{Name: "a.jpg", Action: PlanDuplicate, Duplicate: "2019/12/a.jpg"})
+	plan.Add(ImportPlanFile{Name: "c.jpg", Action: PlanNew, Destination: "2020/01/c.jpg"})
+
+	assert.Equal(t, 2, plan.Count(PlanNew))
+	assert.Equal(t, 1, plan.Count(PlanDuplicate))
+	assert.Equal(t, 0, plan.Count(PlanIgnored))
+}
+
+func TestImportPlan_Sort(t *testing.T) {
+	plan := NewImportPlan()
+
+	plan.Add(ImportPlanFile{Name: "b.jpg", Action: PlanNew})
+	plan.Add(ImportPlanFile{Name: "a.jpg", Action: PlanIgnored})
+	plan.Sort()
+
+	assert.Equal(t, "a.jpg", plan.Files[0].Name)
+	assert.Equal(t, "b.jpg", plan.Files[1].Name)
+}
+
+func TestImportPlan_reserve(t *testing.T) {
+	plan := NewImportPlan()
+
+	// Finds the first destination that isn't taken, like Import.destinationFilename.
+	find := func(name string) func(exists func(fileName string) bool) (string, error) {
+		return func(exists func(fileName string) bool) (string, error) {
+			result := name + ".jpg"
+
+			for i := 1; exists(result); i++ {
+				result = fmt.Sprintf("%s.%05d.jpg", name, i)
+			}
+
+			return result, nil
+		}
+	}
+
+	t.Run("new", func(t *testing.T) {
+		destination, duplicate, err := plan.reserve("abc", find("/originals/2020/01/a"))
+
+		assert.NoError(t, err)
+		assert.False(t, duplicate)
+		assert.Equal(t, "/originals/2020/01/a.jpg", destination)
+	})
+	t.Run("duplicate", func(t *testing.T) {
+		destination, duplicate, err := plan.reserve("abc", find("/originals/2020/01/b"))
+
+		assert.NoError(t, err)
+		assert.True(t, duplicate)
+		assert.Equal(t, "/originals/2020/01/a.jpg", destination)
+	})
+	t.Run("collision", func(t *testing.T) {
+		destination, duplicate, err := plan.reserve("def", find("/originals/2020/01/a"))
+
+		assert.NoError(t, err)
+		assert.False(t, duplicate)
+		assert.Equal(t, "/originals/2020/01/a.00001.jpg", destination)
+	})
+	t.Run("error", func(t *testing.T) {
+		_, _, err := plan.reserve("ghi", func(exists func(fileName string) bool) (string, error) {
+			return "", errors.New("already exists")
+		})
+
+		assert.EqualError(t, err, "already exists")
+	})
+}
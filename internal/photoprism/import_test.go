@@ -1,11 +1,16 @@
 package photoprism
 
 import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/photoprism/photoprism/internal/classify"
 	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/nsfw"
+	"github.com/photoprism/photoprism/pkg/fs"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -46,6 +51,19 @@ func TestImport_DestinationFilename(t *testing.T) {
 	}
 
 	assert.Equal(t, conf.OriginalsPath()+"/2019/07/20190705_153230_C167C6FD.cr2", fileName)
+
+	t.Run("template", func(t *testing.T) {
+		conf.Settings().Import.Template = "{year}-{month}/{original}_{checksum}"
+		defer func() { conf.Settings().Import.Template = "" }()
+
+		fileName, err := imp.DestinationFilename(rawFile, rawFile)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, conf.OriginalsPath()+"/2019-07/IMG_2567_C167C6FD.cr2", fileName)
+	})
 }
 
 func TestImport_Start(t *testing.T) {
@@ -67,5 +85,158 @@ func TestImport_Start(t *testing.T) {
 
 	opt := ImportOptionsMove(conf.ImportPath())
 
-	imp.Start(opt)
+	if _, err := imp.Start(opt); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestImport_Start_takeoutAlbum(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping test in short mode.")
+	}
+
+	conf := config.TestConfig()
+
+	conf.InitializeTestData(t)
+
+	tf := classify.New(conf.ResourcesPath(), conf.DisableTensorFlow())
+	nd := nsfw.New(conf.NSFWModelPath())
+	convert := NewConvert(conf)
+
+	ind := NewIndex(conf, tf, nd, convert)
+	imp := NewImport(conf, ind, convert)
+
+	takeoutPath := filepath.Join(conf.ImportPath(), "Takeout")
+	albumPath := filepath.Join(takeoutPath, "Summer 2019")
+
+	if err := os.MkdirAll(albumPath, os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(takeoutPath)
+
+	img, err := ioutil.ReadFile(conf.ExamplesPath() + "/cat_brown.jpg")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Trailing bytes make sure the file isn't skipped as a duplicate of the example.
+	img = append(img, []byte("takeout album")...)
+	fileName := filepath.Join(albumPath, "IMG_1234.jpg")
+
+	if err := ioutil.WriteFile(fileName, img, os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+
+	sidecar := []byte(`{"title": "IMG_1234.jpg", "photoTakenTime": {"timestamp": "1560500127"}}`)
+
+	if err := ioutil.WriteFile(fileName+".json", sidecar, os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+
+	hash := fs.Hash(fileName)
+
+	if _, err := imp.Start(ImportOptionsCopy(takeoutPath)); err != nil {
+		t.Fatal(err)
+	}
+
+	file, err := entity.FirstFileByHash(hash)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var albums []entity.Album
+
+	if err := entity.Db().Joins("JOIN photos_albums ON photos_albums.album_uid = albums.album_uid").
+		Where("photos_albums.photo_uid = ?", file.PhotoUID).Find(&albums).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	if assert.Len(t, albums, 1) {
+		assert.Equal(t, "Summer 2019", albums[0].AlbumTitle)
+	}
+}
+
+func TestImport_Plan(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping test in short mode.")
+	}
+
+	conf := config.TestConfig()
+
+	conf.InitializeTestData(t)
+
+	tf := classify.New(conf.ResourcesPath(), conf.DisableTensorFlow())
+	nd := nsfw.New(conf.NSFWModelPath())
+	convert := NewConvert(conf)
+
+	ind := NewIndex(conf, tf, nd, convert)
+
+	imp := NewImport(conf, ind, convert)
+
+	plan, err := imp.Plan(ImportOptionsMove(conf.ImportPath()))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.NotEmpty(t, plan.Files)
+	assert.True(t, fs.FileExists(conf.ImportPath()+"/raw/IMG_2567.CR2"))
+
+	t.Run("archive", func(t *testing.T) {
+		archive := filepath.Join(conf.ImportPath(), "plan_test.zip")
+
+		if err := fs.Zip(archive, []string{conf.ExamplesPath() + "/cat_brown.jpg"}); err != nil {
+			t.Fatal(err)
+		}
+
+		defer os.Remove(archive)
+
+		plan, err := imp.Plan(ImportOptionsMove(conf.ImportPath()))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Contains(t, plan.Files, ImportPlanFile{Name: "plan_test.zip", Action: PlanArchive})
+
+		for _, f := range plan.Files {
+			assert.NotEqual(t, "cat_brown.jpg", f.Name)
+		}
+	})
+}
+
+func TestImport_extractArchive(t *testing.T) {
+	conf := config.TestConfig()
+
+	tf := classify.New(conf.ResourcesPath(), conf.DisableTensorFlow())
+	nd := nsfw.New(conf.NSFWModelPath())
+	convert := NewConvert(conf)
+
+	ind := NewIndex(conf, tf, nd, convert)
+	imp := NewImport(conf, ind, convert)
+
+	if err := os.MkdirAll(conf.TempPath(), os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+
+	archive := filepath.Join(conf.TempPath(), "extract_test.zip")
+
+	if err := fs.Zip(archive, []string{conf.ExamplesPath() + "/cat_brown.jpg"}); err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.Remove(archive)
+
+	tempDir, err := imp.extractArchive(archive, conf.TempPath())
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(tempDir)
+
+	assert.True(t, fs.FileExists(filepath.Join(tempDir, "cat_brown.jpg")))
 }
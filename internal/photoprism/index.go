@@ -6,13 +6,13 @@ import (
 	"path/filepath"
 	"runtime"
 	"sync"
+	"time"
 
 	"github.com/jinzhu/gorm"
 	"github.com/karrick/godirwalk"
 	"github.com/photoprism/photoprism/internal/classify"
 	"github.com/photoprism/photoprism/internal/config"
 	"github.com/photoprism/photoprism/internal/entity"
-	"github.com/photoprism/photoprism/internal/event"
 	"github.com/photoprism/photoprism/internal/mutex"
 	"github.com/photoprism/photoprism/internal/nsfw"
 	"github.com/photoprism/photoprism/internal/query"
@@ -28,6 +28,8 @@ type Index struct {
 	convert      *Convert
 	db           *gorm.DB
 	q            *query.Query
+	transcode    []string
+	transcodeMu  sync.Mutex
 }
 
 // NewIndex returns a new indexer and expects its dependencies as arguments.
@@ -52,33 +54,120 @@ func (ind *Index) thumbPath() string {
 	return ind.conf.ThumbPath()
 }
 
+// addTranscode remembers the hash of an indexed video browsers can't play.
+func (ind *Index) addTranscode(fileHash string) {
+	ind.transcodeMu.Lock()
+	defer ind.transcodeMu.Unlock()
+
+	ind.transcode = append(ind.transcode, fileHash)
+}
+
+// Transcode returns the hashes of videos indexed since the last call that browsers can't play.
+func (ind *Index) Transcode() (hashes []string) {
+	ind.transcodeMu.Lock()
+	defer ind.transcodeMu.Unlock()
+
+	hashes, ind.transcode = ind.transcode, nil
+
+	return hashes
+}
+
 // Cancel stops the current indexing operation.
 func (ind *Index) Cancel() {
 	mutex.Worker.Cancel()
 }
 
-// Start indexes media files in the originals directory.
-func (ind *Index) Start(opt IndexOptions) map[string]bool {
+// indexRun returns the interrupted run to resume if requested, or records a new run otherwise.
+func (ind *Index) indexRun(runPath string, opt IndexOptions) *entity.IndexRun {
+	if opt.Resume {
+		if run := entity.FindIndexRun(runPath, opt.Rescan, opt.Convert); run != nil {
+			run.RunStatus = entity.IndexRunning
+
+			if err := run.Save(); err != nil {
+				log.Errorf("index: %s", err)
+				return nil
+			}
+
+			return run
+		}
+
+		log.Infof("index: no interrupted run found for %s", txt.Quote(runPath))
+	}
+
+	run := entity.NewIndexRun(runPath, opt.Rescan, opt.Convert)
+
+	if err := run.Create(); err != nil {
+		log.Errorf("index: %s", err)
+		return nil
+	}
+
+	return run
+}
+
+// Start indexes media files in the originals directory and returns the indexed file names.
+// An error is returned if indexing could not be started, e.g. because another worker is busy.
+func (ind *Index) Start(opt IndexOptions) (map[string]bool, error) {
+	start := time.Now()
 	done := make(map[string]bool)
 	originalsPath := ind.originalsPath()
 	optionsPath := filepath.Join(originalsPath, opt.Path)
 
 	if !fs.PathExists(optionsPath) {
-		event.Error(fmt.Sprintf("index: %s does not exist", txt.Quote(optionsPath)))
-		return done
+		return done, fmt.Errorf("index: %s does not exist", txt.Quote(optionsPath))
 	}
 
 	if err := mutex.Worker.Start(); err != nil {
-		event.Error(fmt.Sprintf("index: %s", err.Error()))
-		return done
+		return done, fmt.Errorf("index: %s", err.Error())
 	}
 
 	defer mutex.Worker.Stop()
 
 	if err := ind.tensorFlow.Init(); err != nil {
-		log.Errorf("index: %s", err.Error())
+		return done, fmt.Errorf("index: %s", err.Error())
+	}
 
-		return done
+	runPath := fs.RelativeName(optionsPath, originalsPath)
+	run := ind.indexRun(runPath, opt)
+	checkpoint := NewIndexCheckpoint()
+	saved := time.Now()
+	resume := ""
+	files, folders := 0, 0
+
+	if run != nil {
+		resume, files, folders = run.RunCheckpoint, run.FilesIndexed, run.FoldersDone
+	}
+
+	if resume != "" {
+		log.Infof("index: resuming after %s", txt.Quote(resume))
+	}
+
+	// The number of files indexed by the last completed run is used until files have been counted.
+	estimate := 0
+
+	if last := entity.LastIndexRun(runPath); last != nil && resume == "" {
+		estimate = last.FilesIndexed
+	}
+
+	progress := NewProgress("index", estimate)
+	progress.Count(optionsPath, IsMediaType, func(fileName string) bool {
+		return IndexedBefore(fs.RelativeName(fileName, originalsPath), resume)
+	})
+
+	// saveCheckpoint stores the progress of the current run so that it can be resumed.
+	saveCheckpoint := func() {
+		if run == nil {
+			return
+		}
+
+		folder, n, m := checkpoint.Folder()
+
+		if folder == "" {
+			folder = resume
+		}
+
+		if err := run.Checkpoint(folder, files+n, folders+m); err != nil {
+			log.Errorf("index: %s", err)
+		}
 	}
 
 	jobs := make(chan IndexJob)
@@ -116,9 +205,22 @@ func (ind *Index) Start(opt IndexOptions) map[string]bool {
 				return errors.New("indexing canceled")
 			}
 
+			if time.Since(saved) > IndexCheckpointInterval {
+				saveCheckpoint()
+				saved = time.Now()
+			}
+
 			isDir := info.IsDir()
 			isSymlink := info.IsSymlink()
 
+			if IndexedBefore(fs.RelativeName(fileName, originalsPath), resume) {
+				if isDir {
+					return filepath.SkipDir
+				}
+
+				return nil
+			}
+
 			if skip, result := fs.SkipWalk(fileName, isDir, isSymlink, done, ignore); skip {
 				if isDir && result != filepath.SkipDir {
 					folder := entity.NewFolder(entity.RootOriginals, fs.RelativeName(fileName, originalsPath), nil)
@@ -165,10 +267,16 @@ func (ind *Index) Start(opt IndexOptions) map[string]bool {
 				Related:  related,
 				IndexOpt: opt,
 				Ind:      ind,
+				Done:     checkpoint.Add(),
+				Progress: progress,
 			}
 
 			return nil
 		},
+		PostChildrenCallback: func(dirName string, info *godirwalk.Dirent) error {
+			checkpoint.FolderWalked(fs.RelativeName(dirName, originalsPath))
+			return nil
+		},
 		Unsorted:            false,
 		FollowSymbolicLinks: true,
 	})
@@ -176,17 +284,37 @@ func (ind *Index) Start(opt IndexOptions) map[string]bool {
 	close(jobs)
 	wg.Wait()
 
+	progress.Finish()
+
 	if err != nil {
 		log.Error(err.Error())
 	}
 
+	if run != nil {
+		saveCheckpoint()
+
+		status := entity.IndexCompleted
+
+		if err != nil {
+			status = entity.IndexCanceled
+		}
+
+		if err := run.Finish(status); err != nil {
+			log.Errorf("index: %s", err)
+		}
+	}
+
 	if len(done) > 0 {
 		if err := entity.UpdatePhotoCounts(); err != nil {
 			log.Errorf("index: %s", err)
 		}
+
+		if _, err := DetectStacks(start); err != nil {
+			log.Errorf("index: %s", err)
+		}
 	}
 
 	runtime.GC()
 
-	return done
+	return done, nil
 }
@@ -0,0 +1,108 @@
+package photoprism
+
+import (
+	"strings"
+	"sync"
+	"time"
+)
+
+// IndexCheckpointInterval is the minimum time between saving the progress of an index run.
+var IndexCheckpointInterval = 5 * time.Second
+
+// IndexCheckpoint keeps track of folders whose files have all been indexed,
+// so that an interrupted run can be resumed from the last completed folder.
+type IndexCheckpoint struct {
+	mutex   sync.Mutex
+	seq     uint64
+	pending map[uint64]bool
+	walked  []walkedFolder
+	folder  string
+	folders int
+	files   int
+}
+
+type walkedFolder struct {
+	name string
+	seq  uint64
+}
+
+// NewIndexCheckpoint returns a new checkpoint tracker.
+func NewIndexCheckpoint() *IndexCheckpoint {
+	return &IndexCheckpoint{pending: make(map[uint64]bool)}
+}
+
+// Add registers a new job and returns a function that must be called once it is done.
+func (c *IndexCheckpoint) Add() func() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.seq++
+	seq := c.seq
+	c.pending[seq] = true
+
+	return func() {
+		c.mutex.Lock()
+		defer c.mutex.Unlock()
+
+		delete(c.pending, seq)
+		c.files++
+		c.update()
+	}
+}
+
+// FolderWalked marks a folder as completely walked, its jobs may still be pending.
+func (c *IndexCheckpoint) FolderWalked(name string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.walked = append(c.walked, walkedFolder{name: name, seq: c.seq})
+	c.update()
+}
+
+// Folder returns the last folder whose files have all been indexed and the progress counters.
+func (c *IndexCheckpoint) Folder() (name string, files, folders int) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.folder, c.files, c.folders
+}
+
+// update advances the checkpoint to the last walked folder without pending jobs.
+func (c *IndexCheckpoint) update() {
+	min := c.seq + 1
+
+	for seq := range c.pending {
+		if seq < min {
+			min = seq
+		}
+	}
+
+	for len(c.walked) > 0 && c.walked[0].seq < min {
+		c.folder = c.walked[0].name
+		c.folders++
+		c.walked = c.walked[1:]
+	}
+}
+
+// IndexedBefore tests if a relative file or folder name was walked before the checkpoint
+// folder had been completed. Ancestors of the checkpoint are never skipped.
+func IndexedBefore(name, checkpoint string) bool {
+	if name == "" || checkpoint == "" {
+		return false
+	}
+
+	if name == checkpoint || strings.HasPrefix(name, checkpoint+"/") {
+		return true
+	}
+
+	a := strings.Split(name, "/")
+	b := strings.Split(checkpoint, "/")
+
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if a[i] != b[i] {
+			return a[i] < b[i]
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,100 @@
+package photoprism
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIndexCheckpoint_Folder(t *testing.T) {
+	t.Run("in order", func(t *testing.T) {
+		c := NewIndexCheckpoint()
+
+		doneA := c.Add()
+		doneB := c.Add()
+		c.FolderWalked("2020/a")
+		doneC := c.Add()
+		c.FolderWalked("2020/b")
+
+		folder, files, folders := c.Folder()
+		assert.Equal(t, "", folder)
+		assert.Equal(t, 0, files)
+		assert.Equal(t, 0, folders)
+
+		doneB()
+		doneA()
+
+		folder, files, folders = c.Folder()
+		assert.Equal(t, "2020/a", folder)
+		assert.Equal(t, 2, files)
+		assert.Equal(t, 1, folders)
+
+		doneC()
+
+		folder, files, folders = c.Folder()
+		assert.Equal(t, "2020/b", folder)
+		assert.Equal(t, 3, files)
+		assert.Equal(t, 2, folders)
+	})
+	t.Run("pending job", func(t *testing.T) {
+		c := NewIndexCheckpoint()
+
+		doneA := c.Add()
+		c.FolderWalked("a")
+		doneB := c.Add()
+		c.FolderWalked("b")
+		c.FolderWalked("")
+
+		doneB()
+
+		folder, _, _ := c.Folder()
+		assert.Equal(t, "", folder)
+
+		doneA()
+
+		folder, files, folders := c.Folder()
+		assert.Equal(t, "", folder)
+		assert.Equal(t, 2, files)
+		assert.Equal(t, 3, folders)
+	})
+	t.Run("empty folders", func(t *testing.T) {
+		c := NewIndexCheckpoint()
+
+		c.FolderWalked("a")
+		c.FolderWalked("b")
+
+		folder, files, folders := c.Folder()
+		assert.Equal(t, "b", folder)
+		assert.Equal(t, 0, files)
+		assert.Equal(t, 2, folders)
+	})
+}
+
+func TestIndexedBefore(t *testing.T) {
+	t.Run("no checkpoint", func(t *testing.T) {
+		assert.False(t, IndexedBefore("2020/a/photo.jpg", ""))
+	})
+	t.Run("root", func(t *testing.T) {
+		assert.False(t, IndexedBefore("", "2020/b"))
+	})
+	t.Run("checkpoint", func(t *testing.T) {
+		assert.True(t, IndexedBefore("2020/b", "2020/b"))
+		assert.True(t, IndexedBefore("2020/b/photo.jpg", "2020/b"))
+		assert.False(t, IndexedBefore("2020/bb", "2020/b"))
+	})
+	t.Run("ancestor", func(t *testing.T) {
+		assert.False(t, IndexedBefore("2020", "2020/b"))
+	})
+	t.Run("before", func(t *testing.T) {
+		assert.True(t, IndexedBefore("2019", "2020/b"))
+		assert.True(t, IndexedBefore("2020/a", "2020/b"))
+		assert.True(t, IndexedBefore("2020/a.jpg", "2020/b"))
+		assert.True(t, IndexedBefore("2020/a/photo.jpg", "2020/b"))
+	})
+	t.Run("after", func(t *testing.T) {
+		assert.False(t, IndexedBefore("2021", "2020/b"))
+		assert.False(t, IndexedBefore("2020/c", "2020/b"))
+		assert.False(t, IndexedBefore("2020/c.jpg", "2020/b"))
+		assert.False(t, IndexedBefore("photo.jpg", "2020/b"))
+	})
+}
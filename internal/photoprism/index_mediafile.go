@@ -12,6 +12,7 @@ import (
 	"github.com/photoprism/photoprism/internal/classify"
 	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/maps"
 	"github.com/photoprism/photoprism/internal/meta"
 	"github.com/photoprism/photoprism/internal/nsfw"
 	"github.com/photoprism/photoprism/internal/query"
@@ -67,6 +68,7 @@ func (ind *Index) MediaFile(m *MediaFile, o IndexOptions, originalName string) (
 	metaData := meta.Data{}
 	description := entity.Details{}
 	labels := classify.Labels{}
+	people := make(map[string]meta.Data)
 
 	fileBase := m.Base(ind.conf.Settings().Index.Group)
 	filePath := m.RelativePath(ind.originalsPath())
@@ -119,6 +121,15 @@ func (ind *Index) MediaFile(m *MediaFile, o IndexOptions, originalName string) (
 	photoExists = photoQuery.Error == nil
 
 	if !fileChanged && photoExists && o.SkipUnchanged() {
+		// Backfill perceptual hashes of files indexed before they were introduced.
+		if fileExists && file.FilePHash == "" && m.IsJpeg() {
+			if h, err := m.PerceptualHash(ind.thumbPath()); err != nil {
+				log.Errorf("index: %s for %s", err.Error(), txt.Quote(m.RelativeName(ind.originalsPath())))
+			} else if err := ind.db.Model(&file).UpdateColumn("file_p_hash", h.Hex()).Error; err != nil {
+				log.Errorf("index: %s for %s", err.Error(), txt.Quote(m.RelativeName(ind.originalsPath())))
+			}
+		}
+
 		result.Status = IndexSkipped
 		return result
 	}
@@ -169,6 +180,13 @@ func (ind *Index) MediaFile(m *MediaFile, o IndexOptions, originalName string) (
 			file.FileChroma = p.Chroma.Value()
 		}
 
+		// Perceptual hash to find near-duplicates
+		if h, err := m.PerceptualHash(ind.thumbPath()); err != nil {
+			log.Errorf("index: %s for %s", err.Error(), txt.Quote(m.RelativeName(ind.originalsPath())))
+		} else {
+			file.FilePHash = h.Hex()
+		}
+
 		if m.Width() > 0 && m.Height() > 0 {
 			file.FileWidth = m.Width()
 			file.FileHeight = m.Height()
@@ -198,22 +216,59 @@ func (ind *Index) MediaFile(m *MediaFile, o IndexOptions, originalName string) (
 			if photo.Details.NoCopyright() && data.Copyright != "" {
 				photo.Details.Copyright = data.Copyright
 			}
+
+			cullingMetaData(&photo, data, entity.SrcXmp)
+
+			labels = append(labels, metaLabels(data, entity.SrcXmp)...)
+
+			people[entity.SrcXmp] = data
+		}
+	case m.IsJson():
+		// Google Takeout sidecar files contain the date, location and people as seen in Google Photos.
+		if data, err := meta.Takeout(m.FileName()); err == nil {
+			locKeywords, locLabels := ind.takeoutMetaData(&photo, data)
+
+			labels = append(labels, locLabels...)
+
+			if len(locKeywords) > 0 {
+				w := txt.Keywords(photo.Details.Keywords)
+				w = append(w, locKeywords...)
+				photo.Details.Keywords = strings.Join(txt.UniqueWords(w), ", ")
+			}
+
+			people[entity.SrcTakeout] = data
 		}
 	case m.IsRaw():
 		if photo.PhotoType == entity.TypeImage {
 			photo.PhotoType = entity.TypeRaw
 		}
 	case m.IsVideo():
-		metaData, _ = m.MetaData()
+		metaData, _ = m.VideoMetaData(ind.conf.FFprobeBin())
+
+		// Videos browsers can't play are transcoded after indexing if configured.
+		if ind.conf.VideoTranscode() == "index" && !m.IsPlayableVideo() {
+			ind.addTranscode(fileHash)
+		}
 
 		file.FileCodec = metaData.Codec
 		file.FileWidth = metaData.Width
 		file.FileHeight = metaData.Height
 		file.FileDuration = metaData.Duration
+		file.FileFPS = metaData.FrameRate
+		file.FileBitrate = metaData.Bitrate
+		file.FileAudioCodec = metaData.AudioCodec
+		file.FileRotation = metaData.Rotation
+		file.FileTransfer = metaData.ColorTransfer
+		file.FileHDR = metaData.HDR()
+		file.FileStreams = metaData.Streams
 		file.FileAspectRatio = metaData.AspectRatio()
 		file.FilePortrait = metaData.Portrait()
 
-		if file.FileDuration == 0 || file.FileDuration > time.Millisecond*3100 {
+		if still := m.LiveStill(); still != nil {
+			log.Debugf("index: %s is the motion clip of %s", txt.Quote(m.RelativeName(ind.originalsPath())), txt.Quote(still.RelativeName(ind.originalsPath())))
+			photo.PhotoType = entity.TypeLive
+			file.FileMotion = true
+		} else if file.FileDuration == 0 || file.FileDuration > LiveMaxDuration {
 			photo.PhotoType = entity.TypeVideo
 		} else {
 			photo.PhotoType = entity.TypeLive
@@ -243,6 +298,7 @@ func (ind *Index) MediaFile(m *MediaFile, o IndexOptions, originalName string) (
 	file.DeletedAt = nil
 	file.FileMissing = false
 	file.FileError = ""
+	file.FileQuarantine = false
 
 	// primary files are used for rendering thumbnails and image classification (plus sidecar files if they exist)
 	if file.FilePrimary {
@@ -288,6 +344,15 @@ func (ind *Index) MediaFile(m *MediaFile, o IndexOptions, originalName string) (
 				photo.CameraSerial = metaData.CameraSerial
 			}
 
+			photo.PhotoBias = metaData.ExposureBias
+			photo.PhotoSequence = metaData.Sequence
+
+			cullingMetaData(&photo, metaData, entity.SrcMeta)
+
+			labels = append(labels, metaLabels(metaData, entity.SrcMeta)...)
+
+			people[entity.SrcMeta] = metaData
+
 			if len(metaData.UniqueID) > 15 {
 				log.Debugf("index: found file uid %s for %s", txt.Quote(metaData.UniqueID), txt.Quote(m.RelativeName(ind.originalsPath())))
 
@@ -335,6 +400,17 @@ func (ind *Index) MediaFile(m *MediaFile, o IndexOptions, originalName string) (
 			photo.LocUID = entity.UnknownLocation.LocUID
 			photo.Place = &entity.UnknownPlace
 			photo.PlaceUID = entity.UnknownPlace.PlaceUID
+
+			// Use location names from IPTC or Exiftool metadata instead, if any.
+			if data, err := m.MetaData(); err == nil {
+				locKeywords = append(locKeywords, metaLocationKeywords(data)...)
+
+				if code := maps.CountryCode(data.CountryCode); code != "" {
+					photo.PhotoCountry = code
+				} else if code := maps.CountryCode(data.Country); code != "" {
+					photo.PhotoCountry = code
+				}
+			}
 		}
 	}
 
@@ -359,6 +435,11 @@ func (ind *Index) MediaFile(m *MediaFile, o IndexOptions, originalName string) (
 	file.FileVideo = m.IsVideo()
 	file.FileRoot = fileRoot
 	file.FileName = fileName
+
+	if file.FileHash != fileHash {
+		file.FileVerified = nil
+	}
+
 	file.FileHash = fileHash
 	file.FileSize = fileSize
 	file.FileModified = fileModified
@@ -380,8 +461,6 @@ func (ind *Index) MediaFile(m *MediaFile, o IndexOptions, originalName string) (
 			} else {
 				log.Infof("index: restored from %s", txt.Quote(fs.RelativeName(yamlName, ind.originalsPath())))
 			}
-		} else {
-			photo.PhotoFavorite = false
 		}
 
 		if err := ind.db.Create(&photo).Error; err != nil {
@@ -412,6 +491,10 @@ func (ind *Index) MediaFile(m *MediaFile, o IndexOptions, originalName string) (
 
 	photo.AddLabels(labels)
 
+	for source, data := range people {
+		metaPeople(&photo, data, source)
+	}
+
 	file.PhotoID = photo.ID
 	result.PhotoID = photo.ID
 
@@ -532,6 +615,105 @@ func (ind *Index) MediaFile(m *MediaFile, o IndexOptions, originalName string) (
 	return result
 }
 
+// cullingMetaData updates rating, favorite flag and keywords based on ratings, color labels and pick flags.
+func cullingMetaData(photo *entity.Photo, data meta.Data, source string) {
+	if data.Rating != 0 || data.Pick != 0 {
+		log.Debugf("index: %s rating is %d, pick flag is %d", source, data.Rating, data.Pick)
+
+		photo.SetRating(data.Stars(), data.Favorite())
+	}
+
+	if data.Keywords == "" && data.ColorLabel == "" {
+		return
+	}
+
+	w := txt.Keywords(photo.Details.Keywords)
+	w = append(w, txt.Keywords(data.Keywords)...)
+	w = append(w, txt.Keywords(data.ColorLabel)...)
+
+	photo.Details.Keywords = strings.Join(txt.UniqueWords(w), ", ")
+}
+
+// takeoutMetaData updates a photo with metadata from a Google Takeout sidecar file.
+func (ind *Index) takeoutMetaData(photo *entity.Photo, data meta.Data) (keywords []string, labels classify.Labels) {
+	photo.SetDescription(data.Description, entity.SrcTakeout)
+
+	// Google Photos knows better than file names and modification times, reindexing reapplies it.
+	// Sidecars without a time leave the current time and source unchanged.
+	if !data.TakenAt.IsZero() && (photo.TakenSrc == entity.SrcAuto || photo.TakenSrc == entity.SrcName || photo.TakenSrc == entity.SrcTakeout) {
+		photo.TakenSrc = entity.SrcAuto
+		photo.SetTakenAt(data.TakenAt, data.TakenAtLocal, data.TimeZone, entity.SrcTakeout)
+	}
+
+	if photo.Details.NoSubject() && data.Subject != "" {
+		photo.Details.Subject = data.Subject
+	}
+
+	if data.Favorite() {
+		photo.SetRating(photo.PhotoRating, true)
+	}
+
+	if photo.HasLatLng() && photo.LocSrc != entity.SrcTakeout {
+		return keywords, labels
+	}
+
+	lat, lng := photo.PhotoLat, photo.PhotoLng
+
+	photo.SetCoordinates(data.Lat, data.Lng, data.Altitude, entity.SrcTakeout)
+
+	if photo.HasLatLng() && (lat != photo.PhotoLat || lng != photo.PhotoLng || photo.NoLocation()) {
+		keywords, labels = photo.UpdateLocation(ind.conf.GeoCodingApi())
+	}
+
+	return keywords, labels
+}
+
+// metaLocationKeywords returns keywords for location names found in metadata.
+func metaLocationKeywords(data meta.Data) (result []string) {
+	result = append(result, txt.Keywords(data.Sublocation)...)
+	result = append(result, txt.Keywords(data.City)...)
+	result = append(result, txt.Keywords(data.State)...)
+	result = append(result, txt.Keywords(data.Country)...)
+
+	return result
+}
+
+// metaLabels returns labels for hierarchical keywords found in metadata.
+func metaLabels(data meta.Data, source string) (result classify.Labels) {
+	for _, keyword := range data.Labels {
+		result = result.AppendLabel(classify.HierarchicalLabel(keyword, source))
+	}
+
+	return result
+}
+
+// metaPeople adds people found in metadata to a photo, including their face regions if known.
+func metaPeople(photo *entity.Photo, data meta.Data, source string) {
+	if photo.ID == 0 || len(data.People) == 0 {
+		return
+	}
+
+	faces := data.Regions.Faces()
+
+	for _, name := range data.People {
+		person := entity.NewPerson(name, source).FirstOrCreate()
+
+		if person.ID == 0 {
+			continue
+		}
+
+		photoPerson := entity.NewPhotoPerson(photo.ID, person.ID, source).FirstOrCreate()
+
+		if region, ok := faces.Person(name); ok && !photoPerson.HasRegion() {
+			photoPerson.SetRegion(region.X, region.Y, region.W, region.H)
+
+			if err := photoPerson.Save(); err != nil {
+				log.Errorf("index: %s", err)
+			}
+		}
+	}
+}
+
 // NSFW returns true if media file might be offensive and detection is enabled.
 func (ind *Index) NSFW(jpeg *MediaFile) bool {
 	filename, err := jpeg.Thumbnail(ind.thumbPath(), "fit_720")
@@ -4,6 +4,7 @@ type IndexOptions struct {
 	Path    string
 	Rescan  bool
 	Convert bool
+	Resume  bool
 }
 
 func (o *IndexOptions) SkipUnchanged() bool {
@@ -1,11 +1,18 @@
 package photoprism
 
 import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/photoprism/photoprism/internal/classify"
 	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/meta"
 	"github.com/photoprism/photoprism/internal/nsfw"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestIndex_Start(t *testing.T) {
@@ -25,9 +32,125 @@ func TestIndex_Start(t *testing.T) {
 	imp := NewImport(conf, ind, convert)
 	opt := ImportOptionsMove(conf.ImportPath())
 
-	imp.Start(opt)
+	if _, err := imp.Start(opt); err != nil {
+		t.Fatal(err)
+	}
 
 	indexOpt := IndexOptionsAll()
 
-	ind.Start(indexOpt)
+	if _, err := ind.Start(indexOpt); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestIndex_MediaFile(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping test in short mode.")
+	}
+
+	conf := config.TestConfig()
+
+	t.Run("favorite", func(t *testing.T) {
+		dir := filepath.Join(conf.OriginalsPath(), "index-favorite")
+
+		if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+			t.Fatal(err)
+		}
+
+		defer os.RemoveAll(dir)
+
+		src, err := NewMediaFile(conf.ExamplesPath() + "/elephants.jpg")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		fileName := filepath.Join(dir, "favorite.jpg")
+
+		if err := src.Copy(fileName); err != nil {
+			t.Fatal(err)
+		}
+
+		sidecar := []byte(`[{"SourceFile": "favorite.jpg", "Rating": 5}]`)
+
+		if err := ioutil.WriteFile(filepath.Join(dir, "favorite.json"), sidecar, 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		mf, err := NewMediaFile(fileName)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		ind := NewIndex(conf, classify.New(conf.ResourcesPath(), true), nsfw.New(conf.NSFWModelPath()), NewConvert(conf))
+		result := ind.MediaFile(mf, IndexOptionsAll(), "")
+
+		if result.Error != nil {
+			t.Fatal(result.Error)
+		}
+
+		assert.Equal(t, IndexAdded, result.Status)
+
+		var photo entity.Photo
+
+		if err := conf.Db().First(&photo, result.PhotoID).Error; err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, 5, photo.PhotoRating)
+		assert.True(t, photo.PhotoFavorite)
+	})
+}
+
+func TestIndex_takeoutMetaData(t *testing.T) {
+	conf := config.TestConfig()
+
+	ind := NewIndex(conf, nil, nil, NewConvert(conf))
+
+	takenAt := time.Date(2019, 6, 14, 8, 15, 27, 0, time.UTC)
+
+	t.Run("name", func(t *testing.T) {
+		photo := entity.Photo{TakenAt: time.Date(2019, 6, 1, 0, 0, 0, 0, time.UTC), TakenSrc: entity.SrcName}
+
+		ind.takeoutMetaData(&photo, meta.Data{TakenAt: takenAt})
+
+		assert.Equal(t, takenAt, photo.TakenAt)
+		assert.Equal(t, entity.SrcTakeout, photo.TakenSrc)
+	})
+	t.Run("reindex", func(t *testing.T) {
+		photo := entity.Photo{TakenAt: time.Date(2019, 6, 1, 0, 0, 0, 0, time.UTC), TakenSrc: entity.SrcTakeout}
+
+		ind.takeoutMetaData(&photo, meta.Data{TakenAt: takenAt})
+
+		assert.Equal(t, takenAt, photo.TakenAt)
+		assert.Equal(t, entity.SrcTakeout, photo.TakenSrc)
+	})
+	t.Run("meta", func(t *testing.T) {
+		photo := entity.Photo{TakenAt: time.Date(2019, 6, 1, 0, 0, 0, 0, time.UTC), TakenSrc: entity.SrcMeta}
+
+		ind.takeoutMetaData(&photo, meta.Data{TakenAt: takenAt})
+
+		assert.Equal(t, time.Date(2019, 6, 1, 0, 0, 0, 0, time.UTC), photo.TakenAt)
+		assert.Equal(t, entity.SrcMeta, photo.TakenSrc)
+	})
+	t.Run("no time", func(t *testing.T) {
+		photo := entity.Photo{TakenAt: time.Date(2019, 6, 1, 0, 0, 0, 0, time.UTC), TakenSrc: entity.SrcTakeout}
+
+		ind.takeoutMetaData(&photo, meta.Data{Description: "Summer"})
+
+		assert.Equal(t, time.Date(2019, 6, 1, 0, 0, 0, 0, time.UTC), photo.TakenAt)
+		assert.Equal(t, entity.SrcTakeout, photo.TakenSrc)
+	})
+}
+
+func TestIndex_Transcode(t *testing.T) {
+	conf := config.TestConfig()
+
+	ind := NewIndex(conf, nil, nil, NewConvert(conf))
+
+	ind.addTranscode("acad9168fa6acc5c5c2965ddf6ec465ca42fd831")
+
+	assert.Equal(t, []string{"acad9168fa6acc5c5c2965ddf6ec465ca42fd831"}, ind.Transcode())
+	assert.Empty(t, ind.Transcode())
 }
@@ -13,81 +13,111 @@ type IndexJob struct {
 	Related  RelatedFiles
 	IndexOpt IndexOptions
 	Ind      *Index
+	Done     func()
+	Progress *Progress
 }
 
 func IndexWorker(jobs <-chan IndexJob) {
 	for job := range jobs {
-		done := make(map[string]bool)
-		related := job.Related
-		opt := job.IndexOpt
-		ind := job.Ind
-
-		// Skip sidecar files without related media file.
-		if related.Main == nil {
-			log.Warnf("index: no media file found for %s", txt.Quote(fs.RelativeName(job.FileName, ind.originalsPath())))
-			continue
-		}
+		count, size := job.Related.MediaCount()
 
-		// Enforce file size limit for originals.
-		if ind.conf.OriginalsLimit() > 0 && related.Main.FileSize() > ind.conf.OriginalsLimit() {
-			log.Warnf("index: %s exceeds file size limit for originals [%d / %d MB]", filepath.Base(related.Main.FileName()), related.Main.FileSize()/(1024*1024), ind.conf.OriginalsLimit()/(1024*1024))
-			continue
+		indexRelated(job)
+
+		job.Progress.Processed(count, size, fs.RelativeName(filepath.Dir(job.FileName), job.Ind.originalsPath()))
+
+		if job.Done != nil {
+			job.Done()
 		}
+	}
+}
 
-		f := related.Main
+// indexRelated indexes the main file of a job and its related files.
+func indexRelated(job IndexJob) {
+	done := make(map[string]bool)
+	related := job.Related
+	opt := job.IndexOpt
+	ind := job.Ind
+
+	// Skip sidecar files without related media file.
+	if related.Main == nil {
+		log.Warnf("index: no media file found for %s", txt.Quote(fs.RelativeName(job.FileName, ind.originalsPath())))
+		job.Progress.Error()
+		return
+	}
 
-		if opt.Convert && !f.HasJpeg() {
-			if jpegFile, err := ind.convert.ToJpeg(f, ind.conf.JpegHidden()); err != nil {
-				log.Errorf("index: creating jpeg failed (%s)", err.Error())
-				continue
-			} else {
-				log.Infof("index: %s created", fs.RelativeName(jpegFile.FileName(), ind.originalsPath()))
+	// Enforce file size limit for originals.
+	if ind.conf.OriginalsLimit() > 0 && related.Main.FileSize() > ind.conf.OriginalsLimit() {
+		log.Warnf("index: %s exceeds file size limit for originals [%d / %d MB]", filepath.Base(related.Main.FileName()), related.Main.FileSize()/(1024*1024), ind.conf.OriginalsLimit()/(1024*1024))
+		return
+	}
 
-				if err := jpegFile.ResampleDefault(ind.thumbPath(), false); err != nil {
-					log.Errorf("index: could not create default thumbnails (%s)", err.Error())
-					continue
-				}
+	f := related.Main
 
-				related.Files = append(related.Files, jpegFile)
+	// Corrupt files are indexed without conversion, so that they can be quarantined.
+	if opt.Convert && !f.HasJpeg() {
+		if err := f.CheckIntegrity(ind.conf.FFmpegBin()); err != nil {
+			log.Warnf("index: %s is corrupt, skipped conversion (%s)", txt.Quote(f.RelativeName(ind.originalsPath())), err)
+		} else if jpegFile, err := ind.convert.ToJpeg(f, ind.conf.JpegHidden()); err != nil {
+			log.Errorf("index: creating jpeg failed (%s)", err.Error())
+			job.Progress.Error()
+			return
+		} else {
+			log.Infof("index: %s created", fs.RelativeName(jpegFile.FileName(), ind.originalsPath()))
+
+			if err := jpegFile.ResampleDefault(ind.thumbPath(), false); err != nil {
+				log.Errorf("index: could not create default thumbnails (%s)", err.Error())
+				job.Progress.Error()
+				return
 			}
+
+			related.Files = append(related.Files, jpegFile)
+		}
+	}
+
+	if ind.conf.SidecarJson() && !f.HasJson() {
+		if jsonFile, err := ind.convert.ToJson(f, ind.conf.SidecarHidden()); err != nil {
+			log.Errorf("index: creating json sidecar file failed (%s)", err.Error())
+		} else {
+			log.Infof("index: %s created", fs.RelativeName(jsonFile.FileName(), ind.originalsPath()))
 		}
+	}
 
-		if ind.conf.SidecarJson() && !f.HasJson() {
-			if jsonFile, err := ind.convert.ToJson(f, ind.conf.SidecarHidden()); err != nil {
-				log.Errorf("index: creating json sidecar file failed (%s)", err.Error())
-			} else {
-				log.Infof("index: %s created", fs.RelativeName(jsonFile.FileName(), ind.originalsPath()))
-			}
+	res := ind.MediaFile(f, opt, "")
+	done[f.FileName()] = true
+
+	if res.Status == IndexFailed {
+		job.Progress.Error()
+	}
+
+	// Only new and changed files are checked, corrupt files don't get thumbnails.
+	if (res.Status == IndexAdded || res.Status == IndexUpdated) && ind.quarantine(f, res.FileUID) == nil && f.IsJpeg() {
+		if err := f.ResampleDefault(ind.thumbPath(), false); err != nil {
+			log.Errorf("index: could not create default thumbnails (%s)", err.Error())
+			query.SetFileError(res.FileUID, err.Error())
+		}
+	}
+
+	log.Infof("index: %s main %s file %s", res, f.FileType(), txt.Quote(f.RelativeName(ind.originalsPath())))
+
+	for _, f := range related.Files {
+		if done[f.FileName()] {
+			continue
 		}
 
 		res := ind.MediaFile(f, opt, "")
 		done[f.FileName()] = true
 
-		if (res.Status == IndexAdded || res.Status == IndexUpdated) && f.IsJpeg() {
+		if res.Status == IndexFailed {
+			job.Progress.Error()
+		}
+
+		if (res.Status == IndexAdded || res.Status == IndexUpdated) && ind.quarantine(f, res.FileUID) == nil && f.IsJpeg() {
 			if err := f.ResampleDefault(ind.thumbPath(), false); err != nil {
 				log.Errorf("index: could not create default thumbnails (%s)", err.Error())
 				query.SetFileError(res.FileUID, err.Error())
 			}
 		}
 
-		log.Infof("index: %s main %s file %s", res, f.FileType(), txt.Quote(f.RelativeName(ind.originalsPath())))
-
-		for _, f := range related.Files {
-			if done[f.FileName()] {
-				continue
-			}
-
-			res := ind.MediaFile(f, opt, "")
-			done[f.FileName()] = true
-
-			if (res.Status == IndexAdded || res.Status == IndexUpdated) && f.IsJpeg() {
-				if err := f.ResampleDefault(ind.thumbPath(), false); err != nil {
-					log.Errorf("index: could not create default thumbnails (%s)", err.Error())
-					query.SetFileError(res.FileUID, err.Error())
-				}
-			}
-
-			log.Infof("index: %s related %s file %s", res, f.FileType(), txt.Quote(f.RelativeName(ind.originalsPath())))
-		}
+		log.Infof("index: %s related %s file %s", res, f.FileType(), txt.Quote(f.RelativeName(ind.originalsPath())))
 	}
 }
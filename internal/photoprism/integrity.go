@@ -0,0 +1,173 @@
+package photoprism
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	_ "image/gif"
+	"image/jpeg"
+	"image/png"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/photoprism/photoprism/internal/mutex"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// IntegrityErrorLength is the maximum length of an integrity error stored as file error.
+const IntegrityErrorLength = 512
+
+// ErrRecheckBusy is returned if a file can't be checked again because another worker is running.
+var ErrRecheckBusy = errors.New("index: another worker is running, please try again later")
+
+// VideoErrors contains ffmpeg error messages that indicate a corrupt video, even if ffmpeg doesn't fail.
+var VideoErrors = []string{
+	"moov atom not found",
+	"Invalid data found when processing input",
+	"partial file",
+	"error while decoding",
+	"corrupt decoded frame",
+}
+
+// CheckIntegrity fully decodes images and decodes the beginning and end of videos with ffmpeg,
+// if available, to detect truncated or otherwise corrupt files. Other file types are not checked.
+// Files are only checked once, the result is returned again on subsequent calls.
+func (m *MediaFile) CheckIntegrity(ffmpegBin string) error {
+	m.checkOnce.Do(func() {
+		m.integrity = m.checkIntegrity(ffmpegBin)
+	})
+
+	return m.integrity
+}
+
+// checkIntegrity checks the file depending on its type.
+func (m *MediaFile) checkIntegrity(ffmpegBin string) error {
+	if size, _ := m.Stat(); size <= 0 {
+		return errors.New("file is empty")
+	}
+
+	switch {
+	case m.IsJpeg(), m.IsPng(), m.HasFileType(fs.TypeGif):
+		return m.checkImage()
+	case m.IsVideo() && ffmpegBin != "":
+		return m.checkVideo(ffmpegBin)
+	}
+
+	return nil
+}
+
+// checkImage decodes the complete image, unsupported features like 12-bit JPEGs are not considered corrupt.
+func (m *MediaFile) checkImage() error {
+	file, err := os.Open(m.FileName())
+
+	if err != nil {
+		return err
+	}
+
+	defer file.Close()
+
+	if _, _, err := image.Decode(file); err != nil {
+		switch err.(type) {
+		case jpeg.UnsupportedError, png.UnsupportedError:
+			log.Debugf("integrity: %s in %s", err, txt.Quote(m.Base(false)))
+			return nil
+		}
+
+		return fmt.Errorf("image: %s", err)
+	}
+
+	return nil
+}
+
+// checkVideo decodes the first and the last second of a video. Warnings printed by ffmpeg
+// are ignored unless they are known to indicate a corrupt file.
+func (m *MediaFile) checkVideo(ffmpegBin string) error {
+	checks := [][]string{
+		{"-v", "error", "-xerror", "-i", m.FileName(), "-t", "1", "-f", "null", "-"},
+		{"-v", "error", "-xerror", "-sseof", "-1", "-i", m.FileName(), "-f", "null", "-"},
+	}
+
+	for _, args := range checks {
+		var stderr bytes.Buffer
+
+		cmd := exec.Command(ffmpegBin, args...)
+		cmd.Stderr = &stderr
+
+		err := cmd.Run()
+		msg := strings.TrimSpace(stderr.String())
+
+		if err != nil && msg != "" {
+			return fmt.Errorf("video: %s", strings.Split(msg, "\n")[0])
+		} else if err != nil {
+			return fmt.Errorf("video: %s", err)
+		} else if line := videoError(msg); line != "" {
+			return fmt.Errorf("video: %s", line)
+		} else if msg != "" {
+			log.Debugf("integrity: %s in %s", strings.Split(msg, "\n")[0], txt.Quote(m.Base(false)))
+		}
+	}
+
+	return nil
+}
+
+// videoError returns the first line of ffmpeg output that contains a known error, if any.
+func videoError(output string) string {
+	for _, line := range strings.Split(output, "\n") {
+		for _, e := range VideoErrors {
+			if strings.Contains(line, e) {
+				return strings.TrimSpace(line)
+			}
+		}
+	}
+
+	return ""
+}
+
+// quarantine checks the integrity of an indexed file and flags it as corrupt if it fails.
+func (ind *Index) quarantine(m *MediaFile, fileUID string) error {
+	err := m.CheckIntegrity(ind.conf.FFmpegBin())
+
+	if err != nil {
+		log.Warnf("index: quarantined %s (%s)", txt.Quote(m.RelativeName(ind.originalsPath())), err)
+		query.QuarantineFile(fileUID, txt.Clip(err.Error(), IntegrityErrorLength))
+	}
+
+	return err
+}
+
+// Recheck verifies a quarantined file after it has been replaced and indexes it again if it is readable.
+func (ind *Index) Recheck(m *MediaFile) error {
+	if err := mutex.Worker.StartExclusive(); err != nil {
+		return ErrRecheckBusy
+	}
+
+	defer mutex.Worker.Stop()
+
+	if err := m.CheckIntegrity(ind.conf.FFmpegBin()); err != nil {
+		return err
+	}
+
+	related, err := m.RelatedFiles(ind.conf.Settings().Index.Group)
+
+	if err != nil {
+		return err
+	}
+
+	// Reuse the result of the integrity check.
+	if related.Main != nil && related.Main.FileName() == m.FileName() {
+		related.Main = m
+	}
+
+	indexRelated(IndexJob{
+		FileName: m.FileName(),
+		Related:  related,
+		IndexOpt: IndexOptionsAll(),
+		Ind:      ind,
+	})
+
+	return nil
+}
@@ -0,0 +1,111 @@
+package photoprism
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMediaFile_CheckIntegrity(t *testing.T) {
+	conf := config.TestConfig()
+
+	t.Run("valid jpeg", func(t *testing.T) {
+		mf, err := NewMediaFile(conf.ExamplesPath() + "/cat_brown.jpg")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Nil(t, mf.CheckIntegrity(""))
+	})
+	t.Run("truncated jpeg", func(t *testing.T) {
+		data, err := ioutil.ReadFile(conf.ExamplesPath() + "/cat_brown.jpg")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		fileName := filepath.Join(os.TempDir(), "truncated.jpg")
+
+		if err := ioutil.WriteFile(fileName, data[:len(data)/2], 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		defer os.Remove(fileName)
+
+		mf, err := NewMediaFile(fileName)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		err = mf.CheckIntegrity("")
+
+		if assert.Error(t, err) {
+			assert.Contains(t, err.Error(), "image: ")
+		}
+	})
+	t.Run("empty file", func(t *testing.T) {
+		fileName := filepath.Join(os.TempDir(), "empty.jpg")
+
+		if err := ioutil.WriteFile(fileName, []byte{}, 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		defer os.Remove(fileName)
+
+		mf, err := NewMediaFile(fileName)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.EqualError(t, mf.CheckIntegrity(""), "file is empty")
+	})
+	t.Run("checked once", func(t *testing.T) {
+		fileName := filepath.Join(os.TempDir(), "checked.jpg")
+
+		if err := ioutil.WriteFile(fileName, []byte{}, 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		defer os.Remove(fileName)
+
+		mf, err := NewMediaFile(fileName)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Error(t, mf.CheckIntegrity(""))
+
+		data, err := ioutil.ReadFile(conf.ExamplesPath() + "/cat_brown.jpg")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if err := ioutil.WriteFile(fileName, data, 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.EqualError(t, mf.CheckIntegrity(""), "file is empty")
+	})
+}
+
+func TestVideoError(t *testing.T) {
+	t.Run("warning", func(t *testing.T) {
+		assert.Equal(t, "", videoError("[mov,mp4,m4a,3gp,3g2,mj2 @ 0x55d] stream 1, timescale not set"))
+	})
+	t.Run("corrupt", func(t *testing.T) {
+		output := "[h264 @ 0x55d] co located POCs unavailable\n[h264 @ 0x55d] error while decoding MB 40 28, bytestream -5\n"
+		assert.Equal(t, "[h264 @ 0x55d] error while decoding MB 40 28, bytestream -5", videoError(output))
+	})
+	t.Run("empty", func(t *testing.T) {
+		assert.Equal(t, "", videoError(""))
+	})
+}
@@ -0,0 +1,54 @@
+package photoprism
+
+import (
+	"time"
+
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+// LiveMaxDuration is the maximum duration of a motion clip paired with a still image by name only.
+const LiveMaxDuration = time.Millisecond * 3100
+
+// LiveStill returns the still image of an Apple Live Photo if this is its motion clip, or nil otherwise.
+func (m *MediaFile) LiveStill() *MediaFile {
+	if !m.IsVideo() {
+		return nil
+	}
+
+	for _, t := range []fs.FileType{fs.TypeHEIF, fs.TypeJpeg} {
+		stillName := t.Find(m.FileName(), false)
+
+		if stillName == "" {
+			continue
+		}
+
+		still, err := NewMediaFile(stillName)
+
+		if err != nil {
+			continue
+		}
+
+		if IsLivePair(still, m) {
+			return still
+		}
+	}
+
+	return nil
+}
+
+// IsLivePair returns true if a still image and a video file form an Apple Live Photo. Files are paired
+// by the content identifier shared in their metadata, or by name if the video is short enough.
+func IsLivePair(still, motion *MediaFile) bool {
+	if still == nil || motion == nil || !motion.IsVideo() || !(still.IsHEIF() || still.IsJpeg()) {
+		return false
+	}
+
+	motionData, _ := motion.MetaData()
+	stillData, _ := still.MetaData()
+
+	if motionData.ContentID != "" && stillData.ContentID != "" {
+		return motionData.ContentID == stillData.ContentID
+	}
+
+	return motionData.Duration > 0 && motionData.Duration <= LiveMaxDuration
+}
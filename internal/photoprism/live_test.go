@@ -0,0 +1,144 @@
+package photoprism
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMediaFile_LiveStill(t *testing.T) {
+	conf := config.TestConfig()
+
+	t.Run("christmas.mp4", func(t *testing.T) {
+		mediaFile, err := NewMediaFile(conf.ExamplesPath() + "/christmas.mp4")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Nil(t, mediaFile.LiveStill())
+	})
+	t.Run("elephants.jpg", func(t *testing.T) {
+		mediaFile, err := NewMediaFile(conf.ExamplesPath() + "/elephants.jpg")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Nil(t, mediaFile.LiveStill())
+	})
+}
+
+func TestIsLivePair(t *testing.T) {
+	conf := config.TestConfig()
+
+	still, err := NewMediaFile(conf.ExamplesPath() + "/elephants.jpg")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	motion, err := NewMediaFile(conf.ExamplesPath() + "/christmas.mp4")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("nil", func(t *testing.T) {
+		assert.False(t, IsLivePair(nil, motion))
+		assert.False(t, IsLivePair(still, nil))
+	})
+	t.Run("swapped", func(t *testing.T) {
+		assert.False(t, IsLivePair(motion, still))
+	})
+	t.Run("long video", func(t *testing.T) {
+		assert.False(t, IsLivePair(still, motion))
+	})
+}
+
+func TestMediaFile_LiveStill_pair(t *testing.T) {
+	conf := config.TestConfig()
+
+	dir, err := ioutil.TempDir("", "live")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	// livePair copies example files to a still and a motion file sharing an exiftool json sidecar.
+	livePair := func(name, sidecar string) (still, motion *MediaFile) {
+		jpg, err := NewMediaFile(conf.ExamplesPath() + "/elephants.jpg")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		mp4, err := NewMediaFile(conf.ExamplesPath() + "/christmas.mp4")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if err := jpg.Copy(filepath.Join(dir, name+".jpg")); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := mp4.Copy(filepath.Join(dir, name+".mp4")); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := ioutil.WriteFile(filepath.Join(dir, name+".json"), []byte(sidecar), os.ModePerm); err != nil {
+			t.Fatal(err)
+		}
+
+		if still, err = NewMediaFile(filepath.Join(dir, name+".jpg")); err != nil {
+			t.Fatal(err)
+		}
+
+		if motion, err = NewMediaFile(filepath.Join(dir, name+".mp4")); err != nil {
+			t.Fatal(err)
+		}
+
+		return still, motion
+	}
+
+	t.Run("duration", func(t *testing.T) {
+		still, motion := livePair("IMG_0001", `[{"SourceFile": "IMG_0001.mp4", "Duration": "2.5 s"}]`)
+
+		assert.True(t, IsLivePair(still, motion))
+
+		if result := motion.LiveStill(); assert.NotNil(t, result) {
+			assert.Equal(t, still.FileName(), result.FileName())
+		}
+
+		related, err := still.RelatedFiles(false)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var names []string
+
+		for _, f := range related.Files {
+			names = append(names, f.FileName())
+		}
+
+		assert.Contains(t, names, still.FileName())
+		assert.Contains(t, names, motion.FileName())
+	})
+	t.Run("content id", func(t *testing.T) {
+		still, motion := livePair("IMG_0002", `[{"SourceFile": "IMG_0002.mp4", "ContentIdentifier": "0B4A6F8E-1C2D-4E5F-9A8B-7C6D5E4F3A2B"}]`)
+
+		assert.True(t, IsLivePair(still, motion))
+
+		if result := motion.LiveStill(); assert.NotNil(t, result) {
+			assert.Equal(t, still.FileName(), result.FileName())
+		}
+	})
+}
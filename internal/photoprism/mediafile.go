@@ -18,6 +18,7 @@ import (
 	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/meta"
 	"github.com/photoprism/photoprism/internal/thumb"
+	"github.com/photoprism/photoprism/internal/video"
 	"github.com/photoprism/photoprism/pkg/capture"
 	"github.com/photoprism/photoprism/pkg/fs"
 	"github.com/photoprism/photoprism/pkg/txt"
@@ -36,6 +37,8 @@ type MediaFile struct {
 	height       int
 	metaData     meta.Data
 	metaDataOnce sync.Once
+	integrity    error
+	checkOnce    sync.Once
 	location     *entity.Location
 }
 
@@ -318,6 +321,22 @@ func (m *MediaFile) RelatedFiles(stripSequence bool) (result RelatedFiles, err e
 		matches = append(matches, filename)
 	}
 
+	// Add Google Takeout sidecar file, as its name may be truncated.
+	if filename := TakeoutSidecar(m.FileName()); filename != "" {
+		exists := false
+
+		for _, match := range matches {
+			if match == filename {
+				exists = true
+				break
+			}
+		}
+
+		if !exists {
+			matches = append(matches, filename)
+		}
+	}
+
 	for _, filename := range matches {
 		resultFile, err := NewMediaFile(filename)
 
@@ -583,7 +602,12 @@ func (m *MediaFile) IsTiff() bool {
 	return m.HasFileType(fs.TypeTiff)
 }
 
-// IsImageOther returns true if this is a PNG, GIF, BMP or TIFF file.
+// IsWebP returns true if this is a WebP file.
+func (m *MediaFile) IsWebP() bool {
+	return m.HasFileType(fs.TypeWebP)
+}
+
+// IsImageOther returns true if this is a PNG, GIF, BMP, TIFF or WebP file.
 func (m *MediaFile) IsImageOther() bool {
 	switch m.FileType() {
 	case fs.TypeBitmap:
@@ -594,6 +618,8 @@ func (m *MediaFile) IsImageOther() bool {
 		return true
 	case fs.TypeTiff:
 		return true
+	case fs.TypeWebP:
+		return true
 	default:
 		return false
 	}
@@ -619,9 +645,15 @@ func (m *MediaFile) IsVideo() bool {
 	return m.MediaType() == fs.MediaVideo
 }
 
-// IsPlayableVideo returns true if this is a supported video file format.
+// IsPlayableVideo returns true if this is a video common browsers can play without transcoding.
 func (m *MediaFile) IsPlayableVideo() bool {
-	return m.MediaType() == fs.MediaVideo && m.HasFileType(fs.TypeMP4)
+	if !m.IsVideo() {
+		return false
+	}
+
+	data, _ := m.MetaData()
+
+	return video.Playable(m.FileType(), data.Codec)
 }
 
 // IsPhoto returns true if this file is a photo / image.
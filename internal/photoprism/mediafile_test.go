@@ -1033,6 +1033,31 @@ func TestMediaFile_IsVideo(t *testing.T) {
 	})
 }
 
+func TestMediaFile_IsPlayableVideo(t *testing.T) {
+	t.Run("/christmas.mp4", func(t *testing.T) {
+		conf := config.TestConfig()
+
+		mediaFile, err := NewMediaFile(conf.ExamplesPath() + "/christmas.mp4")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.True(t, mediaFile.IsPlayableVideo())
+	})
+	t.Run("/canon_eos_6d.dng", func(t *testing.T) {
+		conf := config.TestConfig()
+
+		mediaFile, err := NewMediaFile(conf.ExamplesPath() + "/canon_eos_6d.dng")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.False(t, mediaFile.IsPlayableVideo())
+	})
+}
+
 func TestMediaFile_HasJpeg(t *testing.T) {
 	t.Run("Random.docx", func(t *testing.T) {
 		conf := config.TestConfig()
@@ -1,6 +1,9 @@
 package photoprism
 
 import (
+	"bytes"
+	"errors"
+	"os/exec"
 	"path/filepath"
 
 	"github.com/photoprism/photoprism/internal/meta"
@@ -9,12 +12,26 @@ import (
 )
 
 // MetaData returns exif meta data of a media file.
+//
+// Values are merged with increasing priority: embedded Exif, embedded IPTC-IIM (JPEG only)
+// and finally the Exiftool JSON sidecar file. XMP sidecar files are applied when indexing
+// the sidecar itself and take precedence over embedded metadata.
 func (m *MediaFile) MetaData() (result meta.Data, err error) {
 	m.metaDataOnce.Do(func() {
 		err = m.metaData.Exif(m.FileName())
 
+		if m.IsJpeg() {
+			if iptcErr := m.metaData.IPTC(m.FileName()); iptcErr != nil {
+				log.Debugf("mediafile: %s", iptcErr.Error())
+			} else {
+				err = nil
+			}
+		}
+
 		if jsonFile := fs.TypeJson.FindSub(m.FileName(), fs.HiddenPath, false); jsonFile == "" {
 			log.Debugf("mediafile: no json sidecar file found for %s", txt.Quote(filepath.Base(m.FileName())))
+		} else if meta.IsTakeout(jsonFile) {
+			log.Debugf("mediafile: %s is a google takeout sidecar file", txt.Quote(filepath.Base(jsonFile)))
 		} else if jsonErr := m.metaData.JSON(jsonFile); jsonErr != nil {
 			log.Warn(jsonErr)
 		} else {
@@ -28,3 +45,39 @@ func (m *MediaFile) MetaData() (result meta.Data, err error) {
 
 	return m.metaData, err
 }
+
+// VideoMetaData returns meta data of a video file, including stream details like frame rate,
+// bitrate and color transfer as reported by ffprobe.
+func (m *MediaFile) VideoMetaData(ffprobeBin string) (result meta.Data, err error) {
+	result, err = m.MetaData()
+
+	if !m.IsVideo() || ffprobeBin == "" {
+		return result, err
+	}
+
+	cmd := exec.Command(ffprobeBin, "-v", "quiet", "-print_format", "json", "-show_format", "-show_streams", m.FileName())
+
+	// Fetch command output.
+	var out bytes.Buffer
+	var stderr bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &stderr
+
+	if runErr := cmd.Run(); runErr != nil {
+		if stderr.String() != "" {
+			runErr = errors.New(stderr.String())
+		}
+
+		log.Warnf("mediafile: %s in %s (ffprobe)", runErr, txt.Quote(filepath.Base(m.FileName())))
+
+		return result, err
+	}
+
+	if probeErr := m.metaData.FFprobe(out.Bytes()); probeErr != nil {
+		log.Warnf("mediafile: %s in %s", probeErr, txt.Quote(filepath.Base(m.FileName())))
+
+		return m.metaData, err
+	}
+
+	return m.metaData, nil
+}
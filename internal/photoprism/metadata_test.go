@@ -176,3 +176,41 @@ func TestMediaFile_Exif_HEIF(t *testing.T) {
 		t.Error(err)
 	}
 }
+
+func TestMediaFile_VideoMetaData(t *testing.T) {
+	conf := config.TestConfig()
+
+	t.Run("christmas.mp4", func(t *testing.T) {
+		mediaFile, err := NewMediaFile(conf.ExamplesPath() + "/christmas.mp4")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		info, err := mediaFile.VideoMetaData(conf.FFprobeBin())
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, meta.CodecAvc1, info.Codec)
+		assert.Less(t, float64(0), info.FrameRate)
+		assert.Less(t, int64(0), info.Bitrate)
+		assert.LessOrEqual(t, 1, info.Streams)
+	})
+	t.Run("elephants.jpg", func(t *testing.T) {
+		mediaFile, err := NewMediaFile(conf.ExamplesPath() + "/elephants.jpg")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		info, err := mediaFile.VideoMetaData(conf.FFprobeBin())
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, 0, info.Streams)
+	})
+}
@@ -0,0 +1,260 @@
+package photoprism
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// DefaultPathTemplate is used if no import path template was configured.
+const DefaultPathTemplate = "{year}/{month}/{canonical}"
+
+// PathTokens lists the tokens that can be used in path templates.
+var PathTokens = map[string]string{
+	"year":      "year the photo was taken, e.g. 2020",
+	"month":     "month the photo was taken, e.g. 07",
+	"day":       "day the photo was taken, e.g. 31",
+	"hour":      "hour the photo was taken, e.g. 18",
+	"minute":    "minute the photo was taken, e.g. 05",
+	"second":    "second the photo was taken, e.g. 59",
+	"canonical": "canonical name, e.g. 20200731_180559_1A2B3C4D",
+	"original":  "original file name without extension, e.g. IMG_1234",
+	"checksum":  "CRC32 checksum of the main file, e.g. 1A2B3C4D",
+	"camera":    "camera make and model, e.g. Canon EOS 6D",
+	"make":      "camera make, e.g. Canon",
+	"model":     "camera model, e.g. EOS 6D",
+	"lens":      "lens model, e.g. EF24-105mm f-4L IS USM",
+	"place":     "place label, e.g. Berlin, Germany",
+	"city":      "city, e.g. Berlin",
+	"state":     "state, e.g. Berlin",
+	"country":   "country name, e.g. Germany",
+}
+
+// placeTokens require the location of a photo to be resolved.
+var placeTokens = []string{"place", "city", "state", "country"}
+
+var pathTokenRegexp = regexp.MustCompile(`\{[^{}]*\}`)
+
+// pathValueReplacer replaces characters that are not allowed in file names.
+var pathValueReplacer = strings.NewReplacer(
+	"/", "-",
+	"\\", "-",
+	":", "-",
+	"*", "",
+	"?", "",
+	"\"", "",
+	"<", "",
+	">", "",
+	"|", "",
+	"\n", " ",
+	"\r", "",
+	"\t", " ",
+)
+
+// PathTemplate represents a relative destination path without file extension, for example
+// "{year}/{year}-{month}-{day} {place}/{camera}_{original}".
+type PathTemplate string
+
+// NewPathTemplate returns a validated path template, or the default template if empty.
+func NewPathTemplate(s string) (PathTemplate, error) {
+	if s = strings.TrimSpace(s); s == "" {
+		return DefaultPathTemplate, nil
+	}
+
+	t := PathTemplate(s)
+
+	if err := t.Validate(); err != nil {
+		return DefaultPathTemplate, err
+	}
+
+	return t, nil
+}
+
+// Validate returns an error if the template can not be used to create file names.
+func (t PathTemplate) Validate() error {
+	s := string(t)
+
+	if s == "" {
+		return errors.New("path template is empty")
+	}
+
+	if strings.HasPrefix(s, "/") || filepath.IsAbs(s) {
+		return errors.New("path template must be relative")
+	}
+
+	if strings.HasSuffix(s, "/") {
+		return errors.New("path template must end with a file name")
+	}
+
+	for _, token := range pathTokenRegexp.FindAllString(s, -1) {
+		if _, ok := PathTokens[token[1:len(token)-1]]; !ok {
+			return fmt.Errorf("unknown path template token %s", token)
+		}
+	}
+
+	literal := pathTokenRegexp.ReplaceAllString(s, "")
+
+	if strings.ContainsAny(literal, "{}") {
+		return errors.New("path template contains unbalanced braces")
+	}
+
+	if strings.ContainsAny(literal, "\\:*?\"<>|") {
+		return errors.New("path template contains invalid characters")
+	}
+
+	for _, segment := range strings.Split(s, "/") {
+		switch strings.TrimSpace(segment) {
+		case "":
+			return errors.New("path template contains empty folder names")
+		case ".", "..":
+			return errors.New("path template must not contain relative folder names")
+		}
+	}
+
+	return nil
+}
+
+// UsesPlace tests if the template contains tokens that require the location of a photo.
+func (t PathTemplate) UsesPlace() bool {
+	for _, token := range placeTokens {
+		if strings.Contains(string(t), "{"+token+"}") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Render returns the relative file name without extension for the given values.
+// Empty folder and file names are replaced by "unknown".
+func (t PathTemplate) Render(v PathValues) string {
+	segments := strings.Split(string(t), "/")
+
+	for i, segment := range segments {
+		segment = pathTokenRegexp.ReplaceAllStringFunc(segment, func(token string) string {
+			return pathValueReplacer.Replace(v.Token(token[1 : len(token)-1]))
+		})
+
+		segment = strings.TrimLeft(strings.TrimSpace(segment), ".-_ ")
+		segment = strings.TrimRight(segment, "-_ ")
+
+		if segment == "" {
+			segment = "unknown"
+		}
+
+		segments[i] = segment
+	}
+
+	return strings.Join(segments, "/")
+}
+
+// PathValues contains the metadata used to fill path template tokens.
+type PathValues struct {
+	TakenAt     time.Time
+	Checksum    string
+	Original    string
+	CameraMake  string
+	CameraModel string
+	LensModel   string
+	Place       string
+	City        string
+	State       string
+	Country     string
+}
+
+// SetPlace sets the place related values.
+func (v *PathValues) SetPlace(place *entity.Place) {
+	if place == nil || place.Unknown() {
+		return
+	}
+
+	v.Place = place.Label()
+	v.City = place.City()
+	v.State = place.State()
+	v.Country = place.CountryName()
+}
+
+// Camera returns the normalized camera make and model.
+func (v PathValues) Camera() string {
+	if v.CameraModel == "" {
+		return ""
+	}
+
+	return entity.NewCamera(v.CameraModel, v.CameraMake).String()
+}
+
+// Token returns the value of a path template token.
+func (v PathValues) Token(name string) string {
+	switch name {
+	case "year":
+		return v.TakenAt.Format("2006")
+	case "month":
+		return v.TakenAt.Format("01")
+	case "day":
+		return v.TakenAt.Format("02")
+	case "hour":
+		return v.TakenAt.Format("15")
+	case "minute":
+		return v.TakenAt.Format("04")
+	case "second":
+		return v.TakenAt.Format("05")
+	case "canonical":
+		return CanonicalName(v.TakenAt, v.Checksum)
+	case "original":
+		return v.Original
+	case "checksum":
+		return strings.ToUpper(v.Checksum)
+	case "camera":
+		return v.Camera()
+	case "make":
+		return entity.NormalizeCameraMake(v.CameraMake)
+	case "model":
+		return entity.NormalizeCameraModel(v.CameraModel, v.CameraMake)
+	case "lens":
+		return v.LensModel
+	case "place":
+		return v.Place
+	case "city":
+		return v.City
+	case "state":
+		return v.State
+	case "country":
+		return v.Country
+	default:
+		log.Warnf("path template: unknown token %s", txt.Quote(name))
+		return ""
+	}
+}
+
+// PathValues returns the values used to fill path template tokens. The location is only
+// resolved if a geocoding api is given.
+func (m *MediaFile) PathValues(geoApi string) PathValues {
+	result := PathValues{
+		TakenAt:     m.DateCreated(),
+		Checksum:    m.Checksum(),
+		Original:    m.Base(false),
+		CameraMake:  m.CameraMake(),
+		CameraModel: m.CameraModel(),
+		LensModel:   m.LensModel(),
+	}
+
+	if geoApi == "" {
+		return result
+	}
+
+	if location, err := m.Location(); err != nil {
+		log.Debugf("path template: %s", err)
+	} else if err := location.Find(geoApi); err != nil {
+		log.Warnf("path template: %s", err)
+	} else {
+		result.SetPlace(location.Place)
+	}
+
+	return result
+}
@@ -0,0 +1,104 @@
+package photoprism
+
+import (
+	"testing"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewPathTemplate(t *testing.T) {
+	t.Run("empty", func(t *testing.T) {
+		tpl, err := NewPathTemplate(" ")
+		assert.Nil(t, err)
+		assert.Equal(t, PathTemplate(DefaultPathTemplate), tpl)
+	})
+	t.Run("valid", func(t *testing.T) {
+		tpl, err := NewPathTemplate("{year}/{year}-{month}-{day} {place}/{camera}_{original}")
+		assert.Nil(t, err)
+		assert.Equal(t, PathTemplate("{year}/{year}-{month}-{day} {place}/{camera}_{original}"), tpl)
+	})
+	t.Run("invalid", func(t *testing.T) {
+		tpl, err := NewPathTemplate("{year}/{foo}")
+		assert.EqualError(t, err, "unknown path template token {foo}")
+		assert.Equal(t, PathTemplate(DefaultPathTemplate), tpl)
+	})
+}
+
+func TestPathTemplate_Validate(t *testing.T) {
+	t.Run("default", func(t *testing.T) {
+		assert.Nil(t, PathTemplate(DefaultPathTemplate).Validate())
+	})
+	t.Run("empty", func(t *testing.T) {
+		assert.EqualError(t, PathTemplate("").Validate(), "path template is empty")
+	})
+	t.Run("absolute", func(t *testing.T) {
+		assert.EqualError(t, PathTemplate("/{year}/{canonical}").Validate(), "path template must be relative")
+	})
+	t.Run("folder", func(t *testing.T) {
+		assert.EqualError(t, PathTemplate("{year}/").Validate(), "path template must end with a file name")
+	})
+	t.Run("unbalanced", func(t *testing.T) {
+		assert.EqualError(t, PathTemplate("{year/{canonical}").Validate(), "path template contains unbalanced braces")
+		assert.EqualError(t, PathTemplate("{year}}/{canonical}").Validate(), "path template contains unbalanced braces")
+	})
+	t.Run("invalid characters", func(t *testing.T) {
+		assert.EqualError(t, PathTemplate("{year}/{month}:{canonical}").Validate(), "path template contains invalid characters")
+	})
+	t.Run("empty folder", func(t *testing.T) {
+		assert.EqualError(t, PathTemplate("{year}//{canonical}").Validate(), "path template contains empty folder names")
+	})
+	t.Run("relative folder", func(t *testing.T) {
+		assert.EqualError(t, PathTemplate("{year}/../{canonical}").Validate(), "path template must not contain relative folder names")
+	})
+}
+
+func TestPathTemplate_UsesPlace(t *testing.T) {
+	assert.False(t, PathTemplate(DefaultPathTemplate).UsesPlace())
+	assert.True(t, PathTemplate("{year}/{country}/{canonical}").UsesPlace())
+}
+
+func TestPathTemplate_Render(t *testing.T) {
+	values := PathValues{
+		TakenAt:     time.Date(2020, 7, 31, 18, 5, 59, 0, time.UTC),
+		Checksum:    "1a2b3c4d",
+		Original:    "IMG_1234",
+		CameraMake:  "Canon",
+		CameraModel: "EOS 6D",
+		LensModel:   "EF24-105mm f/4L IS USM",
+		Place:       "Berlin, Germany",
+		City:        "Berlin",
+		State:       "Berlin",
+		Country:     "Germany",
+	}
+
+	t.Run("default", func(t *testing.T) {
+		assert.Equal(t, "2020/07/20200731_180559_1A2B3C4D", PathTemplate(DefaultPathTemplate).Render(values))
+	})
+	t.Run("place and camera", func(t *testing.T) {
+		tpl := PathTemplate("{year}/{year}-{month}-{day} {place}/{camera}_{original}")
+		assert.Equal(t, "2020/2020-07-31 Berlin, Germany/Canon EOS 6D_IMG_1234", tpl.Render(values))
+	})
+	t.Run("invalid characters", func(t *testing.T) {
+		tpl := PathTemplate("{lens}/{hour}{minute}{second}_{checksum}")
+		assert.Equal(t, "EF24-105mm f-4L IS USM/180559_1A2B3C4D", tpl.Render(values))
+	})
+	t.Run("unknown values", func(t *testing.T) {
+		tpl := PathTemplate("{country}/{city}/{camera}_{original}")
+		assert.Equal(t, "unknown/unknown/IMG_1234", tpl.Render(PathValues{Original: "IMG_1234"}))
+	})
+}
+
+func TestPathValues_SetPlace(t *testing.T) {
+	t.Run("unknown", func(t *testing.T) {
+		values := PathValues{}
+		values.SetPlace(&entity.UnknownPlace)
+		assert.Equal(t, "", values.Place)
+	})
+	t.Run("nil", func(t *testing.T) {
+		values := PathValues{}
+		values.SetPlace(nil)
+		assert.Equal(t, "", values.Country)
+	})
+}
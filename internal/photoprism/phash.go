@@ -0,0 +1,22 @@
+package photoprism
+
+import (
+	"errors"
+
+	"github.com/photoprism/photoprism/pkg/phash"
+)
+
+// PerceptualHash returns the difference hash of an image to find near-duplicates (only JPEG supported).
+func (m *MediaFile) PerceptualHash(thumbPath string) (phash.Hash, error) {
+	if !m.IsJpeg() {
+		return 0, errors.New("no perceptual hash: not a JPEG file")
+	}
+
+	img, err := m.Resample(thumbPath, "fit_720")
+
+	if err != nil {
+		return 0, err
+	}
+
+	return phash.DHash(img), nil
+}
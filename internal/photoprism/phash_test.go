@@ -0,0 +1,72 @@
+package photoprism
+
+import (
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMediaFile_PerceptualHash(t *testing.T) {
+	conf := config.TestConfig()
+
+	t.Run("different images", func(t *testing.T) {
+		cat, err := NewMediaFile(conf.ExamplesPath() + "/cat_brown.jpg")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		fern, err := NewMediaFile(conf.ExamplesPath() + "/fern_green.jpg")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		a, err := cat.PerceptualHash(conf.ThumbPath())
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		b, err := fern.PerceptualHash(conf.ThumbPath())
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, a.Hex(), 16)
+		assert.Greater(t, a.Distance(b), 10)
+	})
+	t.Run("same image", func(t *testing.T) {
+		mediaFile, err := NewMediaFile(conf.ExamplesPath() + "/leaves_gold.jpg")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		a, err := mediaFile.PerceptualHash(conf.ThumbPath())
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		b, err := mediaFile.PerceptualHash(conf.ThumbPath())
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, a, b)
+	})
+	t.Run("Random.docx", func(t *testing.T) {
+		mediaFile, err := NewMediaFile(conf.ExamplesPath() + "/Random.docx")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = mediaFile.PerceptualHash(conf.ThumbPath())
+		assert.EqualError(t, err, "no perceptual hash: not a JPEG file")
+	})
+}
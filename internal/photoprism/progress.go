@@ -0,0 +1,351 @@
+package photoprism
+
+import (
+	"errors"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/karrick/godirwalk"
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/mutex"
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+// ProgressInterval is the minimum time between two progress events.
+var ProgressInterval = time.Second
+
+var progressRuns = make(map[string]*Progress)
+var progressMutex = sync.RWMutex{}
+
+// Progress tracks the number of processed files of an index, import or convert run.
+type Progress struct {
+	mutex      sync.RWMutex
+	name       string
+	total      int
+	totalBytes int64
+	estimated  bool
+	processed  int
+	bytes      int64
+	errors     int
+	directory  string
+	started    time.Time
+	published  time.Time
+	done       bool
+}
+
+// ProgressInfo represents a snapshot of the progress, e.g. to be sent to clients.
+type ProgressInfo struct {
+	Name           string  `json:"name"`
+	Total          int     `json:"total"`
+	TotalBytes     int64   `json:"totalBytes"`
+	Estimated      bool    `json:"estimated"`
+	Processed      int     `json:"processed"`
+	Bytes          int64   `json:"bytes"`
+	Errors         int     `json:"errors"`
+	Directory      string  `json:"directory"`
+	Percent        int     `json:"percent"`
+	FilesPerSecond float64 `json:"filesPerSecond"`
+	Elapsed        int     `json:"elapsed"`
+	Eta            int     `json:"eta"`
+	Done           bool    `json:"done"`
+}
+
+// NewProgress starts tracking a new run with an estimated total, or 0 if unknown,
+// and replaces the progress of a previous run with the same name.
+func NewProgress(name string, estimate int) *Progress {
+	p := &Progress{
+		name:      name,
+		total:     estimate,
+		estimated: true,
+		started:   time.Now(),
+	}
+
+	progressMutex.Lock()
+	progressRuns[name] = p
+	progressMutex.Unlock()
+
+	p.publish(true)
+
+	return p
+}
+
+// GetProgress returns the progress of the current or last run with the given name, or nil if there is none.
+func GetProgress(name string) *Progress {
+	progressMutex.RLock()
+	defer progressMutex.RUnlock()
+
+	return progressRuns[name]
+}
+
+// ProgressList returns a snapshot of all runs, sorted by name.
+func ProgressList() []ProgressInfo {
+	progressMutex.RLock()
+
+	result := make([]ProgressInfo, 0, len(progressRuns))
+
+	for _, p := range progressRuns {
+		result = append(result, p.Info())
+	}
+
+	progressMutex.RUnlock()
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+
+	return result
+}
+
+// SetTotal sets the exact number and size of files to be processed.
+func (p *Progress) SetTotal(files int, size int64) {
+	if p == nil {
+		return
+	}
+
+	p.mutex.Lock()
+	p.total = files
+	p.totalBytes = size
+	p.estimated = false
+
+	if p.processed > p.total {
+		p.total = p.processed
+	}
+
+	if p.bytes > p.totalBytes {
+		p.totalBytes = p.bytes
+	}
+	p.mutex.Unlock()
+
+	p.publish(false)
+}
+
+// Count walks the directory in the background and sets the total to the number and size of files
+// with a matching type. Files for which skip returns true are not counted.
+func (p *Progress) Count(dir string, match func(fileType fs.FileType) bool, skip func(fileName string) bool) {
+	if p == nil {
+		return
+	}
+
+	go func() {
+		files, size, err := countFiles(dir, match, skip, p.Done)
+
+		if err != nil {
+			log.Debugf("progress: %s", err)
+			return
+		}
+
+		p.SetTotal(files, size)
+	}()
+}
+
+// Processed adds the number and size of processed files and sets the current directory.
+func (p *Progress) Processed(files int, size int64, dir string) {
+	if p == nil {
+		return
+	}
+
+	p.mutex.Lock()
+	p.processed += files
+	p.bytes += size
+
+	if dir != "" {
+		p.directory = dir
+	}
+
+	// The total may be an estimate, or files may be added while running.
+	if p.processed > p.total {
+		p.total = p.processed
+	}
+
+	if p.bytes > p.totalBytes && !p.estimated {
+		p.totalBytes = p.bytes
+	}
+
+	p.mutex.Unlock()
+
+	p.publish(false)
+}
+
+// Error increments the error count.
+func (p *Progress) Error() {
+	if p == nil {
+		return
+	}
+
+	p.mutex.Lock()
+	p.errors++
+	p.mutex.Unlock()
+
+	p.publish(false)
+}
+
+// Finish marks the run as done and publishes the final progress.
+func (p *Progress) Finish() {
+	if p == nil {
+		return
+	}
+
+	p.mutex.Lock()
+	p.done = true
+	p.mutex.Unlock()
+
+	p.publish(true)
+}
+
+// Done tests if the run is done.
+func (p *Progress) Done() bool {
+	if p == nil {
+		return true
+	}
+
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+
+	return p.done
+}
+
+// Info returns a snapshot of the current progress including rate and estimated time remaining.
+func (p *Progress) Info() ProgressInfo {
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+
+	elapsed := time.Since(p.started)
+
+	result := ProgressInfo{
+		Name:       p.name,
+		Total:      p.total,
+		TotalBytes: p.totalBytes,
+		Estimated:  p.estimated,
+		Processed:  p.processed,
+		Bytes:      p.bytes,
+		Errors:     p.errors,
+		Directory:  p.directory,
+		Elapsed:    int(elapsed.Seconds()),
+		Eta:        -1,
+		Done:       p.done,
+	}
+
+	if p.done {
+		result.Percent = 100
+		result.Eta = 0
+	} else if p.total > 0 {
+		result.Percent = int(math.Min(float64(p.processed*100/p.total), 99))
+	}
+
+	if seconds := elapsed.Seconds(); seconds > 0 {
+		result.FilesPerSecond = math.Round(float64(p.processed)/seconds*10) / 10
+	}
+
+	if !p.done && p.processed > 0 && p.total > 0 {
+		result.Eta = int(float64(p.total-p.processed) * elapsed.Seconds() / float64(p.processed))
+	}
+
+	return result
+}
+
+// Data returns the progress as event data.
+func (i ProgressInfo) Data() event.Data {
+	return event.Data{
+		"name":           i.Name,
+		"total":          i.Total,
+		"totalBytes":     i.TotalBytes,
+		"estimated":      i.Estimated,
+		"processed":      i.Processed,
+		"bytes":          i.Bytes,
+		"errors":         i.Errors,
+		"directory":      i.Directory,
+		"percent":        i.Percent,
+		"filesPerSecond": i.FilesPerSecond,
+		"elapsed":        i.Elapsed,
+		"eta":            i.Eta,
+		"done":           i.Done,
+	}
+}
+
+// publish sends a progress event, at most once per interval unless forced.
+func (p *Progress) publish(force bool) {
+	p.mutex.Lock()
+
+	if !force && time.Since(p.published) < ProgressInterval {
+		p.mutex.Unlock()
+		return
+	}
+
+	p.published = time.Now()
+	p.mutex.Unlock()
+
+	event.Publish("progress."+p.name, p.Info().Data())
+}
+
+// countFiles returns the number and size of files with a matching type in a directory.
+func countFiles(dir string, match func(fileType fs.FileType) bool, skip func(fileName string) bool, canceled func() bool) (files int, size int64, err error) {
+	done := make(map[string]bool)
+	ignore := fs.NewIgnoreList(fs.IgnoreFile, true, false)
+
+	if err := ignore.Dir(dir); err != nil {
+		log.Debugf("progress: %s", err)
+	}
+
+	err = godirwalk.Walk(dir, &godirwalk.Options{
+		Callback: func(fileName string, info *godirwalk.Dirent) error {
+			if canceled() || mutex.Worker.Canceled() {
+				return errors.New("counting canceled")
+			}
+
+			isDir := info.IsDir()
+
+			if skip != nil && skip(fileName) {
+				if isDir {
+					return filepath.SkipDir
+				}
+
+				return nil
+			}
+
+			if skip, result := fs.SkipWalk(fileName, isDir, info.IsSymlink(), done, ignore); skip {
+				return result
+			}
+
+			if !match(fs.GetFileType(fileName)) {
+				return nil
+			}
+
+			if s, err := os.Stat(fileName); err == nil {
+				size += s.Size()
+			}
+
+			files++
+
+			return nil
+		},
+		Unsorted:            true,
+		FollowSymbolicLinks: true,
+	})
+
+	return files, size, err
+}
+
+// IsMediaType tests if files of the given type are indexed as media files.
+func IsMediaType(fileType fs.FileType) bool {
+	switch fileType {
+	case fs.TypeJpeg, fs.TypeRaw, fs.TypeHEIF, fs.TypeMov, fs.TypeMP4, fs.TypeAvi, fs.TypeMkv,
+		fs.TypePng, fs.TypeGif, fs.TypeTiff, fs.TypeBitmap, fs.TypeWebP:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsConvertType tests if files of the given type are converted to JPEG.
+func IsConvertType(fileType fs.FileType) bool {
+	switch fileType {
+	case fs.TypeRaw, fs.TypeHEIF, fs.TypePng, fs.TypeGif, fs.TypeTiff, fs.TypeBitmap, fs.TypeWebP:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,120 @@
+package photoprism
+
+import (
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewProgress(t *testing.T) {
+	p := NewProgress("test-new", 100)
+
+	assert.Equal(t, p, GetProgress("test-new"))
+	assert.Nil(t, GetProgress("test-missing"))
+
+	info := p.Info()
+
+	assert.Equal(t, "test-new", info.Name)
+	assert.Equal(t, 100, info.Total)
+	assert.True(t, info.Estimated)
+	assert.Equal(t, 0, info.Percent)
+	assert.Equal(t, -1, info.Eta)
+	assert.False(t, info.Done)
+}
+
+func TestProgress_Processed(t *testing.T) {
+	t.Run("estimate", func(t *testing.T) {
+		p := NewProgress("test-processed", 4)
+		p.Processed(1, 1000, "2020")
+		p.Processed(1, 1000, "2021")
+		p.Error()
+
+		info := p.Info()
+
+		assert.Equal(t, 2, info.Processed)
+		assert.Equal(t, int64(2000), info.Bytes)
+		assert.Equal(t, 50, info.Percent)
+		assert.Equal(t, 1, info.Errors)
+		assert.Equal(t, "2021", info.Directory)
+		assert.GreaterOrEqual(t, info.Eta, 0)
+	})
+	t.Run("exceeds total", func(t *testing.T) {
+		p := NewProgress("test-exceeds", 1)
+		p.SetTotal(1, 100)
+		p.Processed(2, 300, "")
+
+		info := p.Info()
+
+		assert.Equal(t, 2, info.Total)
+		assert.Equal(t, int64(300), info.TotalBytes)
+		assert.False(t, info.Estimated)
+		assert.Equal(t, 99, info.Percent)
+	})
+	t.Run("nil", func(t *testing.T) {
+		var p *Progress
+
+		p.Processed(1, 100, "2020")
+		p.Error()
+		p.Finish()
+
+		assert.True(t, p.Done())
+	})
+}
+
+func TestProgress_Finish(t *testing.T) {
+	p := NewProgress("test-finish", 10)
+	p.Processed(3, 0, "")
+	p.Finish()
+
+	info := p.Info()
+
+	assert.True(t, info.Done)
+	assert.Equal(t, 100, info.Percent)
+	assert.Equal(t, 0, info.Eta)
+	assert.Equal(t, 3, info.Data()["processed"])
+}
+
+func TestProgressList(t *testing.T) {
+	NewProgress("test-list-b", 0)
+	NewProgress("test-list-a", 0)
+
+	var names []string
+
+	for _, info := range ProgressList() {
+		names = append(names, info.Name)
+	}
+
+	assert.Contains(t, names, "test-list-a")
+	assert.Contains(t, names, "test-list-b")
+}
+
+func TestCountFiles(t *testing.T) {
+	conf := config.TestConfig()
+
+	files, size, err := countFiles(conf.ExamplesPath(), IsMediaType, nil, func() bool { return false })
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Greater(t, files, 1)
+	assert.Greater(t, size, int64(0))
+
+	raw, _, err := countFiles(conf.ExamplesPath(), func(t fs.FileType) bool { return t == fs.TypeRaw }, nil, func() bool { return false })
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Less(t, raw, files)
+}
+
+func TestIsMediaType(t *testing.T) {
+	assert.True(t, IsMediaType(fs.TypeJpeg))
+	assert.True(t, IsMediaType(fs.TypeMP4))
+	assert.False(t, IsMediaType(fs.TypeXMP))
+	assert.False(t, IsConvertType(fs.TypeJpeg))
+	assert.True(t, IsConvertType(fs.TypeRaw))
+}
@@ -0,0 +1,282 @@
+package photoprism
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"image/jpeg"
+	"io"
+	"io/ioutil"
+	"os"
+
+	"github.com/disintegration/imaging"
+	"github.com/photoprism/photoprism/internal/thumb"
+)
+
+// PreviewMinSize is the minimum width or height of an embedded preview to be used as JPEG sidecar.
+var PreviewMinSize = 1024
+
+// RawPreview represents a JPEG image embedded in a RAW file.
+type RawPreview struct {
+	Offset int64
+	Length int64
+	Width  int
+	Height int
+}
+
+// Tiff tags used to find embedded previews.
+const (
+	tagCompression      = 0x0103
+	tagStripOffsets     = 0x0111
+	tagOrientation      = 0x0112
+	tagStripByteCounts  = 0x0117
+	tagSubIFDs          = 0x014a
+	tagJpegOffset       = 0x0201
+	tagJpegLength       = 0x0202
+	rawPreviewMaxIfds   = 64
+	rawPreviewMaxFields = 1024
+	rawPreviewMaxLength = 128 * 1024 * 1024
+)
+
+// tiffTypeSizes contains the size in bytes of TIFF field types.
+var tiffTypeSizes = map[uint16]int64{1: 1, 2: 1, 3: 2, 4: 4, 5: 8, 6: 1, 7: 1, 8: 2, 9: 4, 10: 8, 11: 4, 12: 8, 13: 4}
+
+// RawPreviews returns the JPEG previews embedded in a TIFF-based RAW file like CR2, NEF, ARW or DNG,
+// as well as the Exif orientation of the RAW image.
+func RawPreviews(fileName string) (previews []RawPreview, orientation int, err error) {
+	f, err := os.Open(fileName)
+
+	if err != nil {
+		return previews, 0, err
+	}
+
+	defer f.Close()
+
+	header := make([]byte, 8)
+
+	if _, err := io.ReadFull(f, header); err != nil {
+		return previews, 0, err
+	}
+
+	var order binary.ByteOrder
+
+	switch string(header[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return previews, 0, errors.New("not a tiff-based raw file")
+	}
+
+	// Olympus and Panasonic use non-standard magic numbers.
+	switch order.Uint16(header[2:4]) {
+	case 42, 0x4f52, 0x5352, 0x55:
+	default:
+		return previews, 0, errors.New("not a tiff-based raw file")
+	}
+
+	queue := []int64{int64(order.Uint32(header[4:8]))}
+	visited := make(map[int64]bool)
+	first := true
+
+	for len(queue) > 0 && len(visited) < rawPreviewMaxIfds {
+		offset := queue[0]
+		queue = queue[1:]
+
+		if offset <= 0 || visited[offset] {
+			continue
+		}
+
+		visited[offset] = true
+
+		fields, next, err := readIfd(f, order, offset)
+
+		if err != nil {
+			if first {
+				return previews, 0, err
+			}
+
+			continue
+		}
+
+		if first {
+			if v, ok := fields[tagOrientation]; ok && len(v) > 0 {
+				orientation = int(v[0])
+			}
+
+			first = false
+		}
+
+		queue = append(queue, next)
+		queue = append(queue, fields[tagSubIFDs]...)
+
+		if offsets, lengths := fields[tagJpegOffset], fields[tagJpegLength]; len(offsets) == 1 && len(lengths) == 1 {
+			if p, ok := rawPreview(f, offsets[0], lengths[0]); ok {
+				previews = append(previews, p)
+			}
+		}
+
+		// JPEG compressed strips, lossless JPEG raw data is skipped as it can't be decoded.
+		if c := fields[tagCompression]; len(c) == 1 && (c[0] == 6 || c[0] == 7) {
+			if offsets, lengths := fields[tagStripOffsets], fields[tagStripByteCounts]; len(offsets) == 1 && len(lengths) == 1 {
+				if p, ok := rawPreview(f, offsets[0], lengths[0]); ok {
+					previews = append(previews, p)
+				}
+			}
+		}
+	}
+
+	return previews, orientation, nil
+}
+
+// readIfd returns the numeric values of an image file directory by tag and the offset of the next directory.
+func readIfd(r io.ReaderAt, order binary.ByteOrder, offset int64) (fields map[uint16][]int64, next int64, err error) {
+	buf := make([]byte, 2)
+
+	if _, err := r.ReadAt(buf, offset); err != nil {
+		return nil, 0, err
+	}
+
+	count := int64(order.Uint16(buf))
+
+	if count == 0 || count > rawPreviewMaxFields {
+		return nil, 0, fmt.Errorf("invalid number of fields at offset %d", offset)
+	}
+
+	data := make([]byte, count*12+4)
+
+	if _, err := r.ReadAt(data, offset+2); err != nil {
+		return nil, 0, err
+	}
+
+	fields = make(map[uint16][]int64)
+
+	for i := int64(0); i < count; i++ {
+		entry := data[i*12 : i*12+12]
+		tag := order.Uint16(entry[0:2])
+		fieldType := order.Uint16(entry[2:4])
+		n := int64(order.Uint32(entry[4:8]))
+
+		switch tag {
+		case tagCompression, tagStripOffsets, tagOrientation, tagStripByteCounts, tagSubIFDs, tagJpegOffset, tagJpegLength:
+		default:
+			continue
+		}
+
+		// Only SHORT, LONG and IFD values are used.
+		if (fieldType != 3 && fieldType != 4 && fieldType != 13) || n == 0 || n > rawPreviewMaxIfds {
+			continue
+		}
+
+		size := tiffTypeSizes[fieldType]
+		values := entry[8:12]
+
+		if size*n > 4 {
+			values = make([]byte, size*n)
+
+			if _, err := r.ReadAt(values, int64(order.Uint32(entry[8:12]))); err != nil {
+				continue
+			}
+		}
+
+		for j := int64(0); j < n; j++ {
+			if size == 2 {
+				fields[tag] = append(fields[tag], int64(order.Uint16(values[j*2:j*2+2])))
+			} else {
+				fields[tag] = append(fields[tag], int64(order.Uint32(values[j*4:j*4+4])))
+			}
+		}
+	}
+
+	return fields, int64(order.Uint32(data[count*12:])), nil
+}
+
+// rawPreview returns the preview at the given offset if it is a JPEG supported by the Go decoder.
+func rawPreview(r io.ReaderAt, offset, length int64) (result RawPreview, ok bool) {
+	if offset <= 0 || length <= 2 {
+		return result, false
+	}
+
+	cfg, err := jpeg.DecodeConfig(io.NewSectionReader(r, offset, length))
+
+	if err != nil {
+		return result, false
+	}
+
+	return RawPreview{Offset: offset, Length: length, Width: cfg.Width, Height: cfg.Height}, true
+}
+
+// ExtractRawPreview returns the largest JPEG preview embedded in a RAW file and the Exif orientation of the RAW.
+func ExtractRawPreview(fileName string) (data []byte, orientation int, err error) {
+	previews, orientation, err := RawPreviews(fileName)
+
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var largest RawPreview
+
+	for _, p := range previews {
+		if p.Width*p.Height > largest.Width*largest.Height {
+			largest = p
+		}
+	}
+
+	if largest.Width < PreviewMinSize && largest.Height < PreviewMinSize {
+		return nil, orientation, fmt.Errorf("no preview with at least %d pixels found", PreviewMinSize)
+	}
+
+	f, err := os.Open(fileName)
+
+	if err != nil {
+		return nil, orientation, err
+	}
+
+	defer f.Close()
+
+	info, err := f.Stat()
+
+	if err != nil {
+		return nil, orientation, err
+	}
+
+	// Don't trust offsets and lengths found in the file before allocating memory.
+	if largest.Offset < 0 || largest.Length <= 0 || largest.Length > rawPreviewMaxLength || largest.Offset+largest.Length > info.Size() {
+		return nil, orientation, fmt.Errorf("invalid preview at offset %d with length %d", largest.Offset, largest.Length)
+	}
+
+	data = make([]byte, largest.Length)
+
+	if _, err := f.ReadAt(data, largest.Offset); err != nil {
+		return nil, orientation, err
+	}
+
+	return data, orientation, nil
+}
+
+// RawPreview saves the largest JPEG preview embedded in a RAW file as JPEG sidecar.
+func (c *Convert) RawPreview(mf *MediaFile, jpegName string) error {
+	data, orientation, err := ExtractRawPreview(mf.FileName())
+
+	if err != nil {
+		return err
+	}
+
+	if orientation == 0 {
+		orientation = mf.Orientation()
+	}
+
+	if orientation <= 1 {
+		return ioutil.WriteFile(jpegName, data, 0644)
+	}
+
+	img, err := imaging.Decode(bytes.NewReader(data))
+
+	if err != nil {
+		return err
+	}
+
+	return imaging.Save(thumb.Rotate(img, orientation), jpegName, imaging.JPEGQuality(c.conf.JpegQuality()))
+}
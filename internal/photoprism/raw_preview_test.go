@@ -0,0 +1,138 @@
+package photoprism
+
+import (
+	"bytes"
+	"image/jpeg"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRawPreviews(t *testing.T) {
+	conf := config.TestConfig()
+
+	t.Run("canon_eos_6d.dng", func(t *testing.T) {
+		previews, orientation, err := RawPreviews(conf.ExamplesPath() + "/canon_eos_6d.dng")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, 1, orientation)
+		assert.Len(t, previews, 2)
+		assert.Equal(t, 256, previews[0].Width)
+		assert.Equal(t, 1024, previews[1].Width)
+		assert.Equal(t, 683, previews[1].Height)
+	})
+	t.Run("not a raw file", func(t *testing.T) {
+		_, _, err := RawPreviews(conf.ExamplesPath() + "/cat_black.jpg")
+
+		assert.Error(t, err)
+	})
+}
+
+func TestExtractRawPreview(t *testing.T) {
+	conf := config.TestConfig()
+
+	t.Run("canon_eos_6d.dng", func(t *testing.T) {
+		data, orientation, err := ExtractRawPreview(conf.ExamplesPath() + "/canon_eos_6d.dng")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, 1, orientation)
+
+		cfg, err := jpeg.DecodeConfig(bytes.NewReader(data))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, 1024, cfg.Width)
+		assert.Equal(t, 683, cfg.Height)
+	})
+	t.Run("too small", func(t *testing.T) {
+		minSize := PreviewMinSize
+		PreviewMinSize = 2048
+
+		defer func() { PreviewMinSize = minSize }()
+
+		_, _, err := ExtractRawPreview(conf.ExamplesPath() + "/canon_eos_6d.dng")
+
+		assert.Error(t, err)
+	})
+	t.Run("truncated", func(t *testing.T) {
+		fileName := conf.ExamplesPath() + "/canon_eos_6d.dng"
+		truncated := filepath.Join(conf.TempPath(), "truncated_preview.dng")
+
+		previews, _, err := RawPreviews(fileName)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		data, err := ioutil.ReadFile(fileName)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		largest := previews[len(previews)-1]
+
+		if err := os.MkdirAll(conf.TempPath(), os.ModePerm); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := ioutil.WriteFile(truncated, data[:largest.Offset+largest.Length-1], 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		defer os.Remove(truncated)
+
+		_, _, err = ExtractRawPreview(truncated)
+
+		assert.EqualError(t, err, "invalid preview at offset 145584 with length 57901")
+	})
+}
+
+func TestConvert_RawPreview(t *testing.T) {
+	conf := config.TestConfig()
+	convert := NewConvert(conf)
+	settings := conf.Settings()
+
+	settings.Convert = config.ConvertSettings{Order: map[string][]string{"raw": {"preview"}}}
+
+	defer func() {
+		settings.Convert = config.ConvertSettings{}
+	}()
+
+	mf, err := NewMediaFile(conf.ExamplesPath() + "/canon_eos_6d.dng")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, "preview", convert.Converters(mf)[0].Name)
+
+	jpegName := filepath.Join(conf.ImportPath(), "raw_preview_test.jpg")
+
+	defer os.Remove(jpegName)
+
+	if err := convert.RawPreview(mf, jpegName); err != nil {
+		t.Fatal(err)
+	}
+
+	jpegFile, err := NewMediaFile(jpegName)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.True(t, jpegFile.IsJpeg())
+	assert.Equal(t, 1024, jpegFile.Width())
+}
@@ -1,5 +1,11 @@
 package photoprism
 
+import (
+	"os"
+
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
 // List of related files for importing and indexing.
 type RelatedFiles struct {
 	Files MediaFiles
@@ -20,3 +26,20 @@ func (rf RelatedFiles) ContainsJpeg() bool {
 
 	return false
 }
+
+// MediaCount returns the number and size of related media files, e.g. to report progress.
+func (rf RelatedFiles) MediaCount() (count int, size int64) {
+	for _, f := range rf.Files {
+		if !IsMediaType(fs.GetFileType(f.FileName())) {
+			continue
+		}
+
+		count++
+
+		if s, err := os.Stat(f.FileName()); err == nil {
+			size += s.Size()
+		}
+	}
+
+	return count, size
+}
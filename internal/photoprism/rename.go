@@ -0,0 +1,337 @@
+package photoprism
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/mutex"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// Rename represents a worker that moves indexed files to the paths generated by a path template.
+type Rename struct {
+	conf *config.Config
+}
+
+// NewRename returns a new rename worker.
+func NewRename(conf *config.Config) *Rename {
+	instance := &Rename{
+		conf: conf,
+	}
+
+	return instance
+}
+
+// originalsPath returns the original media files path as string.
+func (w *Rename) originalsPath() string {
+	return w.conf.OriginalsPath()
+}
+
+// Start moves the files of the selected photos to their template paths and returns the UIDs of renamed photos.
+func (w *Rename) Start(opt RenameOptions) (renamed []string, err error) {
+	defer func() {
+		if err := recover(); err != nil {
+			log.Errorf("rename: %s [panic]", err)
+		}
+	}()
+
+	if w.conf.ReadOnly() && !opt.DryRun {
+		return renamed, errors.New("rename: not allowed in read-only mode")
+	}
+
+	if opt.Template == "" {
+		opt.Template = w.conf.Settings().Import.Template
+	}
+
+	tpl, err := NewPathTemplate(opt.Template)
+
+	if err != nil {
+		return renamed, fmt.Errorf("rename: %s", err.Error())
+	}
+
+	photos, err := query.PhotoSelection(opt.Selection)
+
+	if err != nil {
+		return renamed, fmt.Errorf("rename: %s", err.Error())
+	}
+
+	// Dry runs don't change anything, so they don't have to wait for other workers.
+	if !opt.DryRun {
+		if err := mutex.Worker.Start(); err != nil {
+			err = fmt.Errorf("rename: %s", err.Error())
+			event.Error(err.Error())
+			return renamed, err
+		}
+
+		defer mutex.Worker.Stop()
+	}
+
+	for _, p := range photos {
+		if !opt.DryRun && mutex.Worker.Canceled() {
+			return renamed, errors.New("rename canceled")
+		}
+
+		photo, err := query.PreloadPhotoByUID(p.PhotoUID)
+
+		if err != nil {
+			log.Errorf("rename: %s (%s)", err, p.PhotoUID)
+			continue
+		}
+
+		if ok, err := w.renamePhoto(photo, tpl, opt.DryRun); err != nil {
+			log.Errorf("rename: %s (%s)", err, photo.PhotoUID)
+		} else if ok {
+			renamed = append(renamed, photo.PhotoUID)
+		}
+	}
+
+	return renamed, nil
+}
+
+// renamePhoto moves the files of a photo and returns true if their names have changed.
+func (w *Rename) renamePhoto(photo entity.Photo, tpl PathTemplate, dryRun bool) (bool, error) {
+	primary, err := renamePrimary(photo.Files)
+
+	if err != nil {
+		return false, err
+	}
+
+	mf, err := NewMediaFile(filepath.Join(w.originalsPath(), primary.FileName))
+
+	if err != nil {
+		return false, err
+	}
+
+	values := PathValues{
+		TakenAt:  photo.TakenAt,
+		Checksum: mf.Checksum(),
+		Original: photo.PhotoName,
+	}
+
+	if primary.OriginalName != "" {
+		values.Original = fs.Base(primary.OriginalName, false)
+	}
+
+	if photo.Camera != nil && photo.Camera.CameraSlug != entity.UnknownCamera.CameraSlug {
+		values.CameraMake = photo.Camera.CameraMake
+		values.CameraModel = photo.Camera.CameraModel
+	}
+
+	if photo.Lens != nil && photo.Lens.LensSlug != entity.UnknownLens.LensSlug {
+		values.LensModel = photo.Lens.LensModel
+	}
+
+	if photo.Location != nil {
+		values.SetPlace(photo.Location.Place)
+	}
+
+	name, files := RenameTargets(photo, tpl.Render(values), func(fileName string) bool {
+		return fs.FileExists(filepath.Join(w.originalsPath(), fileName))
+	})
+
+	if len(files) == 0 {
+		log.Debugf("rename: photo %s already has the name %s", photo.PhotoUID, txt.Quote(name))
+		return false, nil
+	}
+
+	yamlFile := photo.YamlFileName(w.originalsPath(), w.conf.SidecarHidden())
+
+	var moved []entity.File
+
+	for _, f := range photo.Files {
+		dest, ok := files[f.FileName]
+
+		if !ok {
+			continue
+		}
+
+		if dryRun {
+			log.Infof("rename: would move %s to %s", txt.Quote(f.FileName), txt.Quote(dest))
+			continue
+		}
+
+		if err := w.renameFile(f, dest); err != nil {
+			w.rollback(moved, files)
+			return false, err
+		}
+
+		moved = append(moved, f)
+
+		log.Infof("rename: moved %s to %s", txt.Quote(f.FileName), txt.Quote(dest))
+	}
+
+	if dryRun {
+		return true, nil
+	}
+
+	photo.PhotoPath = filepath.Dir(name)
+	photo.PhotoName = filepath.Base(name)
+
+	if photo.PhotoPath == "." {
+		photo.PhotoPath = ""
+	}
+
+	if err := entity.UnscopedDb().Model(&photo).Updates(map[string]interface{}{
+		"photo_path": photo.PhotoPath,
+		"photo_name": photo.PhotoName,
+	}).Error; err != nil {
+		w.rollback(moved, files)
+		return false, err
+	}
+
+	if newYamlFile := photo.YamlFileName(w.originalsPath(), w.conf.SidecarHidden()); newYamlFile != yamlFile && fs.FileExists(yamlFile) {
+		if err := photo.SaveAsYaml(newYamlFile); err != nil {
+			log.Errorf("rename: %s (update yaml)", err)
+		} else if err := os.Remove(yamlFile); err != nil {
+			log.Errorf("rename: %s (remove yaml)", err)
+		}
+	}
+
+	return true, nil
+}
+
+// renameFile moves a file to its destination and updates its name in the index.
+func (w *Rename) renameFile(f entity.File, dest string) error {
+	fileName := filepath.Join(w.originalsPath(), f.FileName)
+	destName := filepath.Join(w.originalsPath(), dest)
+
+	if err := os.MkdirAll(filepath.Dir(destName), os.ModePerm); err != nil {
+		return err
+	}
+
+	if err := os.Rename(fileName, destName); err != nil {
+		return err
+	}
+
+	if err := f.Update("file_name", dest); err != nil {
+		if err := os.Rename(destName, fileName); err != nil {
+			log.Errorf("rename: %s (restore %s)", err, txt.Quote(f.FileName))
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+// rollback moves already renamed files of a photo back to their former names, so that it isn't left half renamed.
+func (w *Rename) rollback(moved []entity.File, files map[string]string) {
+	for i := len(moved) - 1; i >= 0; i-- {
+		f := moved[i]
+		dest := files[f.FileName]
+
+		if err := os.Rename(filepath.Join(w.originalsPath(), dest), filepath.Join(w.originalsPath(), f.FileName)); err != nil {
+			log.Errorf("rename: %s (restore %s)", err, txt.Quote(f.FileName))
+			continue
+		}
+
+		if err := f.Update("file_name", f.FileName); err != nil {
+			log.Errorf("rename: %s (restore %s)", err, txt.Quote(f.FileName))
+			continue
+		}
+
+		log.Infof("rename: moved %s back to %s", txt.Quote(dest), txt.Quote(f.FileName))
+	}
+}
+
+// renamePrimary returns the primary file of a photo, or the first file if there is none.
+func renamePrimary(files []entity.File) (result entity.File, err error) {
+	for _, f := range files {
+		if f.FileRoot != entity.RootOriginals || f.FileMissing {
+			continue
+		}
+
+		if f.FilePrimary {
+			return f, nil
+		} else if result.FileName == "" {
+			result = f
+		}
+	}
+
+	if result.FileName == "" {
+		return result, errors.New("no original files found")
+	}
+
+	return result, nil
+}
+
+// RenameTargets returns the relative name of a photo and its file names mapped to their new names.
+// Each file keeps its suffix after the photo name, e.g. ".jpg" or ".cr2.xmp", and files in the
+// hidden sidecar folder are moved to the sidecar folder of the new path. A sequence number
+// is appended to the name if a target file already exists. The map is empty if nothing would change.
+func RenameTargets(photo entity.Photo, name string, exists func(fileName string) bool) (string, map[string]string) {
+	current := make(map[string]bool)
+
+	for _, f := range photo.Files {
+		current[f.FileName] = true
+	}
+
+	for iteration := 0; ; iteration++ {
+		candidate := name
+
+		if iteration > 0 {
+			candidate = name + "." + fmt.Sprintf("%05d", iteration)
+		}
+
+		files := make(map[string]string)
+		collision := false
+		changed := false
+
+		for _, f := range photo.Files {
+			if f.FileRoot != entity.RootOriginals || f.FileMissing {
+				continue
+			}
+
+			base := filepath.Base(f.FileName)
+			suffix := filepath.Ext(base)
+
+			if photo.PhotoName != "" && strings.HasPrefix(base, photo.PhotoName) {
+				suffix = base[len(photo.PhotoName):]
+			}
+
+			dest := candidate + suffix
+
+			// Sidecar files, e.g. JPEGs converted from RAW or HEIC, stay in the hidden sidecar folder.
+			if filepath.Base(filepath.Dir(f.FileName)) == fs.HiddenPath {
+				dest = filepath.Join(filepath.Dir(candidate), fs.HiddenPath, filepath.Base(candidate)+suffix)
+			}
+
+			if dest == f.FileName {
+				files[f.FileName] = dest
+				continue
+			}
+
+			if current[dest] || exists(dest) {
+				collision = true
+				break
+			}
+
+			files[f.FileName] = dest
+			changed = true
+		}
+
+		if collision {
+			continue
+		}
+
+		if !changed {
+			return candidate, map[string]string{}
+		}
+
+		for fileName, dest := range files {
+			if fileName == dest {
+				delete(files, fileName)
+			}
+		}
+
+		return candidate, files
+	}
+}
@@ -0,0 +1,11 @@
+package photoprism
+
+import (
+	"github.com/photoprism/photoprism/internal/form"
+)
+
+type RenameOptions struct {
+	Selection form.Selection // Photos, albums or folders
+	Template  string         // Path template, the import template is used if empty
+	DryRun    bool           // Only logs the new file names
+}
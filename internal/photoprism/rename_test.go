@@ -0,0 +1,129 @@
+package photoprism
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenameTargets(t *testing.T) {
+	photo := entity.Photo{
+		PhotoPath: "2020/vacation",
+		PhotoName: "IMG_1234",
+		Files: []entity.File{
+			{FileName: "2020/vacation/IMG_1234.jpg", FileRoot: entity.RootOriginals, FilePrimary: true},
+			{FileName: "2020/vacation/IMG_1234.CR2", FileRoot: entity.RootOriginals},
+			{FileName: "2020/vacation/IMG_1234.CR2.xmp", FileRoot: entity.RootOriginals},
+			{FileName: "2020/vacation/IMG_1234.mov", FileRoot: entity.RootOriginals, FileMissing: true},
+		},
+	}
+
+	none := func(fileName string) bool { return false }
+
+	t.Run("rename", func(t *testing.T) {
+		name, files := RenameTargets(photo, "2020/07/Canon EOS 6D_IMG_1234", none)
+		assert.Equal(t, "2020/07/Canon EOS 6D_IMG_1234", name)
+		assert.Equal(t, map[string]string{
+			"2020/vacation/IMG_1234.jpg":     "2020/07/Canon EOS 6D_IMG_1234.jpg",
+			"2020/vacation/IMG_1234.CR2":     "2020/07/Canon EOS 6D_IMG_1234.CR2",
+			"2020/vacation/IMG_1234.CR2.xmp": "2020/07/Canon EOS 6D_IMG_1234.CR2.xmp",
+		}, files)
+	})
+	t.Run("unchanged", func(t *testing.T) {
+		name, files := RenameTargets(photo, "2020/vacation/IMG_1234", none)
+		assert.Equal(t, "2020/vacation/IMG_1234", name)
+		assert.Empty(t, files)
+	})
+	t.Run("collision", func(t *testing.T) {
+		exists := func(fileName string) bool {
+			return fileName == "2020/07/IMG_1234.CR2"
+		}
+
+		name, files := RenameTargets(photo, "2020/07/IMG_1234", exists)
+		assert.Equal(t, "2020/07/IMG_1234.00001", name)
+		assert.Equal(t, "2020/07/IMG_1234.00001.jpg", files["2020/vacation/IMG_1234.jpg"])
+		assert.Equal(t, "2020/07/IMG_1234.00001.CR2.xmp", files["2020/vacation/IMG_1234.CR2.xmp"])
+	})
+	t.Run("sidecar", func(t *testing.T) {
+		raw := entity.Photo{
+			PhotoPath: "2020/vacation",
+			PhotoName: "IMG_5678",
+			Files: []entity.File{
+				{FileName: "2020/vacation/IMG_5678.CR2", FileRoot: entity.RootOriginals},
+				{FileName: "2020/vacation/.photoprism/IMG_5678.jpg", FileRoot: entity.RootOriginals, FilePrimary: true},
+			},
+		}
+
+		exists := func(fileName string) bool {
+			return fileName == "2020/07/.photoprism/IMG_5678.jpg"
+		}
+
+		name, files := RenameTargets(raw, "2020/07/IMG_5678", exists)
+		assert.Equal(t, "2020/07/IMG_5678.00001", name)
+		assert.Equal(t, map[string]string{
+			"2020/vacation/IMG_5678.CR2":             "2020/07/IMG_5678.00001.CR2",
+			"2020/vacation/.photoprism/IMG_5678.jpg": "2020/07/.photoprism/IMG_5678.00001.jpg",
+		}, files)
+	})
+}
+
+func TestRename_rollback(t *testing.T) {
+	conf := config.TestConfig()
+	w := NewRename(conf)
+
+	dir := filepath.Join(conf.OriginalsPath(), "rename-rollback")
+
+	if err := os.MkdirAll(filepath.Join(dir, fs.HiddenPath), os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	files := []entity.File{
+		{FileName: "rename-rollback/IMG_9.CR2", FileRoot: entity.RootOriginals, FileHash: "rename-rollback-raw"},
+		{FileName: "rename-rollback/.photoprism/IMG_9.jpg", FileRoot: entity.RootOriginals, FileHash: "rename-rollback-jpg"},
+	}
+
+	targets := map[string]string{
+		"rename-rollback/IMG_9.CR2":             "rename-rollback/renamed/IMG_10.CR2",
+		"rename-rollback/.photoprism/IMG_9.jpg": "rename-rollback/renamed/.photoprism/IMG_10.jpg",
+	}
+
+	for i := range files {
+		if err := ioutil.WriteFile(filepath.Join(conf.OriginalsPath(), files[i].FileName), []byte("rollback"), os.ModePerm); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := entity.Db().Create(&files[i]).Error; err != nil {
+			t.Fatal(err)
+		}
+
+		if err := w.renameFile(files[i], targets[files[i].FileName]); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	assert.FileExists(t, filepath.Join(conf.OriginalsPath(), "rename-rollback/renamed/.photoprism/IMG_10.jpg"))
+
+	w.rollback(files, targets)
+
+	for _, f := range files {
+		assert.FileExists(t, filepath.Join(conf.OriginalsPath(), f.FileName))
+
+		result := entity.File{}
+
+		if err := entity.Db().Where("id = ?", f.ID).First(&result).Error; err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, f.FileName, result.FileName)
+
+		entity.Db().Unscoped().Delete(&result)
+	}
+}
@@ -36,7 +36,9 @@ func TestResample_Start(t *testing.T) {
 	imp := NewImport(conf, ind, convert)
 	opt := ImportOptionsMove(conf.ImportPath())
 
-	imp.Start(opt)
+	if _, err := imp.Start(opt); err != nil {
+		t.Fatal(err)
+	}
 
 	rs := NewResample(conf)
 
@@ -0,0 +1,137 @@
+package photoprism
+
+import (
+	"math"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/query"
+)
+
+// StackInterval is the maximum time between two consecutive photos of a burst or exposure bracket.
+var StackInterval = 2 * time.Second
+
+// DetectStacks groups photos that were taken in quick succession with the same camera, like bursts
+// and exposure brackets, in folders changed since the given time and returns the number of stacks changed.
+func DetectStacks(since time.Time) (count int, err error) {
+	paths, err := query.PhotoPathsSince(since)
+
+	if err != nil {
+		return 0, err
+	}
+
+	photos, err := query.StackCandidates(paths)
+
+	if err != nil {
+		return 0, err
+	}
+
+	for _, run := range stackRuns(photos, StackInterval) {
+		var stack *entity.Stack
+		var unstacked []entity.Photo
+
+		for _, photo := range run {
+			if photo.StackUID == "" {
+				unstacked = append(unstacked, photo)
+			} else if stack == nil {
+				stack = entity.FindStack(photo.StackUID)
+			}
+		}
+
+		if len(unstacked) == 0 {
+			continue
+		}
+
+		if stack == nil {
+			stack = entity.NewStack(stackType(run))
+
+			if err := stack.Create(); err != nil {
+				return count, err
+			}
+		}
+
+		if err := stack.AddPhotos(unstacked, stackCover(run)); err != nil {
+			return count, err
+		}
+
+		log.Infof("index: %d photos in %s stack %s", stack.PhotoCount, stack.StackType, stack.StackUID)
+
+		count++
+	}
+
+	return count, nil
+}
+
+// stackRuns returns runs of at least two photos taken with the same camera in quick succession,
+// expects photos sorted by camera and capture time.
+func stackRuns(photos []entity.Photo, interval time.Duration) (runs [][]entity.Photo) {
+	var run []entity.Photo
+
+	flush := func() {
+		if len(run) > 1 {
+			runs = append(runs, run)
+		}
+
+		run = nil
+	}
+
+	for _, photo := range photos {
+		if len(run) > 0 && !stackNext(run[len(run)-1], photo, interval) {
+			flush()
+		}
+
+		run = append(run, photo)
+	}
+
+	flush()
+
+	return runs
+}
+
+// stackNext tests if a photo directly follows the previous photo of a burst or bracket.
+func stackNext(prev, photo entity.Photo, interval time.Duration) bool {
+	if prev.CameraID != photo.CameraID || prev.CameraSerial != photo.CameraSerial {
+		return false
+	}
+
+	if photo.TakenAt.Sub(prev.TakenAt) > interval {
+		return false
+	}
+
+	// Sequence numbers are optional, but must be consecutive if present.
+	if prev.PhotoSequence > 0 && photo.PhotoSequence > 0 && photo.PhotoSequence != prev.PhotoSequence+1 {
+		return false
+	}
+
+	return true
+}
+
+// stackType returns StackBracket if the exposure bias varies, StackBurst otherwise.
+func stackType(run []entity.Photo) string {
+	for _, photo := range run {
+		if photo.PhotoBias != run[0].PhotoBias {
+			return entity.StackBracket
+		}
+	}
+
+	return entity.StackBurst
+}
+
+// stackCover returns the photo with the best quality, preferring the normal exposure of brackets.
+func stackCover(run []entity.Photo) (cover entity.Photo) {
+	for i, photo := range run {
+		if i == 0 {
+			cover = photo
+			continue
+		}
+
+		coverBias := math.Abs(float64(cover.PhotoBias))
+		photoBias := math.Abs(float64(photo.PhotoBias))
+
+		if photoBias < coverBias || photoBias == coverBias && photo.PhotoQuality > cover.PhotoQuality {
+			cover = photo
+		}
+	}
+
+	return cover
+}
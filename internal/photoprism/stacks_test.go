@@ -0,0 +1,83 @@
+package photoprism
+
+import (
+	"testing"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func stackPhoto(name string, camera uint, sec int, seq int, bias float32) entity.Photo {
+	return entity.Photo{
+		PhotoName:     name,
+		CameraID:      camera,
+		TakenAt:       time.Date(2020, 5, 1, 10, 0, sec, 0, time.UTC),
+		PhotoSequence: seq,
+		PhotoBias:     bias,
+		PhotoQuality:  3,
+	}
+}
+
+func TestStackRuns(t *testing.T) {
+	t.Run("burst", func(t *testing.T) {
+		photos := []entity.Photo{
+			stackPhoto("a", 2, 0, 0, 0),
+			stackPhoto("b", 2, 0, 0, 0),
+			stackPhoto("c", 2, 1, 0, 0),
+			stackPhoto("d", 2, 10, 0, 0),
+		}
+
+		runs := stackRuns(photos, 2*time.Second)
+
+		if len(runs) != 1 {
+			t.Fatalf("one run expected, found %d", len(runs))
+		}
+
+		assert.Len(t, runs[0], 3)
+		assert.Equal(t, "c", runs[0][2].PhotoName)
+	})
+	t.Run("different cameras", func(t *testing.T) {
+		photos := []entity.Photo{
+			stackPhoto("a", 2, 0, 0, 0),
+			stackPhoto("b", 3, 0, 0, 0),
+		}
+
+		assert.Empty(t, stackRuns(photos, 2*time.Second))
+	})
+	t.Run("sequence gap", func(t *testing.T) {
+		photos := []entity.Photo{
+			stackPhoto("a", 2, 0, 1, 0),
+			stackPhoto("b", 2, 0, 2, 0),
+			stackPhoto("c", 2, 0, 5, 0),
+			stackPhoto("d", 2, 0, 6, 0),
+		}
+
+		runs := stackRuns(photos, 2*time.Second)
+
+		assert.Len(t, runs, 2)
+	})
+}
+
+func TestStackType(t *testing.T) {
+	t.Run("burst", func(t *testing.T) {
+		run := []entity.Photo{stackPhoto("a", 2, 0, 0, 0), stackPhoto("b", 2, 0, 0, 0)}
+		assert.Equal(t, entity.StackBurst, stackType(run))
+	})
+	t.Run("bracket", func(t *testing.T) {
+		run := []entity.Photo{stackPhoto("a", 2, 0, 0, -2), stackPhoto("b", 2, 0, 0, 0), stackPhoto("c", 2, 0, 0, 2)}
+		assert.Equal(t, entity.StackBracket, stackType(run))
+	})
+}
+
+func TestStackCover(t *testing.T) {
+	t.Run("bracket", func(t *testing.T) {
+		run := []entity.Photo{stackPhoto("a", 2, 0, 0, -2), stackPhoto("b", 2, 0, 0, 0), stackPhoto("c", 2, 0, 0, 2)}
+		assert.Equal(t, "b", stackCover(run).PhotoName)
+	})
+	t.Run("burst", func(t *testing.T) {
+		run := []entity.Photo{stackPhoto("a", 2, 0, 0, 0), stackPhoto("b", 2, 0, 0, 0), stackPhoto("c", 2, 0, 0, 0)}
+		run[2].PhotoQuality = 5
+		assert.Equal(t, "c", stackCover(run).PhotoName)
+	})
+}
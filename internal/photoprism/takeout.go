@@ -0,0 +1,106 @@
+package photoprism
+
+import (
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/photoprism/photoprism/internal/meta"
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+// TakeoutNameLimit is the maximum length of Google Takeout sidecar file names including the extension.
+const TakeoutNameLimit = 51
+
+// TakeoutAlbumFile is the name of album metadata files in Google Takeout folders.
+const TakeoutAlbumFile = "metadata.json"
+
+var takeoutDuplicate = regexp.MustCompile(`^(.+)\((\d+)\)(\.[^.]+)$`)
+var takeoutDateFolder = regexp.MustCompile(`^Photos from \d{4}$`)
+var takeoutEdited = []string{"-edited", "-bearbeitet", "-modifié", "-editado", "-modificato"}
+
+// TakeoutSidecar returns the Google Takeout json sidecar file name for a media file,
+// or an empty string if there is none.
+func TakeoutSidecar(fileName string) string {
+	dir := filepath.Dir(fileName)
+
+	for _, name := range takeoutSidecarNames(filepath.Base(fileName)) {
+		jsonName := filepath.Join(dir, name)
+
+		if fs.FileExists(jsonName) && meta.IsTakeout(jsonName) {
+			return jsonName
+		}
+	}
+
+	return ""
+}
+
+// takeoutSidecarNames returns possible sidecar file names for a media file name.
+func takeoutSidecarNames(baseName string) (result []string) {
+	ext := filepath.Ext(baseName)
+	names := []string{baseName}
+	suffix := ""
+
+	// Duplicates like "IMG_1234(1).jpg" use "IMG_1234.jpg(1).json".
+	if m := takeoutDuplicate.FindStringSubmatch(baseName); m != nil {
+		names = []string{m[1] + m[3]}
+		suffix = "(" + m[2] + ")"
+	}
+
+	// Edited copies like "IMG_1234-edited.jpg" share the sidecar of the original.
+	for _, name := range names {
+		base := strings.TrimSuffix(name, ext)
+
+		for _, s := range takeoutEdited {
+			if strings.HasSuffix(strings.ToLower(base), s) {
+				names = append(names, base[:len(base)-len(s)]+ext)
+				break
+			}
+		}
+	}
+
+	for _, name := range names {
+		result = append(result, takeoutName(name, suffix+fs.JsonExt))
+		result = append(result, strings.TrimSuffix(name, ext)+suffix+fs.JsonExt)
+	}
+
+	return result
+}
+
+// takeoutName returns a file name truncated like Google Takeout does.
+func takeoutName(name, ext string) string {
+	runes := []rune(name)
+	limit := TakeoutNameLimit - len([]rune(ext))
+
+	if len(runes) > limit {
+		runes = runes[:limit]
+	}
+
+	return string(runes) + ext
+}
+
+// TakeoutAlbumTitle returns the album title for a folder in a Google Takeout export,
+// or an empty string if it is not an album folder.
+func TakeoutAlbumTitle(dir, importPath string) string {
+	if album, err := meta.ReadTakeoutAlbum(filepath.Join(dir, TakeoutAlbumFile)); err == nil {
+		return album.Title
+	}
+
+	if filepath.Clean(dir) == filepath.Clean(importPath) {
+		return ""
+	}
+
+	base := filepath.Base(dir)
+
+	// Folders that contain all photos of a year are not albums.
+	if takeoutDateFolder.MatchString(base) {
+		return ""
+	}
+
+	switch base {
+	case "Takeout", "Google Photos", "Google Fotos":
+		return ""
+	}
+
+	return base
+}
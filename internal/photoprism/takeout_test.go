@@ -0,0 +1,66 @@
+package photoprism
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTakeoutSidecarNames(t *testing.T) {
+	t.Run("IMG_1234.jpg", func(t *testing.T) {
+		assert.Equal(t, []string{"IMG_1234.jpg.json", "IMG_1234.json"}, takeoutSidecarNames("IMG_1234.jpg"))
+	})
+	t.Run("IMG_1234(1).jpg", func(t *testing.T) {
+		assert.Equal(t, []string{"IMG_1234.jpg(1).json", "IMG_1234(1).json"}, takeoutSidecarNames("IMG_1234(1).jpg"))
+	})
+	t.Run("IMG_1234-edited.jpg", func(t *testing.T) {
+		assert.Equal(t, []string{"IMG_1234-edited.jpg.json", "IMG_1234-edited.json", "IMG_1234.jpg.json", "IMG_1234.json"}, takeoutSidecarNames("IMG_1234-edited.jpg"))
+	})
+	t.Run("truncated", func(t *testing.T) {
+		names := takeoutSidecarNames("Screenshot_20190614-101527_Google_Photos_App.jpg")
+		assert.Equal(t, "Screenshot_20190614-101527_Google_Photos_App.j.json", names[0])
+		assert.Equal(t, 51, len(names[0]))
+	})
+}
+
+func TestTakeoutSidecar(t *testing.T) {
+	dir, err := ioutil.TempDir("", "takeout")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	albumDir := filepath.Join(dir, "Summer 2019")
+
+	if err := os.MkdirAll(albumDir, os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+
+	sidecar := []byte(`{"title": "IMG_1234.jpg", "photoTakenTime": {"timestamp": "1560500127"}}`)
+
+	if err := ioutil.WriteFile(filepath.Join(albumDir, "IMG_1234.jpg.json"), sidecar, os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := ioutil.WriteFile(filepath.Join(albumDir, "IMG_1235.json"), []byte(`{"SourceFile": "IMG_1235.jpg"}`), os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("found", func(t *testing.T) {
+		assert.Equal(t, filepath.Join(albumDir, "IMG_1234.jpg.json"), TakeoutSidecar(filepath.Join(albumDir, "IMG_1234.jpg")))
+		assert.Equal(t, filepath.Join(albumDir, "IMG_1234.jpg.json"), TakeoutSidecar(filepath.Join(albumDir, "IMG_1234-edited.jpg")))
+	})
+	t.Run("exiftool", func(t *testing.T) {
+		assert.Equal(t, "", TakeoutSidecar(filepath.Join(albumDir, "IMG_1235.jpg")))
+	})
+	t.Run("album", func(t *testing.T) {
+		assert.Equal(t, "Summer 2019", TakeoutAlbumTitle(albumDir, dir))
+		assert.Equal(t, "", TakeoutAlbumTitle(dir, dir))
+		assert.Equal(t, "", TakeoutAlbumTitle(filepath.Join(dir, "Photos from 2019"), dir))
+	})
+}
@@ -0,0 +1,94 @@
+package photoprism
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/mutex"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// Timeshift represents a worker that corrects the time photos were taken in batches.
+type Timeshift struct {
+	conf *config.Config
+}
+
+// NewTimeshift returns a new timeshift worker.
+func NewTimeshift(conf *config.Config) *Timeshift {
+	instance := &Timeshift{
+		conf: conf,
+	}
+
+	return instance
+}
+
+// Start applies a time offset and / or time zone to the selected photos and returns their UIDs.
+func (w *Timeshift) Start(opt TimeshiftOptions) (shifted []string, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("timeshift: %s [panic]", r)
+			log.Error(err.Error())
+		}
+	}()
+
+	if opt.Offset == 0 && opt.TimeZone == "" {
+		return shifted, errors.New("timeshift: no offset or time zone")
+	}
+
+	photos, err := query.PhotoSelection(opt.Selection)
+
+	if err != nil {
+		return shifted, fmt.Errorf("timeshift: %s", err.Error())
+	}
+
+	if err := mutex.Worker.Start(); err != nil {
+		err = fmt.Errorf("timeshift: %s", err.Error())
+		event.Error(err.Error())
+		return shifted, err
+	}
+
+	defer mutex.Worker.Stop()
+
+	for _, p := range photos {
+		if mutex.Worker.Canceled() {
+			return shifted, errors.New("timeshift canceled")
+		}
+
+		photo, err := query.PreloadPhotoByUID(p.PhotoUID)
+
+		if err != nil {
+			log.Errorf("timeshift: %s (%s)", err, p.PhotoUID)
+			continue
+		}
+
+		if err := photo.ShiftTakenAt(opt.Offset, opt.TimeZone); err != nil {
+			log.Warnf("timeshift: %s", err)
+			continue
+		}
+
+		if err := photo.Save(); err != nil {
+			log.Errorf("timeshift: %s (%s)", err, photo.PhotoUID)
+			continue
+		}
+
+		log.Infof("timeshift: photo %s taken at %s", photo.PhotoUID, photo.TakenAtLocal.Format("2006-01-02 15:04:05"))
+
+		if opt.Yaml {
+			yamlFile := photo.YamlFileName(w.conf.OriginalsPath(), w.conf.SidecarHidden())
+
+			if err := photo.SaveAsYaml(yamlFile); err != nil {
+				log.Errorf("timeshift: %s (update yaml)", err)
+			} else {
+				log.Infof("timeshift: updated yaml file %s", txt.Quote(fs.RelativeName(yamlFile, w.conf.OriginalsPath())))
+			}
+		}
+
+		shifted = append(shifted, photo.PhotoUID)
+	}
+
+	return shifted, nil
+}
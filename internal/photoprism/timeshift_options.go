@@ -0,0 +1,14 @@
+package photoprism
+
+import (
+	"time"
+
+	"github.com/photoprism/photoprism/internal/form"
+)
+
+type TimeshiftOptions struct {
+	Selection form.Selection // Photos, albums or folders
+	Offset    time.Duration  // Added to the local time, e.g. if the camera clock was set wrong
+	TimeZone  string         // Target time zone, keeps the local time
+	Yaml      bool           // Updates YAML sidecar files
+}
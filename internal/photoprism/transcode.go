@@ -0,0 +1,385 @@
+package photoprism
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/mutex"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/internal/video"
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// HlsPlaylist is the file name of the HLS master playlist.
+const HlsPlaylist = "index.m3u8"
+
+// HlsSegmentDuration is the target duration of HLS segments in seconds.
+const HlsSegmentDuration = 6
+
+// Transcode represents a worker that transcodes videos browsers can't play to H.264 MP4 files in the cache.
+type Transcode struct {
+	conf     *config.Config
+	cmdMutex sync.Mutex
+}
+
+// NewTranscode returns a new video transcoder and expects the config as argument.
+func NewTranscode(conf *config.Config) *Transcode {
+	return &Transcode{conf: conf}
+}
+
+// Start transcodes all indexed videos that browsers can't play and that are not cached yet, or only
+// the video with the given file hash if not empty. HLS renditions are created as well if enabled.
+func (t *Transcode) Start(fileHash string) error {
+	if t.conf.VideoTranscode() == "none" && !t.conf.VideoHls() {
+		return errors.New("transcode: disabled or ffmpeg not installed")
+	}
+
+	if err := mutex.Worker.Start(); err != nil {
+		return err
+	}
+
+	defer mutex.Worker.Stop()
+
+	var files query.Files
+	var size int64
+
+	limit := 1000
+	offset := 0
+
+	if fileHash != "" {
+		f, err := query.FileByHash(fileHash)
+
+		if err == nil && !f.FileVideo {
+			f, err = query.VideoByPhotoUID(f.PhotoUID)
+		}
+
+		if err != nil {
+			return fmt.Errorf("transcode: %s", err)
+		}
+
+		if t.needsAvc(f.FileType, f.FileCodec, f.FileHash) || t.needsHls(f.FileHash) {
+			files = append(files, f)
+			size += f.FileSize
+		}
+	} else {
+		for {
+			videos, err := query.Videos(limit, offset)
+
+			if err != nil {
+				return err
+			}
+
+			for _, f := range videos {
+				if t.needsAvc(f.FileType, f.FileCodec, f.FileHash) || t.needsHls(f.FileHash) {
+					files = append(files, f)
+					size += f.FileSize
+				}
+			}
+
+			if len(videos) < limit {
+				break
+			}
+
+			offset += limit
+		}
+	}
+
+	progress := NewProgress("transcode", len(files))
+	progress.SetTotal(len(files), size)
+
+	defer progress.Finish()
+
+	for _, f := range files {
+		if mutex.Worker.Canceled() {
+			return errors.New("transcode: canceled")
+		}
+
+		fileName := filepath.Join(t.conf.OriginalsPath(), f.FileName)
+
+		if t.needsAvc(f.FileType, f.FileCodec, f.FileHash) {
+			if _, err := t.ToAvc(fileName, f.FileHash); err != nil {
+				log.Error(err)
+				progress.Error()
+			}
+		}
+
+		if t.needsHls(f.FileHash) {
+			if _, err := t.ToHls(fileName, f.FileHash, f.FileWidth, f.FileHeight); err != nil {
+				log.Error(err)
+				progress.Error()
+			}
+		}
+
+		progress.Processed(1, f.FileSize, filepath.Dir(f.FileName))
+	}
+
+	return nil
+}
+
+// needsAvc tests if a video can't be played by browsers and hasn't been transcoded yet.
+func (t *Transcode) needsAvc(fileType, codec, fileHash string) bool {
+	if t.conf.VideoTranscode() == "none" || video.Playable(fs.FileType(fileType), codec) {
+		return false
+	}
+
+	avcName, err := t.AvcName(fileHash)
+
+	return err == nil && !fs.FileExists(avcName)
+}
+
+// needsHls tests if HLS is enabled and the renditions of a video haven't been created yet.
+func (t *Transcode) needsHls(fileHash string) bool {
+	if !t.conf.VideoHls() {
+		return false
+	}
+
+	hlsPath, err := t.HlsPath(fileHash)
+
+	return err == nil && !fs.FileExists(filepath.Join(hlsPath, HlsPlaylist))
+}
+
+// AvcName returns the cache file name of the H.264 MP4 version of a video.
+func (t *Transcode) AvcName(fileHash string) (string, error) {
+	if len(fileHash) < 4 {
+		return "", fmt.Errorf("transcode: file hash is empty or too short (%s)", txt.Quote(fileHash))
+	}
+
+	return filepath.Join(t.conf.VideoPath(), fileHash[0:1], fileHash[1:2], fileHash[2:3], fileHash+"_avc.mp4"), nil
+}
+
+// AvcArgs returns the ffmpeg arguments to transcode a video to H.264 MP4 within the configured bitrate and size limits.
+func (t *Transcode) AvcArgs(fileName, avcName string) []string {
+	size := t.conf.VideoSize()
+	bitrate := t.conf.VideoBitrate()
+
+	// Videos are only scaled down, with even dimensions as required by the encoder.
+	scale := fmt.Sprintf("scale='if(gte(iw,ih),trunc(min(%d,iw)/2)*2,-2)':'if(gte(iw,ih),-2,trunc(min(%d,ih)/2)*2)'", size, size)
+
+	return []string{
+		"-hide_banner", "-loglevel", "error",
+		"-i", fileName,
+		"-map", "0:v:0", "-map", "0:a:0?",
+		"-c:v", "libx264", "-preset", "fast", "-crf", "23",
+		"-maxrate", fmt.Sprintf("%dM", bitrate), "-bufsize", fmt.Sprintf("%dM", bitrate*2),
+		"-vf", scale, "-pix_fmt", "yuv420p",
+		"-c:a", "aac", "-b:a", "128k",
+		"-movflags", "+faststart",
+		"-f", "mp4", "-y", avcName,
+	}
+}
+
+// ToAvc returns the cache file name of the H.264 MP4 version of a video and transcodes it first if needed.
+func (t *Transcode) ToAvc(fileName, fileHash string) (string, error) {
+	avcName, err := t.AvcName(fileHash)
+
+	if err != nil {
+		return "", err
+	}
+
+	if fs.FileExists(avcName) {
+		return avcName, nil
+	}
+
+	ffmpegBin := t.conf.FFmpegBin()
+
+	if ffmpegBin == "" {
+		return "", errors.New("transcode: ffmpeg not installed")
+	}
+
+	if !fs.FileExists(fileName) {
+		return "", fmt.Errorf("transcode: %s not found", txt.Quote(filepath.Base(fileName)))
+	}
+
+	// Transcoding is cpu intensive, so only one video is transcoded at a time.
+	t.cmdMutex.Lock()
+	defer t.cmdMutex.Unlock()
+
+	// The video may have been transcoded while waiting.
+	if fs.FileExists(avcName) {
+		return avcName, nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(avcName), 0755); err != nil {
+		return "", err
+	}
+
+	start := time.Now()
+	tempName := avcName + ".tmp"
+
+	log.Infof("transcode: converting %s to avc", txt.Quote(filepath.Base(fileName)))
+
+	if err := ffmpeg(ffmpegBin, t.AvcArgs(fileName, tempName)); err != nil {
+		_ = os.Remove(tempName)
+
+		return "", fmt.Errorf("transcode: %s in %s", err, txt.Quote(filepath.Base(fileName)))
+	}
+
+	// Partial files must never be served, so the result is renamed when done.
+	if err := os.Rename(tempName, avcName); err != nil {
+		return "", err
+	}
+
+	log.Infof("transcode: created %s in %s", txt.Quote(filepath.Base(avcName)), time.Since(start))
+
+	return avcName, nil
+}
+
+// HlsPath returns the cache directory of the HLS renditions of a video.
+func (t *Transcode) HlsPath(fileHash string) (string, error) {
+	if len(fileHash) < 4 {
+		return "", fmt.Errorf("transcode: file hash is empty or too short (%s)", txt.Quote(fileHash))
+	}
+
+	return filepath.Join(t.conf.VideoPath(), "hls", fileHash[0:1], fileHash[1:2], fileHash[2:3], fileHash), nil
+}
+
+// hlsBitrate returns the bitrate limit of a rendition in kbit/s.
+func (t *Transcode) hlsBitrate(r video.Rendition) int {
+	if limit := t.conf.VideoBitrate() * 1000; r.Bitrate > limit {
+		return limit
+	}
+
+	return r.Bitrate
+}
+
+// HlsArgs returns the ffmpeg arguments to create a HLS rendition of a video in the given directory.
+func (t *Transcode) HlsArgs(fileName, dir string, r video.Rendition, width, height int) []string {
+	bitrate := t.hlsBitrate(r)
+	scale := fmt.Sprintf("scale=-2:%d", r.Size)
+
+	if width > 0 && height > 0 {
+		w, h := r.Dimensions(width, height)
+		scale = fmt.Sprintf("scale=%d:%d", w, h)
+	}
+
+	return []string{
+		"-hide_banner", "-loglevel", "error",
+		"-i", fileName,
+		"-map", "0:v:0", "-map", "0:a:0?",
+		"-c:v", "libx264", "-preset", "fast", "-crf", "23",
+		"-maxrate", fmt.Sprintf("%dk", bitrate), "-bufsize", fmt.Sprintf("%dk", bitrate*2),
+		"-vf", scale, "-pix_fmt", "yuv420p",
+		// Keyframes at fixed intervals, so that all renditions have the same segment boundaries.
+		"-force_key_frames", fmt.Sprintf("expr:gte(t,n_forced*%d)", HlsSegmentDuration),
+		"-c:a", "aac", "-b:a", "128k", "-ac", "2",
+		"-f", "hls", "-hls_time", strconv.Itoa(HlsSegmentDuration), "-hls_playlist_type", "vod",
+		"-hls_segment_filename", filepath.Join(dir, r.Name+"_%03d.ts"),
+		filepath.Join(dir, r.Name+".m3u8"),
+	}
+}
+
+// HlsMasterPlaylist returns the HLS master playlist that references the renditions of a video.
+func (t *Transcode) HlsMasterPlaylist(renditions []video.Rendition, width, height int) string {
+	var b strings.Builder
+
+	b.WriteString("#EXTM3U\n#EXT-X-VERSION:3\n")
+
+	for _, r := range renditions {
+		w, h := r.Dimensions(width, height)
+		bandwidth := (t.hlsBitrate(r) + 128) * 1000
+
+		fmt.Fprintf(&b, "#EXT-X-STREAM-INF:BANDWIDTH=%d,RESOLUTION=%dx%d\n%s.m3u8\n", bandwidth, w, h, r.Name)
+	}
+
+	return b.String()
+}
+
+// ToHls returns the cache directory of the HLS renditions of a video and creates them first if needed.
+func (t *Transcode) ToHls(fileName, fileHash string, width, height int) (string, error) {
+	hlsPath, err := t.HlsPath(fileHash)
+
+	if err != nil {
+		return "", err
+	}
+
+	if fs.FileExists(filepath.Join(hlsPath, HlsPlaylist)) {
+		return hlsPath, nil
+	}
+
+	ffmpegBin := t.conf.FFmpegBin()
+
+	if ffmpegBin == "" {
+		return "", errors.New("transcode: ffmpeg not installed")
+	}
+
+	if !fs.FileExists(fileName) {
+		return "", fmt.Errorf("transcode: %s not found", txt.Quote(filepath.Base(fileName)))
+	}
+
+	t.cmdMutex.Lock()
+	defer t.cmdMutex.Unlock()
+
+	if fs.FileExists(filepath.Join(hlsPath, HlsPlaylist)) {
+		return hlsPath, nil
+	}
+
+	start := time.Now()
+	tempPath := hlsPath + ".tmp"
+
+	// Remove leftovers of canceled runs.
+	_ = os.RemoveAll(tempPath)
+
+	if err := os.MkdirAll(tempPath, 0755); err != nil {
+		return "", err
+	}
+
+	renditions := video.RenditionsFor(width, height, t.conf.VideoSize())
+
+	log.Infof("transcode: creating %d hls renditions of %s", len(renditions), txt.Quote(filepath.Base(fileName)))
+
+	for _, r := range renditions {
+		if err := ffmpeg(ffmpegBin, t.HlsArgs(fileName, tempPath, r, width, height)); err != nil {
+			_ = os.RemoveAll(tempPath)
+
+			return "", fmt.Errorf("transcode: %s in %s (%s)", err, txt.Quote(filepath.Base(fileName)), r.Name)
+		}
+	}
+
+	playlist := t.HlsMasterPlaylist(renditions, width, height)
+
+	if err := ioutil.WriteFile(filepath.Join(tempPath, HlsPlaylist), []byte(playlist), 0644); err != nil {
+		_ = os.RemoveAll(tempPath)
+
+		return "", err
+	}
+
+	// Incomplete renditions must never be served, so the directory is renamed when done.
+	_ = os.RemoveAll(hlsPath)
+
+	if err := os.Rename(tempPath, hlsPath); err != nil {
+		return "", err
+	}
+
+	log.Infof("transcode: created hls renditions of %s in %s", txt.Quote(filepath.Base(fileName)), time.Since(start))
+
+	return hlsPath, nil
+}
+
+// ffmpeg runs ffmpeg with the given arguments and returns its error output if it fails.
+func ffmpeg(ffmpegBin string, args []string) error {
+	cmd := exec.Command(ffmpegBin, args...)
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return errors.New(msg)
+		}
+
+		return err
+	}
+
+	return nil
+}
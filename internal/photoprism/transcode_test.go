@@ -0,0 +1,128 @@
+package photoprism
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/video"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTranscode_AvcName(t *testing.T) {
+	conf := config.TestConfig()
+	transcode := NewTranscode(conf)
+
+	t.Run("valid hash", func(t *testing.T) {
+		avcName, err := transcode.AvcName("acad9168fa6acc5c5c2965ddf6ec465ca42fd831")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, conf.VideoPath()+"/a/c/a/acad9168fa6acc5c5c2965ddf6ec465ca42fd831_avc.mp4", avcName)
+	})
+	t.Run("empty hash", func(t *testing.T) {
+		avcName, err := transcode.AvcName("")
+
+		assert.Error(t, err)
+		assert.Equal(t, "", avcName)
+	})
+}
+
+func TestTranscode_AvcArgs(t *testing.T) {
+	conf := config.TestConfig()
+	transcode := NewTranscode(conf)
+
+	args := strings.Join(transcode.AvcArgs("input.mkv", "output.mp4"), " ")
+
+	assert.Contains(t, args, "-i input.mkv")
+	assert.Contains(t, args, "-c:v libx264")
+	assert.Contains(t, args, "-maxrate 8M -bufsize 16M")
+	assert.Contains(t, args, "min(720,iw)")
+	assert.True(t, strings.HasSuffix(args, "-f mp4 -y output.mp4"))
+}
+
+func TestTranscode_ToAvc(t *testing.T) {
+	conf := config.TestConfig()
+	transcode := NewTranscode(conf)
+
+	if conf.FFmpegBin() == "" {
+		t.Skip("ffmpeg not installed")
+	}
+
+	fileName := conf.ExamplesPath() + "/gopher-video.mp4"
+	fileHash := "0000transcodetest"
+
+	avcName, err := transcode.ToAvc(fileName, fileHash)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.Remove(avcName)
+
+	mf, err := NewMediaFile(avcName)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.True(t, mf.IsVideo())
+	assert.FileExists(t, avcName)
+
+	_, err = transcode.ToAvc(conf.ExamplesPath()+"/missing.mkv", "1111transcodetest")
+
+	assert.Error(t, err)
+}
+
+func TestTranscode_HlsPath(t *testing.T) {
+	conf := config.TestConfig()
+	transcode := NewTranscode(conf)
+
+	hlsPath, err := transcode.HlsPath("acad9168fa6acc5c5c2965ddf6ec465ca42fd831")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, conf.VideoPath()+"/hls/a/c/a/acad9168fa6acc5c5c2965ddf6ec465ca42fd831", hlsPath)
+
+	_, err = transcode.HlsPath("abc")
+
+	assert.Error(t, err)
+}
+
+func TestTranscode_HlsArgs(t *testing.T) {
+	conf := config.TestConfig()
+	transcode := NewTranscode(conf)
+
+	t.Run("landscape", func(t *testing.T) {
+		args := strings.Join(transcode.HlsArgs("input.mov", "/tmp/hls", video.Renditions[1], 3840, 2160), " ")
+
+		assert.Contains(t, args, "-i input.mov")
+		assert.Contains(t, args, "-vf scale=1280:720")
+		assert.Contains(t, args, "-maxrate 3000k -bufsize 6000k")
+		assert.Contains(t, args, "-hls_segment_filename /tmp/hls/720p_%03d.ts")
+		assert.True(t, strings.HasSuffix(args, "/tmp/hls/720p.m3u8"))
+	})
+	t.Run("unknown size", func(t *testing.T) {
+		args := strings.Join(transcode.HlsArgs("input.mov", "/tmp/hls", video.Renditions[0], 0, 0), " ")
+
+		assert.Contains(t, args, "-vf scale=-2:360")
+	})
+}
+
+func TestTranscode_HlsMasterPlaylist(t *testing.T) {
+	conf := config.TestConfig()
+	transcode := NewTranscode(conf)
+
+	playlist := transcode.HlsMasterPlaylist(video.Renditions[:2], 1920, 1080)
+
+	expected := "#EXTM3U\n#EXT-X-VERSION:3\n" +
+		"#EXT-X-STREAM-INF:BANDWIDTH=1128000,RESOLUTION=640x360\n360p.m3u8\n" +
+		"#EXT-X-STREAM-INF:BANDWIDTH=3128000,RESOLUTION=1280x720\n720p.m3u8\n"
+
+	assert.Equal(t, expected, playlist)
+}
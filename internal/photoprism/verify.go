@@ -0,0 +1,228 @@
+package photoprism
+
+import (
+	"crypto/sha1"
+	"encoding/csv"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/mutex"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+const (
+	verifyBatchSize = 100
+	verifyChunkSize = 1024 * 1024
+)
+
+const (
+	VerifyOK       = "ok"
+	VerifySkipped  = "skipped"
+	VerifyMismatch = "mismatch"
+)
+
+// VerifyResult contains the number of files and bytes verified in a run.
+type VerifyResult struct {
+	Files      int
+	Bytes      int64
+	Skipped    int
+	Mismatches int
+}
+
+// Verify represents a worker that compares originals with the hashes stored in the index to detect bit rot.
+type Verify struct {
+	conf *config.Config
+}
+
+// NewVerify returns a new verify worker.
+func NewVerify(conf *config.Config) *Verify {
+	instance := &Verify{
+		conf: conf,
+	}
+
+	return instance
+}
+
+// originalsPath returns the original media files path as string.
+func (w *Verify) originalsPath() string {
+	return w.conf.OriginalsPath()
+}
+
+// Start re-hashes originals that haven't been verified recently, least recently verified first,
+// until the budget is exhausted. At least one file is verified per run, even if it exceeds the budget.
+func (w *Verify) Start(opt VerifyOptions) (result VerifyResult, err error) {
+	defer func() {
+		if err := recover(); err != nil {
+			log.Errorf("verify: %s [panic]", err)
+		}
+	}()
+
+	if err := mutex.Verify.Start(); err != nil {
+		return result, fmt.Errorf("verify: %s", err.Error())
+	}
+
+	defer mutex.Verify.Stop()
+
+	verifiedBefore := time.Now().UTC().Add(-1 * opt.Age)
+
+	for {
+		// Skipped files keep their verification time, so they are excluded with an offset.
+		files, err := query.FilesToVerify(verifyBatchSize, result.Skipped, verifiedBefore)
+
+		if err != nil {
+			return result, fmt.Errorf("verify: %s", err.Error())
+		} else if len(files) == 0 {
+			break
+		}
+
+		for _, file := range files {
+			if mutex.Verify.Canceled() {
+				return result, errors.New("verify: canceled")
+			}
+
+			if opt.Budget > 0 && result.Bytes >= opt.Budget {
+				log.Debugf("verify: budget of %d bytes exhausted", opt.Budget)
+				return result, nil
+			}
+
+			status, size := w.verifyFile(file, opt.Rate)
+
+			switch status {
+			case VerifySkipped:
+				result.Skipped++
+				continue
+			case VerifyMismatch:
+				result.Mismatches++
+			}
+
+			result.Files++
+			result.Bytes += size
+		}
+	}
+
+	return result, nil
+}
+
+// verifyFile compares an original with its stored hash and returns the status and number of bytes read.
+func (w *Verify) verifyFile(file entity.File, rate int64) (status string, size int64) {
+	fileName := filepath.Join(w.originalsPath(), file.FileName)
+
+	info, err := os.Stat(fileName)
+
+	if err != nil {
+		log.Debugf("verify: %s not found, skipped", txt.Quote(file.FileName))
+		return VerifySkipped, 0
+	}
+
+	// Modified files are updated by the indexer, bit rot doesn't change the modification time.
+	if !info.ModTime().Round(time.Second).Equal(file.FileModified.Round(time.Second)) {
+		log.Infof("verify: %s was modified after indexing, skipped", txt.Quote(file.FileName))
+		return VerifySkipped, 0
+	}
+
+	hash, size, err := verifyHash(fileName, rate)
+
+	query.SetFileVerified(file.FileUID, time.Now().UTC())
+
+	if err != nil {
+		mismatch := entity.NewFileMismatch(file, hash, size)
+		mismatch.Error = txt.Clip(err.Error(), IntegrityErrorLength)
+		mismatch.FirstOrCreate()
+
+		event.Error(fmt.Sprintf("verify: could not read %s (%s)", txt.Quote(file.FileName), err))
+
+		return VerifyMismatch, size
+	}
+
+	if hash != file.FileHash {
+		entity.NewFileMismatch(file, hash, size).FirstOrCreate()
+
+		event.Error(fmt.Sprintf("verify: %s does not match its stored hash", txt.Quote(file.FileName)))
+
+		return VerifyMismatch, size
+	}
+
+	log.Debugf("verify: %s ok", txt.Quote(file.FileName))
+
+	return VerifyOK, size
+}
+
+// verifyHash returns the SHA1 hash and size of a file like fs.Hash, reading at most
+// rate bytes per second unless rate is 0.
+func verifyHash(fileName string, rate int64) (hash string, size int64, err error) {
+	file, err := os.Open(fileName)
+
+	if err != nil {
+		return "", 0, err
+	}
+
+	defer file.Close()
+
+	h := sha1.New()
+	buf := make([]byte, verifyChunkSize)
+	start := time.Now()
+
+	for {
+		n, err := file.Read(buf)
+
+		if n > 0 {
+			h.Write(buf[:n])
+			size += int64(n)
+
+			if rate > 0 {
+				expected := time.Duration(float64(size) / float64(rate) * float64(time.Second))
+
+				if wait := expected - time.Since(start); wait > 0 {
+					time.Sleep(wait)
+				}
+			}
+		}
+
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return "", size, err
+		}
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), size, nil
+}
+
+// WriteMismatchReport writes a list of file mismatches as CSV.
+func WriteMismatchReport(w io.Writer, mismatches entity.FileMismatches) error {
+	out := csv.NewWriter(w)
+
+	if err := out.Write([]string{"Detected", "UID", "Root", "Name", "Hash", "Size", "Actual Hash", "Actual Size", "Error"}); err != nil {
+		return err
+	}
+
+	for _, m := range mismatches {
+		if err := out.Write([]string{
+			m.DetectedAt.UTC().Format(time.RFC3339),
+			m.FileUID,
+			m.FileRoot,
+			m.FileName,
+			m.FileHash,
+			strconv.FormatInt(m.FileSize, 10),
+			m.ActualHash,
+			strconv.FormatInt(m.ActualSize, 10),
+			m.Error,
+		}); err != nil {
+			return err
+		}
+	}
+
+	out.Flush()
+
+	return out.Error()
+}
@@ -0,0 +1,24 @@
+package photoprism
+
+import (
+	"time"
+
+	"github.com/photoprism/photoprism/internal/config"
+)
+
+type VerifyOptions struct {
+	Budget int64         // Maximum number of bytes to read, 0 for unlimited
+	Rate   int64         // Maximum read rate in bytes per second, 0 for unlimited
+	Age    time.Duration // Files verified more recently are skipped
+}
+
+// VerifyOptionsConfig returns the verify options for scheduled verification.
+func VerifyOptionsConfig(conf *config.Config) VerifyOptions {
+	result := VerifyOptions{
+		Budget: conf.VerifyBudget(),
+		Rate:   conf.VerifyRate(),
+		Age:    conf.VerifyAge(),
+	}
+
+	return result
+}
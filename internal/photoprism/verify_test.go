@@ -0,0 +1,173 @@
+package photoprism
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVerify_Start(t *testing.T) {
+	conf := config.TestConfig()
+
+	w := NewVerify(conf)
+
+	result, err := w.Start(VerifyOptions{Budget: 1})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.LessOrEqual(t, result.Files, 1)
+}
+
+func TestVerify_verifyFile(t *testing.T) {
+	conf := config.TestConfig()
+
+	w := NewVerify(conf)
+
+	fileName := filepath.Join(conf.OriginalsPath(), "verify_test.jpg")
+
+	data, err := ioutil.ReadFile(conf.ExamplesPath() + "/cat_brown.jpg")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := ioutil.WriteFile(fileName, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.Remove(fileName)
+
+	info, err := os.Stat(fileName)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	file := entity.File{
+		FileUID:      "ft9es39w45bnverify",
+		FileName:     "verify_test.jpg",
+		FileRoot:     entity.RootOriginals,
+		FileHash:     fs.Hash(fileName),
+		FileSize:     info.Size(),
+		FileModified: info.ModTime(),
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		status, size := w.verifyFile(file, 0)
+
+		assert.Equal(t, VerifyOK, status)
+		assert.Equal(t, info.Size(), size)
+	})
+	t.Run("mismatch", func(t *testing.T) {
+		damaged := file
+		damaged.FileHash = "0cad9168fa6acc5c5c2965ddf6ec465ca42fd818"
+
+		status, size := w.verifyFile(damaged, 0)
+
+		assert.Equal(t, VerifyMismatch, status)
+		assert.Equal(t, info.Size(), size)
+	})
+	t.Run("modified", func(t *testing.T) {
+		modified := file
+		modified.FileHash = "0cad9168fa6acc5c5c2965ddf6ec465ca42fd818"
+		modified.FileModified = info.ModTime().Add(-1 * time.Hour)
+
+		status, size := w.verifyFile(modified, 0)
+
+		assert.Equal(t, VerifySkipped, status)
+		assert.Equal(t, int64(0), size)
+	})
+	t.Run("missing", func(t *testing.T) {
+		missing := file
+		missing.FileName = "verify_missing.jpg"
+
+		status, _ := w.verifyFile(missing, 0)
+
+		assert.Equal(t, VerifySkipped, status)
+	})
+}
+
+func TestVerifyHash(t *testing.T) {
+	conf := config.TestConfig()
+
+	t.Run("unlimited", func(t *testing.T) {
+		fileName := conf.ExamplesPath() + "/cat_brown.jpg"
+
+		hash, size, err := verifyHash(fileName, 0)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, fs.Hash(fileName), hash)
+		assert.Greater(t, size, int64(0))
+	})
+	t.Run("rate", func(t *testing.T) {
+		if err := os.MkdirAll(conf.TempPath(), os.ModePerm); err != nil {
+			t.Fatal(err)
+		}
+
+		fileName := filepath.Join(conf.TempPath(), "verify_rate.bin")
+
+		if err := ioutil.WriteFile(fileName, make([]byte, 256*1024), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		defer os.Remove(fileName)
+
+		start := time.Now()
+
+		hash, size, err := verifyHash(fileName, 1024*1024)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.GreaterOrEqual(t, int64(time.Since(start)), int64(200*time.Millisecond))
+		assert.Equal(t, fs.Hash(fileName), hash)
+		assert.Equal(t, int64(256*1024), size)
+	})
+	t.Run("not found", func(t *testing.T) {
+		_, _, err := verifyHash(filepath.Join(conf.TempPath(), "verify_missing.bin"), 0)
+
+		assert.Error(t, err)
+	})
+}
+
+func TestWriteMismatchReport(t *testing.T) {
+	var buf bytes.Buffer
+
+	mismatches := entity.FileMismatches{
+		{
+			FileUID:    "ft8es39w45bnlqdw",
+			FileRoot:   entity.RootOriginals,
+			FileName:   "2020/vacation/IMG_1234.jpg",
+			FileHash:   "2cad9168fa6acc5c5c2965ddf6ec465ca42fd818",
+			FileSize:   4278906,
+			ActualHash: "0cad9168fa6acc5c5c2965ddf6ec465ca42fd818",
+			ActualSize: 4278906,
+			DetectedAt: time.Date(2020, 7, 1, 12, 0, 0, 0, time.UTC),
+		},
+	}
+
+	if err := WriteMismatchReport(&buf, mismatches); err != nil {
+		t.Fatal(err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+
+	assert.Len(t, lines, 2)
+	assert.Equal(t, "Detected,UID,Root,Name,Hash,Size,Actual Hash,Actual Size,Error", lines[0])
+	assert.Equal(t, "2020-07-01T12:00:00Z,ft8es39w45bnlqdw,originals,2020/vacation/IMG_1234.jpg,2cad9168fa6acc5c5c2965ddf6ec465ca42fd818,4278906,0cad9168fa6acc5c5c2965ddf6ec465ca42fd818,4278906,", lines[1])
+}
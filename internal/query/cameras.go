@@ -0,0 +1,21 @@
+package query
+
+import (
+	"github.com/photoprism/photoprism/internal/entity"
+)
+
+// CameraBySlug returns a Camera based on the slug name.
+func CameraBySlug(cameraSlug string) (camera entity.Camera, err error) {
+	if err := Db().Where("camera_slug = ?", cameraSlug).First(&camera).Error; err != nil {
+		return camera, err
+	}
+
+	return camera, nil
+}
+
+// CamerasBySlug returns cameras based on a list of slug names.
+func CamerasBySlug(cameraSlugs []string) (cameras []entity.Camera, err error) {
+	err = Db().Where("camera_slug IN (?)", cameraSlugs).Find(&cameras).Error
+
+	return cameras, err
+}
@@ -0,0 +1,33 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCameraBySlug(t *testing.T) {
+	t.Run("found", func(t *testing.T) {
+		result, err := CameraBySlug("canon-eos-6d")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "EOS 6D", result.CameraModel)
+	})
+	t.Run("not found", func(t *testing.T) {
+		_, err := CameraBySlug("xxx")
+		assert.Error(t, err)
+	})
+}
+
+func TestCamerasBySlug(t *testing.T) {
+	results, err := CamerasBySlug([]string{"canon-eos-6d", "canon-eos-7d", "xxx"})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Len(t, results, 2)
+}
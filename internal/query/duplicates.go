@@ -0,0 +1,151 @@
+package query
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/pkg/capture"
+	"github.com/photoprism/photoprism/pkg/phash"
+)
+
+// DuplicateDistance is the default maximum number of differing perceptual hash bits of near-duplicates.
+const DuplicateDistance = 4
+
+// Duplicate represents the primary file of a photo that has near-duplicates.
+type Duplicate struct {
+	PhotoUID     string    `json:"PhotoUID"`
+	PhotoTitle   string    `json:"Title"`
+	PhotoQuality int       `json:"Quality"`
+	TakenAt      time.Time `json:"TakenAt"`
+	FileUID      string    `json:"FileUID"`
+	FileName     string    `json:"FileName"`
+	FileHash     string    `json:"Hash"`
+	FilePHash    string    `json:"PHash"`
+	FileWidth    int       `json:"Width"`
+	FileHeight   int       `json:"Height"`
+	FileSize     int64     `json:"Size"`
+	Distance     int       `gorm:"-" json:"Distance"`
+}
+
+// Better returns true if the duplicate is a better copy than the other one.
+func (d Duplicate) Better(other Duplicate) bool {
+	if d.PhotoQuality != other.PhotoQuality {
+		return d.PhotoQuality > other.PhotoQuality
+	}
+
+	if a, b := d.FileWidth*d.FileHeight, other.FileWidth*other.FileHeight; a != b {
+		return a > b
+	}
+
+	return d.FileSize > other.FileSize
+}
+
+// Duplicates represents a group of near-duplicates, the best copy first.
+type Duplicates []Duplicate
+
+// DuplicateGroups finds groups of photos whose primary files look the same.
+func DuplicateGroups(f form.DuplicateSearch) (results []Duplicates, err error) {
+	defer log.Debug(capture.Time(time.Now(), fmt.Sprintf("duplicates: search with distance %d", f.Distance)))
+
+	if results, err = duplicateGroups(f.Distance); err != nil {
+		return results, err
+	}
+
+	if f.Offset >= len(results) {
+		return nil, nil
+	}
+
+	results = results[f.Offset:]
+
+	if f.Count > 0 && f.Count < len(results) {
+		results = results[:f.Count]
+	}
+
+	return results, nil
+}
+
+// DuplicateGroup returns the group of near-duplicates a photo belongs to, if any.
+func DuplicateGroup(photoUID string, distance int) (Duplicates, error) {
+	groups, err := duplicateGroups(distance)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, group := range groups {
+		if group.Contains(photoUID) {
+			return group, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// Contains returns true if the group contains the photo with the given UID.
+func (d Duplicates) Contains(photoUID string) bool {
+	for _, dupe := range d {
+		if dupe.PhotoUID == photoUID {
+			return true
+		}
+	}
+
+	return false
+}
+
+// duplicateGroups groups all primary files with a perceptual hash by distance.
+func duplicateGroups(distance int) (results []Duplicates, err error) {
+	if distance <= 0 {
+		distance = DuplicateDistance
+	} else if distance > 16 {
+		distance = 16
+	}
+
+	var files []Duplicate
+
+	if err := Db().Table("files").
+		Select("photos.photo_uid, photos.photo_title, photos.photo_quality, photos.taken_at, " +
+			"files.file_uid, files.file_name, files.file_hash, files.file_p_hash, files.file_width, files.file_height, files.file_size").
+		Joins("JOIN photos ON photos.id = files.photo_id AND photos.deleted_at IS NULL").
+		Where("files.file_primary = 1 AND files.file_missing = 0 AND files.deleted_at IS NULL AND files.file_p_hash <> ''").
+		Order("photos.taken_at, photos.id").
+		Scan(&files).Error; err != nil {
+		return results, err
+	}
+
+	var valid []Duplicate
+	var hashes []phash.Hash
+
+	for _, file := range files {
+		if h, err := phash.FromHex(file.FilePHash); err != nil {
+			log.Warnf("duplicates: %s", err)
+		} else {
+			valid = append(valid, file)
+			hashes = append(hashes, h)
+		}
+	}
+
+	for _, group := range phash.Group(hashes, distance) {
+		dupes := make(Duplicates, len(group))
+
+		for i, n := range group {
+			dupes[i] = valid[n]
+		}
+
+		sort.SliceStable(dupes, func(i, j int) bool {
+			return dupes[i].Better(dupes[j])
+		})
+
+		best, _ := phash.FromHex(dupes[0].FilePHash)
+
+		for i := range dupes {
+			h, _ := phash.FromHex(dupes[i].FilePHash)
+			dupes[i].Distance = best.Distance(h)
+		}
+
+		results = append(results, dupes)
+	}
+
+	return results, nil
+}
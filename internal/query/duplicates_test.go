@@ -0,0 +1,82 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDuplicate_Better(t *testing.T) {
+	a := Duplicate{PhotoQuality: 3, FileWidth: 1200, FileHeight: 1600, FileSize: 1000}
+
+	t.Run("quality", func(t *testing.T) {
+		assert.True(t, a.Better(Duplicate{PhotoQuality: 2, FileWidth: 4000, FileHeight: 3000}))
+	})
+	t.Run("resolution", func(t *testing.T) {
+		assert.False(t, a.Better(Duplicate{PhotoQuality: 3, FileWidth: 4000, FileHeight: 3000}))
+	})
+	t.Run("size", func(t *testing.T) {
+		assert.True(t, a.Better(Duplicate{PhotoQuality: 3, FileWidth: 1200, FileHeight: 1600, FileSize: 500}))
+	})
+}
+
+func TestDuplicateGroups(t *testing.T) {
+	t.Run("default distance", func(t *testing.T) {
+		results, err := DuplicateGroups(form.DuplicateSearch{Count: 10})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(results) == 0 {
+			t.Fatal("at least one group expected")
+		}
+
+		for _, group := range results {
+			if group[0].PhotoUID != "pt9jtdre2lvl0yh0" {
+				continue
+			}
+
+			assert.Len(t, group, 2)
+			assert.Equal(t, 0, group[0].Distance)
+			assert.Equal(t, "pt9jtdre2lvl0yh9", group[1].PhotoUID)
+			assert.Equal(t, 1, group[1].Distance)
+			return
+		}
+
+		t.Fatal("group not found")
+	})
+	t.Run("offset", func(t *testing.T) {
+		results, err := DuplicateGroups(form.DuplicateSearch{Count: 10, Offset: 1000})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Empty(t, results)
+	})
+}
+
+func TestDuplicateGroup(t *testing.T) {
+	t.Run("found", func(t *testing.T) {
+		group, err := DuplicateGroup("pt9jtdre2lvl0yh9", 0)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.True(t, group.Contains("pt9jtdre2lvl0yh0"))
+		assert.True(t, group.Contains("pt9jtdre2lvl0yh9"))
+		assert.False(t, group.Contains("pt9jtdre2lvl0yh8"))
+	})
+	t.Run("not found", func(t *testing.T) {
+		group, err := DuplicateGroup("xxx", 0)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Empty(t, group)
+	})
+}
@@ -2,6 +2,7 @@ package query
 
 import (
 	"strings"
+	"time"
 
 	"github.com/photoprism/photoprism/internal/entity"
 )
@@ -93,9 +94,91 @@ func SetPhotoPrimary(photoUID, fileUID string) error {
 	return Db().Model(entity.File{}).Where("photo_uid = ? AND file_uid = ?", photoUID, fileUID).UpdateColumn("file_primary", true).Error
 }
 
+// FileErrors returns files with errors, including quarantined files, sorted by name.
+func FileErrors(limit int, offset int) (files Files, err error) {
+	err = Db().
+		Where("file_error <> '' OR file_quarantine = 1").
+		Order("file_name").
+		Limit(limit).Offset(offset).
+		Find(&files).Error
+
+	return files, err
+}
+
+// QuarantineFile flags a file as corrupt and stores the reason in the file error column.
+func QuarantineFile(fileUID, reason string) {
+	if err := Db().Model(entity.File{}).Where("file_uid = ?", fileUID).UpdateColumns(map[string]interface{}{
+		"file_quarantine": true,
+		"file_error":      reason,
+	}).Error; err != nil {
+		log.Errorf("query: %s", err.Error())
+	}
+}
+
 // SetFileError updates the file error column.
 func SetFileError(fileUID, errorString string) {
 	if err := Db().Model(entity.File{}).Where("file_uid = ?", fileUID).UpdateColumn("file_error", errorString).Error; err != nil {
 		log.Errorf("query: %s", err.Error())
 	}
 }
+
+// FilesToVerify returns indexed originals that have not been verified since the given time,
+// files that were never verified first.
+func FilesToVerify(limit int, offset int, verifiedBefore time.Time) (files Files, err error) {
+	err = Db().
+		Where("file_root = ? AND file_missing = 0 AND file_hash <> ''", entity.RootOriginals).
+		Where("file_verified IS NULL OR file_verified < ?", verifiedBefore).
+		Order("file_verified, id").
+		Limit(limit).Offset(offset).
+		Find(&files).Error
+
+	return files, err
+}
+
+// SetFileVerified updates the time a file was last verified.
+func SetFileVerified(fileUID string, verifiedAt time.Time) {
+	if err := Db().Model(entity.File{}).Where("file_uid = ?", fileUID).UpdateColumn("file_verified", verifiedAt).Error; err != nil {
+		log.Errorf("query: %s", err.Error())
+	}
+}
+
+// FileMismatches returns files whose content no longer matched the indexed hash, most recent first.
+func FileMismatches(limit int, offset int) (mismatches entity.FileMismatches, err error) {
+	err = Db().
+		Order("detected_at DESC, id DESC").
+		Limit(limit).Offset(offset).
+		Find(&mismatches).Error
+
+	return mismatches, err
+}
+
+// FileConversions returns the conversion attempts for a file name relative to the originals path,
+// or for all files if the name is empty, most recent first.
+func FileConversions(fileName string, failed bool, limit int, offset int) (conversions entity.FileConversions, err error) {
+	stmt := Db()
+
+	if fileName != "" {
+		stmt = stmt.Where("file_name = ?", fileName)
+	}
+
+	if failed {
+		stmt = stmt.Where("success = 0")
+	}
+
+	err = stmt.Order("id DESC").
+		Limit(limit).Offset(offset).
+		Find(&conversions).Error
+
+	return conversions, err
+}
+
+// Videos returns indexed video files without errors, e.g. to transcode them, sorted by id.
+func Videos(limit int, offset int) (files Files, err error) {
+	err = Db().
+		Where("file_video = 1 AND file_missing = 0 AND file_error = '' AND file_quarantine = 0 AND file_hash <> ''").
+		Order("id").
+		Limit(limit).Offset(offset).
+		Find(&files).Error
+
+	return files, err
+}
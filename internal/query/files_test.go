@@ -1,8 +1,11 @@
 package query
 
 import (
-	"github.com/photoprism/photoprism/internal/entity"
+	"errors"
 	"testing"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/entity"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -135,3 +138,132 @@ func TestSetPhotoPrimary(t *testing.T) {
 	//TODO How to assert
 	//assert.Equal(t, true, entity.FileFixturesExampleXMP.FilePrimary)
 }
+
+func TestFileErrors(t *testing.T) {
+	files, err := FileErrors(10, 0)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.GreaterOrEqual(t, len(files), 2)
+
+	for _, f := range files {
+		assert.True(t, f.FileError != "" || f.FileQuarantine)
+	}
+}
+
+func TestVideos(t *testing.T) {
+	files, err := Videos(10, 0)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.NotEmpty(t, files)
+
+	for _, f := range files {
+		assert.True(t, f.FileVideo)
+		assert.False(t, f.FileMissing)
+	}
+}
+
+func TestQuarantineFile(t *testing.T) {
+	QuarantineFile("ft72s39w45bnlqdw", "video: moov atom not found")
+
+	file, err := FileByUID("ft72s39w45bnlqdw")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.True(t, file.FileQuarantine)
+	assert.Equal(t, "video: moov atom not found", file.FileError)
+}
+
+func TestFilesToVerify(t *testing.T) {
+	files, err := FilesToVerify(10, 0, time.Now())
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.NotEmpty(t, files)
+
+	for _, f := range files {
+		assert.Equal(t, entity.RootOriginals, f.FileRoot)
+		assert.False(t, f.FileMissing)
+		assert.NotEmpty(t, f.FileHash)
+	}
+}
+
+func TestSetFileVerified(t *testing.T) {
+	verifiedAt := time.Date(2020, 7, 1, 12, 0, 0, 0, time.UTC)
+
+	SetFileVerified("ft8es39w45bnlqdw", verifiedAt)
+
+	file, err := FileByUID("ft8es39w45bnlqdw")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if assert.NotNil(t, file.FileVerified) {
+		assert.True(t, verifiedAt.Equal(*file.FileVerified))
+	}
+
+	files, err := FilesToVerify(1000, 0, verifiedAt)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, f := range files {
+		assert.NotEqual(t, "ft8es39w45bnlqdw", f.FileUID)
+	}
+}
+
+func TestFileMismatches(t *testing.T) {
+	entity.NewFileMismatch(entity.FileFixturesExampleJPG, "0000000000000000000000000000000000000000", 123).FirstOrCreate()
+
+	mismatches, err := FileMismatches(10, 0)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.NotEmpty(t, mismatches)
+}
+
+func TestFileConversions(t *testing.T) {
+	if err := entity.NewFileConversion("2020/conversions.cr2", "raw", "darktable", time.Second, "", errors.New("unsupported camera")).Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("file name", func(t *testing.T) {
+		conversions, err := FileConversions("2020/conversions.cr2", false, 10, 0)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(conversions) == 0 {
+			t.Fatal("conversions should not be empty")
+		}
+
+		assert.Equal(t, "darktable", conversions[0].Converter)
+	})
+	t.Run("failed", func(t *testing.T) {
+		conversions, err := FileConversions("", true, 10, 0)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.NotEmpty(t, conversions)
+
+		for _, c := range conversions {
+			assert.False(t, c.Success)
+		}
+	})
+}
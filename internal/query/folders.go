@@ -29,3 +29,10 @@ func FoldersByPath(root, rootPath, path string, recursive bool) (folders Folders
 
 	return folders, nil
 }
+
+// WatchedFolders returns originals folders that should be watched for changes.
+func WatchedFolders() (folders Folders, err error) {
+	err = Db().Where("root = ? AND folder_watch = 1", entity.RootOriginals).Order("path").Find(&folders).Error
+
+	return folders, err
+}
@@ -32,3 +32,24 @@ func TestFoldersByPath(t *testing.T) {
 		assert.Len(t, folders, 2)
 	})
 }
+
+func TestWatchedFolders(t *testing.T) {
+	folder := entity.NewFolder(entity.RootOriginals, "watched", nil)
+
+	if err := folder.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := folder.Updates(entity.Folder{FolderWatch: true}); err != nil {
+		t.Fatal(err)
+	}
+
+	folders, err := WatchedFolders()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Len(t, folders, 1)
+	assert.Equal(t, "watched", folders[0].Path)
+}
@@ -0,0 +1,53 @@
+package query
+
+import (
+	"time"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+)
+
+// Jobs returns queued, running and finished jobs, most recent first.
+func Jobs(f form.JobSearch) (jobs entity.Jobs, err error) {
+	s := Db()
+
+	if f.Type != "" {
+		s = s.Where("job_type = ?", f.Type)
+	}
+
+	if f.Status != "" {
+		s = s.Where("job_status = ?", f.Status)
+	}
+
+	s = s.Order("id DESC")
+
+	if f.Count > 0 && f.Count <= 1000 {
+		s = s.Limit(f.Count).Offset(f.Offset)
+	} else {
+		s = s.Limit(1000).Offset(0)
+	}
+
+	err = s.Find(&jobs).Error
+
+	return jobs, err
+}
+
+// NextJob returns the queued job that should run next, with the highest priority first.
+func NextJob() (job entity.Job, err error) {
+	err = Db().
+		Where("job_status = ? AND run_after <= ?", entity.JobQueued, time.Now().UTC()).
+		Order("job_priority DESC, id").
+		First(&job).Error
+
+	return job, err
+}
+
+// ActiveJobs returns queued and running jobs of the given type.
+func ActiveJobs(jobType string) (jobs entity.Jobs, err error) {
+	err = Db().
+		Where("job_type = ? AND job_status IN (?)", jobType, []string{entity.JobQueued, entity.JobRunning}).
+		Order("id").
+		Find(&jobs).Error
+
+	return jobs, err
+}
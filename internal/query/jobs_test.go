@@ -0,0 +1,83 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJobs(t *testing.T) {
+	t.Run("all", func(t *testing.T) {
+		jobs, err := Jobs(form.JobSearch{Count: 10})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.GreaterOrEqual(t, len(jobs), 2)
+	})
+	t.Run("status", func(t *testing.T) {
+		jobs, err := Jobs(form.JobSearch{Status: entity.JobDone, Count: 10})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.NotEmpty(t, jobs)
+
+		for _, job := range jobs {
+			assert.Equal(t, entity.JobDone, job.JobStatus)
+		}
+	})
+}
+
+func TestNextJob(t *testing.T) {
+	low, err := entity.NewJob(entity.JobResample, entity.JobPriorityLow, struct{ Force bool }{Force: false})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	high, err := entity.NewJob(entity.JobImport, entity.JobPriorityHigh, struct{ Path string }{Path: "next"})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := low.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := high.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	defer low.Cancel()
+	defer high.Cancel()
+
+	job, err := NextJob()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, high.JobUID, job.JobUID)
+	assert.NotEqual(t, "qqcwk7ew9ss5xjb1", job.JobUID)
+}
+
+func TestActiveJobs(t *testing.T) {
+	jobs, err := ActiveJobs(entity.JobPurge)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.NotEmpty(t, jobs)
+
+	for _, job := range jobs {
+		assert.Equal(t, entity.JobPurge, job.JobType)
+		assert.False(t, job.Finished())
+	}
+}
@@ -0,0 +1,21 @@
+package query
+
+import (
+	"github.com/photoprism/photoprism/internal/entity"
+)
+
+// LensBySlug returns a Lens based on the slug name.
+func LensBySlug(lensSlug string) (lens entity.Lens, err error) {
+	if err := Db().Where("lens_slug = ?", lensSlug).First(&lens).Error; err != nil {
+		return lens, err
+	}
+
+	return lens, nil
+}
+
+// LensesBySlug returns lenses based on a list of slug names.
+func LensesBySlug(lensSlugs []string) (lenses []entity.Lens, err error) {
+	err = Db().Where("lens_slug IN (?)", lensSlugs).Find(&lenses).Error
+
+	return lenses, err
+}
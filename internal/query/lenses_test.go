@@ -0,0 +1,33 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLensBySlug(t *testing.T) {
+	t.Run("found", func(t *testing.T) {
+		result, err := LensBySlug("lens-f-380")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "F380", result.LensModel)
+	})
+	t.Run("not found", func(t *testing.T) {
+		_, err := LensBySlug("xxx")
+		assert.Error(t, err)
+	})
+}
+
+func TestLensesBySlug(t *testing.T) {
+	results, err := LensesBySlug([]string{"lens-f-380", "xxx"})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Len(t, results, 1)
+}
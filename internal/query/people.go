@@ -0,0 +1,74 @@
+package query
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gosimple/slug"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/pkg/capture"
+)
+
+// People searches people based on their name.
+func People(f form.PersonSearch) (results []entity.Person, err error) {
+	if err := f.ParseQueryString(); err != nil {
+		return results, err
+	}
+
+	defer log.Debug(capture.Time(time.Now(), fmt.Sprintf("people: search %s", form.Serialize(f, true))))
+
+	s := Db().Where("people.photo_count > 0")
+
+	if f.ID != "" {
+		s = s.Where("people.person_uid IN (?)", strings.Split(f.ID, ","))
+	}
+
+	if f.Query != "" {
+		s = s.Where("LOWER(people.person_name) LIKE ?", "%"+strings.ToLower(f.Query)+"%")
+	}
+
+	if f.Name != "" {
+		s = s.Where("people.person_slug = ?", slug.Make(f.Name))
+	}
+
+	if f.Favorite {
+		s = s.Where("people.person_favorite = 1")
+	}
+
+	switch f.Order {
+	case "count":
+		s = s.Order("people.photo_count DESC, people.person_slug")
+	default:
+		s = s.Order("people.person_favorite DESC, people.person_slug")
+	}
+
+	if f.Count > 0 && f.Count <= 1000 {
+		s = s.Limit(f.Count).Offset(f.Offset)
+	} else {
+		s = s.Limit(100).Offset(0)
+	}
+
+	if err := s.Find(&results).Error; err != nil {
+		return results, err
+	}
+
+	return results, nil
+}
+
+// PersonByUID returns a Person based on the UID.
+func PersonByUID(personUID string) (person entity.Person, err error) {
+	if err := Db().Where("person_uid = ?", personUID).First(&person).Error; err != nil {
+		return person, err
+	}
+
+	return person, nil
+}
+
+// PeopleByUID returns people based on a list of UIDs.
+func PeopleByUID(personUIDs []string) (people []entity.Person, err error) {
+	err = Db().Where("person_uid IN (?)", personUIDs).Find(&people).Error
+
+	return people, err
+}
@@ -0,0 +1,67 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPeople(t *testing.T) {
+	t.Run("all", func(t *testing.T) {
+		results, err := People(form.PersonSearch{Count: 100})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.LessOrEqual(t, 2, len(results))
+	})
+	t.Run("query", func(t *testing.T) {
+		results, err := People(form.PersonSearch{Query: "jane", Count: 10})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, results, 1)
+		assert.Equal(t, "Jane Doe", results[0].PersonName)
+	})
+	t.Run("favorite", func(t *testing.T) {
+		results, err := People(form.PersonSearch{Query: "favorite:true", Count: 10})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, r := range results {
+			assert.True(t, r.PersonFavorite)
+		}
+	})
+}
+
+func TestPersonByUID(t *testing.T) {
+	t.Run("found", func(t *testing.T) {
+		result, err := PersonByUID("jt9k3pw1wowuy3c2")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "Jane Doe", result.PersonName)
+	})
+	t.Run("not found", func(t *testing.T) {
+		_, err := PersonByUID("jt9k3pw1wowuy000")
+		assert.Error(t, err)
+	})
+}
+
+func TestPeopleByUID(t *testing.T) {
+	results, err := PeopleByUID([]string{"jt9k3pw1wowuy3c2", "jt9k3pw1wowuy3c3"})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Len(t, results, 2)
+}
@@ -1,6 +1,8 @@
 package query
 
 import (
+	"time"
+
 	"github.com/jinzhu/gorm"
 	"github.com/photoprism/photoprism/internal/entity"
 )
@@ -18,6 +20,8 @@ func PhotoByID(photoID uint64) (photo entity.Photo, err error) {
 			return db.Order("photos_labels.uncertainty ASC, photos_labels.label_id DESC")
 		}).
 		Preload("Labels.Label").
+		Preload("People").
+		Preload("People.Person").
 		First(&photo).Error; err != nil {
 		return photo, err
 	}
@@ -38,6 +42,8 @@ func PhotoByUID(photoUID string) (photo entity.Photo, err error) {
 			return db.Order("photos_labels.uncertainty ASC, photos_labels.label_id DESC")
 		}).
 		Preload("Labels.Label").
+		Preload("People").
+		Preload("People.Person").
 		First(&photo).Error; err != nil {
 		return photo, err
 	}
@@ -52,6 +58,8 @@ func PreloadPhotoByUID(photoUID string) (photo entity.Photo, err error) {
 			return db.Order("photos_labels.uncertainty ASC, photos_labels.label_id DESC")
 		}).
 		Preload("Labels.Label").
+		Preload("People").
+		Preload("People.Person").
 		Preload("Camera").
 		Preload("Lens").
 		Preload("Links").
@@ -81,6 +89,23 @@ func MissingPhotos(limit int, offset int) (entities []entity.Photo, err error) {
 	return entities, err
 }
 
+// PhotosWithoutLocation returns photo entities without coordinates taken in a given time range.
+func PhotosWithoutLocation(from, to time.Time) (entities []entity.Photo, err error) {
+	err = Db().
+		Preload("Labels", func(db *gorm.DB) *gorm.DB {
+			return db.Order("photos_labels.uncertainty ASC, photos_labels.label_id DESC")
+		}).
+		Preload("Labels.Label").
+		Preload("Details").
+		Where("photo_lat = 0 AND photo_lng = 0").
+		Where("taken_at BETWEEN ? AND ?", from, to).
+		Where("photo_type <> ?", entity.TypeText).
+		Order("taken_at").
+		Find(&entities).Error
+
+	return entities, err
+}
+
 // ResetPhotosQuality resets the quality of photos without primary file to -1.
 func ResetPhotosQuality() error {
 	return Db().Table("photos").
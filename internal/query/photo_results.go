@@ -47,6 +47,7 @@ type PhotoResult struct {
 	LensID           uint          `json:"LensID"` // Lens
 	LensModel        string        `json:"LensModel"`
 	LensMake         string        `json:"LensMake"`
+	StackUID         string        `json:"StackUID"`
 	PlaceUID         string        `json:"PlaceUID"`
 	LocUID           string        `json:"LocUID"` // Location
 	LocLabel         string        `json:"LocLabel"`
@@ -63,6 +64,7 @@ type PhotoResult struct {
 	FilePrimary      bool          `json:"-"`
 	FileMissing      bool          `json:"-"`
 	FileVideo        bool          `json:"-"`
+	FileMotion       bool          `json:"-"`
 	FileDuration     time.Duration `json:"-"`
 	FileCodec        string        `json:"-"`
 	FileType         string        `json:"-"`
@@ -74,6 +76,7 @@ type PhotoResult struct {
 	FileChroma       uint8         `json:"-"`
 	FileLuminance    string        `json:"-"`
 	FileDiff         uint32        `json:"-"`
+	MotionHash       string        `json:"MotionHash,omitempty"`
 	Merged           bool          `json:"Merged"`
 	CreatedAt        time.Time     `json:"CreatedAt"`
 	UpdatedAt        time.Time     `json:"UpdatedAt"`
@@ -101,6 +104,10 @@ func (m PhotoResults) Merged() (PhotoResults, int, error) {
 		file.ID = res.FileID
 
 		if lastId == res.ID && i > 0 {
+			if file.FileMotion {
+				merged[i-1].MotionHash = file.FileHash
+			}
+
 			merged[i-1].Files = append(merged[i-1].Files, file)
 			merged[i-1].Merged = true
 			continue
@@ -108,6 +115,10 @@ func (m PhotoResults) Merged() (PhotoResults, int, error) {
 
 		lastId = res.ID
 
+		if file.FileMotion {
+			res.MotionHash = file.FileHash
+		}
+
 		res.Files = append(res.Files, file)
 		merged = append(merged, res)
 
@@ -136,6 +136,25 @@ func TestPhotosResults_Merged(t *testing.T) {
 	t.Log(merged)
 }
 
+func TestPhotosResults_Merged_Motion(t *testing.T) {
+	still := PhotoResult{ID: 33333, PhotoType: "live", FileID: 1, FileHash: "still", FileType: "jpg", FilePrimary: true}
+	motion := PhotoResult{ID: 33333, PhotoType: "live", FileID: 2, FileHash: "motion", FileType: "mov", FileVideo: true, FileMotion: true}
+
+	results := PhotoResults{still, motion}
+
+	merged, count, err := results.Merged()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, 2, count)
+	assert.Len(t, merged, 1)
+	assert.Equal(t, "motion", merged[0].MotionHash)
+	assert.Len(t, merged[0].Files, 2)
+	assert.True(t, merged[0].Files[1].FileMotion)
+}
+
 func TestPhotosResult_ShareFileName(t *testing.T) {
 	t.Run("with photo title", func(t *testing.T) {
 		result1 := PhotoResult{
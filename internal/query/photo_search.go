@@ -5,6 +5,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/gosimple/slug"
 	"github.com/jinzhu/gorm"
 	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/form"
@@ -30,7 +31,7 @@ func PhotoSearch(f form.PhotoSearch) (results PhotoResults, count int, err error
 		files.file_root, files.file_hash, files.file_codec, files.file_type, files.file_mime, files.file_width, 
 		files.file_height, files.file_aspect_ratio, files.file_orientation, files.file_main_color, 
 		files.file_colors, files.file_luminance, files.file_chroma,
-		files.file_diff, files.file_video, files.file_duration, files.file_size,
+		files.file_diff, files.file_video, files.file_motion, files.file_duration, files.file_size,
 		cameras.camera_make, cameras.camera_model,
 		lenses.lens_make, lenses.lens_model,
 		places.loc_label, places.loc_city, places.loc_state, places.loc_country`).
@@ -82,6 +83,19 @@ func PhotoSearch(f form.PhotoSearch) (results PhotoResults, count int, err error
 		}
 	}
 
+	// Filter by person name, slug or uid, including former names of renamed or merged people.
+	if f.Person != "" {
+		var slugs []string
+
+		values := strings.Split(f.Person, ",")
+
+		for _, v := range values {
+			slugs = append(slugs, slug.Make(v))
+		}
+
+		s = s.Where("photos.id IN (SELECT pp.photo_id FROM photos_people pp JOIN people p ON pp.person_id = p.id AND p.deleted_at IS NULL WHERE p.person_slug IN (?) OR p.person_uid IN (?) OR p.id IN (SELECT person_id FROM people_aliases WHERE alias_slug IN (?)))", slugs, values, slugs)
+	}
+
 	// Filter by location.
 	if f.Location == true {
 		s = s.Where("loc_uid <> ''")
@@ -187,6 +201,8 @@ func PhotoSearch(f form.PhotoSearch) (results PhotoResults, count int, err error
 
 	if f.Video {
 		s = s.Where("photos.photo_type = 'video'")
+	} else if f.Live {
+		s = s.Where("photos.photo_type = 'live'")
 	} else if f.Photo {
 		s = s.Where("photos.photo_type IN ('image','raw','live')")
 	}
@@ -223,10 +239,30 @@ func PhotoSearch(f form.PhotoSearch) (results PhotoResults, count int, err error
 		s = s.Where("files.file_duplicate = 1")
 	}
 
+	if f.Codec != "" {
+		s = s.Where("photos.id IN (SELECT photo_id FROM files WHERE file_video = 1 AND file_codec IN (?))", strings.Split(strings.ToLower(f.Codec), ","))
+	}
+
+	if f.Audio != "" {
+		s = s.Where("photos.id IN (SELECT photo_id FROM files WHERE file_video = 1 AND file_audio_codec IN (?))", strings.Split(strings.ToLower(f.Audio), ","))
+	}
+
+	if f.Fps > 0 {
+		s = s.Where("photos.id IN (SELECT photo_id FROM files WHERE file_video = 1 AND file_fps >= ?)", f.Fps)
+	}
+
+	if f.Hdr {
+		s = s.Where("photos.id IN (SELECT photo_id FROM files WHERE file_video = 1 AND file_hdr = 1)")
+	}
+
 	if f.Portrait {
 		s = s.Where("files.file_portrait = 1")
 	}
 
+	if f.Stack != "" {
+		s = s.Where("photos.stack_uid IN (?)", strings.Split(f.Stack, ","))
+	}
+
 	if f.Mono {
 		s = s.Where("files.file_chroma = 0")
 	} else if f.Chroma > 9 {
@@ -274,6 +310,19 @@ func PhotoSearch(f form.PhotoSearch) (results PhotoResults, count int, err error
 		s = s.Where("photos.taken_at >= ?", f.After.Format("2006-01-02"))
 	}
 
+	if f.Order == entity.SortOrderSimilar {
+		s = s.Where("files.file_diff > 0")
+	}
+
+	// Show each stack as a single photo, unless stacks=false or a stack is selected. Only photos
+	// matching all filters are considered: the cover if it matches, otherwise the first matching member.
+	if f.Stack == "" && (f.Stacks == "" || txt.Bool(f.Stacks)) {
+		covers := s.Select("photos.stack_uid").Where("photos.photo_uid IN (SELECT cover_uid FROM stacks)").SubQuery()
+		first := s.Select("MIN(photos.id)").Where("photos.stack_uid <> ''").Group("photos.stack_uid").SubQuery()
+
+		s = s.Where("photos.stack_uid = '' OR photos.photo_uid IN (SELECT cover_uid FROM stacks) OR photos.stack_uid NOT IN ? AND photos.id IN ?", covers, first)
+	}
+
 	// Set sort order for results.
 	switch f.Order {
 	case entity.SortOrderRelevance:
@@ -289,7 +338,6 @@ func PhotoSearch(f form.PhotoSearch) (results PhotoResults, count int, err error
 	case entity.SortOrderImported:
 		s = s.Order("photos.id DESC, files.file_primary DESC")
 	case entity.SortOrderSimilar:
-		s = s.Where("files.file_diff > 0")
 		s = s.Order("files.file_main_color, photos.loc_uid, files.file_diff, taken_at DESC, files.file_primary DESC")
 	case entity.SortOrderName:
 		s = s.Order("photos.photo_path, photos.photo_name, files.file_primary DESC")
@@ -1,6 +1,7 @@
 package query
 
 import (
+	"fmt"
 	"github.com/photoprism/photoprism/internal/entity"
 	"testing"
 
@@ -303,6 +304,207 @@ func TestPhotos(t *testing.T) {
 
 		assert.LessOrEqual(t, 1, len(photos))
 	})
+	t.Run("form.hdr", func(t *testing.T) {
+		var f form.PhotoSearch
+		f.Query = "hdr:true fps:50 codec:avc1 audio:aac"
+		f.Count = 10
+		f.Offset = 0
+
+		photos, _, err := PhotoSearch(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.LessOrEqual(t, 1, len(photos))
+	})
+	t.Run("form.fps", func(t *testing.T) {
+		var f form.PhotoSearch
+		f.Query = "fps:120"
+		f.Count = 10
+		f.Offset = 0
+
+		photos, _, err := PhotoSearch(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, photos, 0)
+	})
+	t.Run("form.stack", func(t *testing.T) {
+		var f form.PhotoSearch
+		f.Query = "stack:sqcwk7ew9ss5xgrv"
+		f.Count = 10
+		f.Offset = 0
+
+		photos, _, err := PhotoSearch(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.LessOrEqual(t, 1, len(photos))
+
+		for _, p := range photos {
+			assert.Equal(t, "sqcwk7ew9ss5xgrv", p.StackUID)
+		}
+	})
+	t.Run("form.stacks", func(t *testing.T) {
+		stack := entity.NewStack(entity.StackBurst)
+
+		if err := stack.Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		var photos []entity.Photo
+
+		for i, title := range []string{"Stack Search Cover", "Stack Search Member", "Stack Search Other"} {
+			photo := entity.Photo{
+				PhotoTitle: title,
+				PhotoType:  entity.TypeImage,
+				PlaceUID:   entity.UnknownPlace.PlaceUID,
+				CameraID:   entity.UnknownCamera.ID,
+				LensID:     entity.UnknownLens.ID,
+			}
+
+			if err := entity.Db().Create(&photo).Error; err != nil {
+				t.Fatal(err)
+			}
+
+			file := entity.File{
+				PhotoID:     photo.ID,
+				PhotoUID:    photo.PhotoUID,
+				FileName:    fmt.Sprintf("2020/stacks/search_%d.jpg", i),
+				FileRoot:    entity.RootOriginals,
+				FileType:    "jpg",
+				FileHash:    fmt.Sprintf("stacksearch%d", i),
+				FilePrimary: true,
+			}
+
+			if err := entity.Db().Create(&file).Error; err != nil {
+				t.Fatal(err)
+			}
+
+			photos = append(photos, photo)
+		}
+
+		if err := stack.AddPhotos(photos, photos[0]); err != nil {
+			t.Fatal(err)
+		}
+
+		search := func(query, title string) (uids []string) {
+			var f form.PhotoSearch
+			f.Query = query
+			f.Title = title
+			f.Count = 1000
+			f.Offset = 0
+
+			results, _, err := PhotoSearch(f)
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for _, r := range results {
+				if r.StackUID == stack.StackUID {
+					uids = append(uids, r.PhotoUID)
+				}
+			}
+
+			return uids
+		}
+
+		assert.Equal(t, []string{photos[0].PhotoUID}, search("", "Stack Search*"))
+		assert.ElementsMatch(t, []string{photos[0].PhotoUID, photos[1].PhotoUID, photos[2].PhotoUID}, search("stacks:false", "Stack Search*"))
+
+		// The cover doesn't match, so the first matching member represents the stack.
+		assert.Equal(t, []string{photos[1].PhotoUID}, search("", "Stack Search M*"))
+		assert.Equal(t, []string{photos[2].PhotoUID}, search("", "Stack Search O*"))
+
+		if err := entity.Db().Delete(&photos[0]).Error; err != nil {
+			t.Fatal(err)
+		}
+
+		// Archived covers don't hide the remaining members.
+		assert.Equal(t, []string{photos[1].PhotoUID}, search("", "Stack Search*"))
+		assert.Equal(t, []string{photos[0].PhotoUID}, search("archived:true", "Stack Search*"))
+	})
+	t.Run("form.person", func(t *testing.T) {
+		var f form.PhotoSearch
+		f.Query = "person:jane-doe"
+		f.Count = 10
+		f.Offset = 0
+
+		photos, _, err := PhotoSearch(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.LessOrEqual(t, 1, len(photos))
+	})
+	t.Run("form.person alias", func(t *testing.T) {
+		jane := entity.PersonFixtures.Get("jane-doe")
+
+		if err := entity.Db().Save(entity.NewPersonAlias("jane-former", jane.ID)).Error; err != nil {
+			t.Fatal(err)
+		}
+
+		var f form.PhotoSearch
+		f.Query = "person:jane-former"
+		f.Count = 10
+		f.Offset = 0
+
+		photos, _, err := PhotoSearch(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.LessOrEqual(t, 1, len(photos))
+	})
+	t.Run("form.live", func(t *testing.T) {
+		var f form.PhotoSearch
+		f.Query = "live:true"
+		f.Count = 10
+		f.Offset = 0
+
+		photos, _, err := PhotoSearch(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.GreaterOrEqual(t, len(photos), 1)
+
+		for _, p := range photos {
+			assert.Equal(t, "live", p.PhotoType)
+		}
+	})
+	t.Run("form.live merged", func(t *testing.T) {
+		var f form.PhotoSearch
+		f.Query = "live:true"
+		f.Count = 10
+		f.Offset = 0
+		f.Merged = true
+
+		photos, _, err := PhotoSearch(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		motionHash := ""
+
+		for _, p := range photos {
+			if p.PhotoUID == "pt9jtxrexxvl0yh1" {
+				motionHash = p.MotionHash
+			}
+		}
+
+		assert.Equal(t, entity.FileFixtures["IMG_0020.mov"].FileHash, motionHash)
+	})
 	t.Run("form.country", func(t *testing.T) {
 		var f form.PhotoSearch
 		f.Query = "country:zz"
@@ -2,6 +2,7 @@ package query
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -68,3 +69,27 @@ func TestResetPhotosQuality(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+func TestPhotosWithoutLocation(t *testing.T) {
+	t.Run("all", func(t *testing.T) {
+		result, err := PhotosWithoutLocation(time.Time{}, time.Now())
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, p := range result {
+			assert.Equal(t, float32(0), p.PhotoLat)
+			assert.Equal(t, float32(0), p.PhotoLng)
+		}
+	})
+	t.Run("empty range", func(t *testing.T) {
+		result, err := PhotosWithoutLocation(time.Date(1800, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(1800, 1, 2, 0, 0, 0, 0, time.UTC))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Empty(t, result)
+	})
+}
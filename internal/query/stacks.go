@@ -0,0 +1,32 @@
+package query
+
+import (
+	"time"
+
+	"github.com/photoprism/photoprism/internal/entity"
+)
+
+// PhotoPathsSince returns the distinct paths of photos added or updated since the given time.
+func PhotoPathsSince(since time.Time) (paths []string, err error) {
+	err = Db().Model(&entity.Photo{}).
+		Where("updated_at >= ?", since.Add(-time.Second)).
+		Pluck("DISTINCT photo_path", &paths).Error
+
+	return paths, err
+}
+
+// StackCandidates returns photos in the given originals folders that may belong to a burst
+// or exposure bracket, sorted by camera and capture time.
+func StackCandidates(paths []string) (photos Photos, err error) {
+	if len(paths) == 0 {
+		return photos, nil
+	}
+
+	err = Db().
+		Where("camera_id <> ? AND taken_src <> ?", entity.UnknownCamera.ID, entity.SrcAuto).
+		Where("photo_path IN (?)", paths).
+		Order("camera_id, camera_serial, taken_at, photo_sequence, photo_name").
+		Find(&photos).Error
+
+	return photos, err
+}
@@ -0,0 +1,48 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStackCandidates(t *testing.T) {
+	t.Run("folder", func(t *testing.T) {
+		photos, err := StackCandidates([]string{"2790/02"})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.NotEmpty(t, photos)
+
+		for i, photo := range photos {
+			assert.Equal(t, "2790/02", photo.PhotoPath)
+			assert.NotEqual(t, entity.UnknownCamera.ID, photo.CameraID)
+			assert.NotEqual(t, entity.SrcAuto, photo.TakenSrc)
+
+			if i > 0 && photos[i-1].CameraID == photo.CameraID && photos[i-1].CameraSerial == photo.CameraSerial {
+				assert.False(t, photo.TakenAt.Before(photos[i-1].TakenAt))
+			}
+		}
+	})
+	t.Run("folder not found", func(t *testing.T) {
+		photos, err := StackCandidates([]string{"xxx"})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Empty(t, photos)
+	})
+	t.Run("no folders", func(t *testing.T) {
+		photos, err := StackCandidates(nil)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Empty(t, photos)
+	})
+}
@@ -27,6 +27,7 @@ func registerRoutes(router *gin.Engine, conf *config.Config) {
 		api.GetThumbnail(v1, conf)
 		api.GetDownload(v1, conf)
 		api.GetVideo(v1, conf)
+		api.GetVideoStream(v1, conf)
 		api.CreateZip(v1, conf)
 		api.DownloadZip(v1, conf)
 
@@ -44,7 +45,9 @@ func registerRoutes(router *gin.Engine, conf *config.Config) {
 		api.UpdatePhotoLabel(v1, conf)
 		api.GetMomentsTime(v1, conf)
 		api.GetFile(v1, conf)
+		api.GetFileErrors(v1, conf)
 		api.LinkFile(v1, conf)
+		api.CheckFile(v1, conf)
 		api.SetPhotoPrimary(v1, conf)
 
 		api.GetLabels(v1, conf)
@@ -54,6 +57,16 @@ func registerRoutes(router *gin.Engine, conf *config.Config) {
 		api.DislikeLabel(v1, conf)
 		api.LabelThumbnail(v1, conf)
 
+		api.GetPeople(v1, conf)
+		api.UpdatePerson(v1, conf)
+		api.MergePeople(v1, conf)
+
+		api.MergeCameras(v1, conf)
+		api.MergeLenses(v1, conf)
+
+		api.GetDuplicates(v1, conf)
+		api.ResolveDuplicates(v1, conf)
+
 		api.GetFoldersOriginals(v1, conf)
 		api.GetFoldersImport(v1, conf)
 
@@ -62,10 +75,17 @@ func registerRoutes(router *gin.Engine, conf *config.Config) {
 		api.CancelImport(v1, conf)
 		api.StartIndexing(v1, conf)
 		api.CancelIndexing(v1, conf)
+		api.GetJobs(v1, conf)
+		api.GetJob(v1, conf)
+		api.CancelJob(v1, conf)
+		api.GetProgressList(v1, conf)
+		api.GetProgress(v1, conf)
+		api.Geotag(v1, conf)
 
 		api.BatchPhotosArchive(v1, conf)
 		api.BatchPhotosRestore(v1, conf)
 		api.BatchPhotosPrivate(v1, conf)
+		api.BatchPhotosTime(v1, conf)
 		api.BatchAlbumsDelete(v1, conf)
 		api.BatchLabelsDelete(v1, conf)
 
@@ -0,0 +1,19 @@
+package service
+
+import (
+	"sync"
+
+	"github.com/photoprism/photoprism/internal/photoprism"
+)
+
+var onceRename sync.Once
+
+func initRename() {
+	services.Rename = photoprism.NewRename(Config())
+}
+
+func Rename() *photoprism.Rename {
+	onceRename.Do(initRename)
+
+	return services.Rename
+}
@@ -13,16 +13,21 @@ import (
 var conf *config.Config
 
 var services struct {
-	Cache    *gc.Cache
-	Classify *classify.TensorFlow
-	Convert  *photoprism.Convert
-	Import   *photoprism.Import
-	Index    *photoprism.Index
-	Purge    *photoprism.Purge
-	Nsfw     *nsfw.Detector
-	Query    *query.Query
-	Resample *photoprism.Resample
-	Session  *session.Session
+	Cache     *gc.Cache
+	Classify  *classify.TensorFlow
+	Convert   *photoprism.Convert
+	Geotag    *photoprism.Geotag
+	Import    *photoprism.Import
+	Index     *photoprism.Index
+	Purge     *photoprism.Purge
+	Rename    *photoprism.Rename
+	Nsfw      *nsfw.Detector
+	Query     *query.Query
+	Resample  *photoprism.Resample
+	Timeshift *photoprism.Timeshift
+	Transcode *photoprism.Transcode
+	Verify    *photoprism.Verify
+	Session   *session.Session
 }
 
 func SetConfig(c *config.Config) {
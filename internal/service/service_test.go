@@ -38,6 +38,10 @@ func TestConvert(t *testing.T) {
 	assert.IsType(t, &photoprism.Convert{}, Convert())
 }
 
+func TestGeotag(t *testing.T) {
+	assert.IsType(t, &photoprism.Geotag{}, Geotag())
+}
+
 func TestImport(t *testing.T) {
 	assert.IsType(t, &photoprism.Import{}, Import())
 }
@@ -58,6 +62,10 @@ func TestQuery(t *testing.T) {
 	assert.IsType(t, &query.Query{}, Query())
 }
 
+func TestRename(t *testing.T) {
+	assert.IsType(t, &photoprism.Rename{}, Rename())
+}
+
 func TestResample(t *testing.T) {
 	assert.IsType(t, &photoprism.Resample{}, Resample())
 }
@@ -65,3 +73,15 @@ func TestResample(t *testing.T) {
 func TestSession(t *testing.T) {
 	assert.IsType(t, &session.Session{}, Session())
 }
+
+func TestTimeshift(t *testing.T) {
+	assert.IsType(t, &photoprism.Timeshift{}, Timeshift())
+}
+
+func TestTranscode(t *testing.T) {
+	assert.IsType(t, &photoprism.Transcode{}, Transcode())
+}
+
+func TestVerify(t *testing.T) {
+	assert.IsType(t, &photoprism.Verify{}, Verify())
+}
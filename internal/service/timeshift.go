@@ -0,0 +1,19 @@
+package service
+
+import (
+	"sync"
+
+	"github.com/photoprism/photoprism/internal/photoprism"
+)
+
+var onceTimeshift sync.Once
+
+func initTimeshift() {
+	services.Timeshift = photoprism.NewTimeshift(Config())
+}
+
+func Timeshift() *photoprism.Timeshift {
+	onceTimeshift.Do(initTimeshift)
+
+	return services.Timeshift
+}
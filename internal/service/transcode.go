@@ -0,0 +1,19 @@
+package service
+
+import (
+	"sync"
+
+	"github.com/photoprism/photoprism/internal/photoprism"
+)
+
+var onceTranscode sync.Once
+
+func initTranscode() {
+	services.Transcode = photoprism.NewTranscode(Config())
+}
+
+func Transcode() *photoprism.Transcode {
+	onceTranscode.Do(initTranscode)
+
+	return services.Transcode
+}
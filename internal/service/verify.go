@@ -0,0 +1,19 @@
+package service
+
+import (
+	"sync"
+
+	"github.com/photoprism/photoprism/internal/photoprism"
+)
+
+var onceVerify sync.Once
+
+func initVerify() {
+	services.Verify = photoprism.NewVerify(Config())
+}
+
+func Verify() *photoprism.Verify {
+	onceVerify.Do(initVerify)
+
+	return services.Verify
+}
@@ -4,16 +4,28 @@ import (
 	"image"
 
 	"github.com/disintegration/imaging"
+
+	_ "golang.org/x/image/bmp"
+	_ "golang.org/x/image/tiff"
+	_ "golang.org/x/image/webp"
 )
 
-func Jpeg(srcFilename, jpgFilename string) (img image.Image, err error) {
-	img, err = imaging.Open(srcFilename, imaging.AutoOrientation(true))
+// Jpeg converts an image in any format supported by the Go image decoders to JPEG.
+// The orientation is applied if greater than 1, otherwise the Exif orientation of JPEG files is used.
+func Jpeg(srcFilename, jpgFilename string, orientation int) (img image.Image, err error) {
+	if orientation > 1 {
+		img, err = imaging.Open(srcFilename)
+	} else {
+		img, err = imaging.Open(srcFilename, imaging.AutoOrientation(true))
+	}
 
 	if err != nil {
 		log.Errorf("resample: can't open %s", srcFilename)
 		return img, err
 	}
 
+	img = Rotate(img, orientation)
+
 	saveOption := imaging.JPEGQuality(JpegQuality)
 
 	if err = imaging.Save(img, jpgFilename, saveOption); err != nil {
@@ -9,7 +9,7 @@ import (
 )
 
 func TestJpeg(t *testing.T) {
-	formats := []string{"bmp", "gif", "png", "tif"}
+	formats := []string{"bmp", "gif", "png", "tif", "webp"}
 
 	for _, ext := range formats {
 		t.Run(ext, func(t *testing.T) {
@@ -18,7 +18,7 @@ func TestJpeg(t *testing.T) {
 
 			assert.NoFileExists(t, dst)
 
-			img, err := Jpeg(src, dst)
+			img, err := Jpeg(src, dst, 0)
 
 			if err != nil {
 				t.Fatal(err)
@@ -36,6 +36,22 @@ func TestJpeg(t *testing.T) {
 		})
 	}
 
+	t.Run("orientation", func(t *testing.T) {
+		src := "testdata/example.png"
+		dst := "testdata/example.png.rotated" + fs.JpegExt
+
+		img, err := Jpeg(src, dst, 6)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		defer os.Remove(dst)
+
+		bounds := img.Bounds()
+		assert.Equal(t, 67, bounds.Max.X)
+		assert.Equal(t, 100, bounds.Max.Y)
+	})
 	t.Run("foo", func(t *testing.T) {
 
 		src := "testdata/example.foo"
@@ -43,7 +59,7 @@ func TestJpeg(t *testing.T) {
 
 		assert.NoFileExists(t, dst)
 
-		img, err := Jpeg(src, dst)
+		img, err := Jpeg(src, dst, 0)
 
 		assert.NoFileExists(t, dst)
 
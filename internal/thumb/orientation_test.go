@@ -0,0 +1,32 @@
+package thumb
+
+import (
+	"image"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRotate(t *testing.T) {
+	img := image.NewNRGBA(image.Rect(0, 0, 100, 67))
+
+	t.Run("unchanged", func(t *testing.T) {
+		for _, orientation := range []int{0, 1, 9} {
+			assert.Equal(t, img, Rotate(img, orientation))
+		}
+	})
+	t.Run("flipped", func(t *testing.T) {
+		for _, orientation := range []int{2, 3, 4} {
+			bounds := Rotate(img, orientation).Bounds()
+			assert.Equal(t, 100, bounds.Dx())
+			assert.Equal(t, 67, bounds.Dy())
+		}
+	})
+	t.Run("rotated", func(t *testing.T) {
+		for _, orientation := range []int{5, 6, 7, 8} {
+			bounds := Rotate(img, orientation).Bounds()
+			assert.Equal(t, 67, bounds.Dx())
+			assert.Equal(t, 100, bounds.Dy())
+		}
+	})
+}
@@ -8,6 +8,8 @@ https://github.com/photoprism/photoprism/wiki
 package video
 
 import (
+	"strings"
+
 	"github.com/photoprism/photoprism/internal/event"
 	"github.com/photoprism/photoprism/pkg/fs"
 )
@@ -34,3 +36,108 @@ var Types = TypeMap{
 	"":    TypeMP4,
 	"mp4": TypeMP4,
 }
+
+// Codecs that common browsers can play in MP4 containers, as reported by exiftool or ffprobe.
+var Codecs = map[string]bool{
+	"avc1": true,
+	"avc3": true,
+	"h264": true,
+}
+
+// Playable returns true if common browsers can play videos with the given file type and codec without transcoding.
+func Playable(fileType fs.FileType, codec string) bool {
+	codec = strings.ToLower(codec)
+
+	switch fileType {
+	case fs.TypeMP4:
+		// The codec of MP4 files is assumed to be supported if unknown.
+		return codec == "" || Codecs[codec]
+	case fs.TypeMov:
+		return Codecs[codec]
+	default:
+		return false
+	}
+}
+
+// Content types of video files and HLS streams.
+const (
+	ContentTypeDefault  = "application/octet-stream"
+	ContentTypePlaylist = "application/vnd.apple.mpegurl"
+	ContentTypeSegment  = "video/mp2t"
+)
+
+// ContentTypes maps video file types to their content type.
+var ContentTypes = map[fs.FileType]string{
+	fs.TypeMP4: "video/mp4",
+	fs.TypeMov: "video/quicktime",
+	fs.TypeAvi: "video/x-msvideo",
+	fs.TypeMkv: "video/x-matroska",
+}
+
+// ContentType returns the content type of a video file type.
+func ContentType(fileType fs.FileType) string {
+	if result, ok := ContentTypes[fileType]; ok {
+		return result
+	}
+
+	return ContentTypeDefault
+}
+
+// Rendition represents a HLS stream variant, the size is the length of the shorter side in pixels.
+type Rendition struct {
+	Name    string
+	Size    int
+	Bitrate int
+}
+
+// Renditions contains the HLS stream variants from low to high resolution, the bitrate is in kbit/s.
+var Renditions = []Rendition{
+	{Name: "360p", Size: 360, Bitrate: 1000},
+	{Name: "720p", Size: 720, Bitrate: 3000},
+	{Name: "1080p", Size: 1080, Bitrate: 6000},
+}
+
+// Dimensions returns the width and height of the rendition for a video with the given dimensions.
+func (r Rendition) Dimensions(width, height int) (int, int) {
+	if width <= 0 || height <= 0 {
+		return r.Size * 16 / 9, r.Size
+	}
+
+	if width >= height {
+		return even(r.Size * width / height), r.Size
+	}
+
+	return r.Size, even(r.Size * height / width)
+}
+
+// even rounds a size down to the next even number as required by the encoder.
+func even(size int) int {
+	return size / 2 * 2
+}
+
+// RenditionsFor returns the renditions for a video with the given dimensions, at least the lowest resolution
+// and none with a shorter side larger than the video or a longer side larger than the size limit.
+func RenditionsFor(width, height, sizeLimit int) (result []Rendition) {
+	short := height
+
+	if width > 0 && width < height {
+		short = width
+	}
+
+	// All renditions within the size limit are used if the dimensions are unknown.
+	if short <= 0 {
+		short = sizeLimit
+	}
+
+	for _, r := range Renditions {
+		w, h := r.Dimensions(width, height)
+
+		if len(result) > 0 && (r.Size > short || w > sizeLimit || h > sizeLimit) {
+			break
+		}
+
+		result = append(result, r)
+	}
+
+	return result
+}
@@ -0,0 +1,71 @@
+package video
+
+import (
+	"testing"
+
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPlayable(t *testing.T) {
+	t.Run("mp4", func(t *testing.T) {
+		assert.True(t, Playable(fs.TypeMP4, "avc1"))
+		assert.True(t, Playable(fs.TypeMP4, "H264"))
+		assert.True(t, Playable(fs.TypeMP4, ""))
+		assert.False(t, Playable(fs.TypeMP4, "hvc1"))
+	})
+	t.Run("mov", func(t *testing.T) {
+		assert.True(t, Playable(fs.TypeMov, "avc1"))
+		assert.False(t, Playable(fs.TypeMov, "hev1"))
+		assert.False(t, Playable(fs.TypeMov, ""))
+	})
+	t.Run("other", func(t *testing.T) {
+		assert.False(t, Playable(fs.TypeAvi, "h264"))
+		assert.False(t, Playable(fs.TypeMkv, "h264"))
+	})
+}
+
+func TestContentType(t *testing.T) {
+	assert.Equal(t, "video/mp4", ContentType(fs.TypeMP4))
+	assert.Equal(t, "video/quicktime", ContentType(fs.TypeMov))
+	assert.Equal(t, "video/x-matroska", ContentType(fs.TypeMkv))
+	assert.Equal(t, ContentTypeDefault, ContentType(fs.TypeJpeg))
+}
+
+func TestRendition_Dimensions(t *testing.T) {
+	t.Run("landscape", func(t *testing.T) {
+		w, h := Renditions[1].Dimensions(3840, 2160)
+		assert.Equal(t, 1280, w)
+		assert.Equal(t, 720, h)
+	})
+	t.Run("portrait", func(t *testing.T) {
+		w, h := Renditions[0].Dimensions(1080, 1920)
+		assert.Equal(t, 360, w)
+		assert.Equal(t, 640, h)
+	})
+	t.Run("unknown", func(t *testing.T) {
+		w, h := Renditions[0].Dimensions(0, 0)
+		assert.Equal(t, 640, w)
+		assert.Equal(t, 360, h)
+	})
+}
+
+func TestRenditionsFor(t *testing.T) {
+	t.Run("4k", func(t *testing.T) {
+		result := RenditionsFor(3840, 2160, 1920)
+		assert.Len(t, result, 3)
+	})
+	t.Run("size limit", func(t *testing.T) {
+		result := RenditionsFor(3840, 2160, 1280)
+		assert.Len(t, result, 2)
+	})
+	t.Run("small", func(t *testing.T) {
+		result := RenditionsFor(320, 240, 1920)
+		assert.Len(t, result, 1)
+		assert.Equal(t, "360p", result[0].Name)
+	})
+	t.Run("unknown", func(t *testing.T) {
+		result := RenditionsFor(0, 0, 1920)
+		assert.Len(t, result, 3)
+	})
+}
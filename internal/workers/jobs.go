@@ -0,0 +1,443 @@
+package workers
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/jinzhu/gorm"
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/mutex"
+	"github.com/photoprism/photoprism/internal/photoprism"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/internal/service"
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// ConvertJobOptions contains the options of a convert job.
+type ConvertJobOptions struct {
+	Path string
+}
+
+// ResampleJobOptions contains the options of a resample job.
+type ResampleJobOptions struct {
+	Force bool
+}
+
+// TranscodeJobOptions contains the options of a transcode job.
+type TranscodeJobOptions struct {
+	Hash string
+}
+
+// Jobs represents a worker that runs queued jobs one at a time, highest priority first.
+type Jobs struct {
+	conf *config.Config
+}
+
+// NewJobs returns a new job queue worker.
+func NewJobs(conf *config.Config) *Jobs {
+	return &Jobs{conf: conf}
+}
+
+// EnqueueJob adds a job to the queue and returns it, or an identical job that is already queued.
+func EnqueueJob(jobType string, priority int, options interface{}) (*entity.Job, error) {
+	job, err := entity.NewJob(jobType, priority, options)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := job.Enqueue(); err != nil {
+		return nil, err
+	}
+
+	log.Infof("jobs: queued %s job %s", job.JobType, job.JobUID)
+
+	return job, nil
+}
+
+// StartJobs runs queued jobs in the background, unless they are already running.
+func StartJobs(conf *config.Config) {
+	if !mutex.Jobs.Busy() {
+		go func() {
+			w := NewJobs(conf)
+			if err := w.Start(); err != nil {
+				log.Errorf("jobs: %s", err)
+			}
+		}()
+	}
+}
+
+// WaitForJob runs queued jobs in the background and waits until the given job is finished
+// or the context is done, for example because the client has disconnected.
+func WaitForJob(ctx context.Context, conf *config.Config, jobUID string) (*entity.Job, error) {
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	for {
+		StartJobs(conf)
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-ticker.C:
+		}
+
+		job := entity.FindJob(jobUID)
+
+		if job == nil {
+			return nil, fmt.Errorf("job %s not found", jobUID)
+		} else if job.Finished() {
+			return job, nil
+		}
+	}
+}
+
+// Start runs queued jobs until the queue is empty. It holds the worker lock while a job
+// is running, so that other work started in the meantime, e.g. a dry import run, can't
+// interfere and jobs wait until it is done.
+func (w *Jobs) Start() error {
+	if err := mutex.Jobs.Start(); err != nil {
+		return err
+	}
+
+	defer mutex.Jobs.Stop()
+
+	for !mutex.Jobs.Canceled() {
+		if ok, err := w.next(); err != nil {
+			return err
+		} else if !ok {
+			return nil
+		}
+	}
+
+	return nil
+}
+
+// next runs the next queued job and returns false if there is nothing to do right now.
+func (w *Jobs) next() (bool, error) {
+	// The lock is held by other work, queued jobs run when the runner is started again.
+	if err := mutex.Worker.Hold(); err != nil {
+		return false, nil
+	}
+
+	defer mutex.Worker.Release()
+
+	job, err := query.NextJob()
+
+	if gorm.IsRecordNotFoundError(err) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+
+	if job.Claim() {
+		w.run(&job)
+	}
+
+	return true, nil
+}
+
+// run executes a claimed job and updates its state when done.
+func (w *Jobs) run(job *entity.Job) {
+	start := time.Now()
+	done := make(chan bool)
+
+	log.Infof("jobs: running %s job %s", job.JobType, job.JobUID)
+
+	// Cancellation may be requested by the API or another process, e.g. the cli.
+	go func() {
+		ticker := time.NewTicker(time.Second)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				if job.CancelRequested() {
+					mutex.Worker.Cancel()
+					return
+				}
+			}
+		}
+	}()
+
+	err := w.exec(job)
+
+	close(done)
+
+	if err := job.Finish(err, job.CancelRequested()); err != nil {
+		log.Errorf("jobs: %s", err)
+	}
+
+	switch job.JobStatus {
+	case entity.JobDone:
+		log.Infof("jobs: %s job %s completed in %s", job.JobType, job.JobUID, time.Since(start))
+	case entity.JobCanceled:
+		log.Infof("jobs: %s job %s canceled", job.JobType, job.JobUID)
+	case entity.JobQueued:
+		log.Warnf("jobs: %s job %s failed, will retry (%s)", job.JobType, job.JobUID, job.JobError)
+	default:
+		event.Error(fmt.Sprintf("jobs: %s job failed (%s)", job.JobType, job.JobError))
+	}
+}
+
+// exec runs the worker for the job type.
+func (w *Jobs) exec(job *entity.Job) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%s [panic]", r)
+		}
+	}()
+
+	switch job.JobType {
+	case entity.JobIndex:
+		var opt photoprism.IndexOptions
+
+		if err := job.Options(&opt); err != nil {
+			return err
+		}
+
+		return w.index(opt)
+	case entity.JobImport:
+		var opt photoprism.ImportOptions
+
+		if err := job.Options(&opt); err != nil {
+			return err
+		}
+
+		return w.imp(opt)
+	case entity.JobPurge:
+		var opt photoprism.PurgeOptions
+
+		if err := job.Options(&opt); err != nil {
+			return err
+		}
+
+		files, photos, err := service.Purge().Start(opt)
+
+		if err != nil {
+			return err
+		}
+
+		log.Infof("jobs: removed %d files and %d photos", len(files), len(photos))
+
+		return nil
+	case entity.JobConvert:
+		var opt ConvertJobOptions
+
+		if err := job.Options(&opt); err != nil {
+			return err
+		}
+
+		if opt.Path == "" {
+			opt.Path = w.conf.OriginalsPath()
+		}
+
+		return service.Convert().Start(opt.Path)
+	case entity.JobResample:
+		var opt ResampleJobOptions
+
+		if err := job.Options(&opt); err != nil {
+			return err
+		}
+
+		return service.Resample().Start(opt.Force)
+	case entity.JobTranscode:
+		var opt TranscodeJobOptions
+
+		if err := job.Options(&opt); err != nil {
+			return err
+		}
+
+		return service.Transcode().Start(opt.Hash)
+	case entity.JobGeotag:
+		var opt photoprism.GeotagOptions
+
+		if err := job.Options(&opt); err != nil {
+			return err
+		}
+
+		return w.geotag(opt)
+	case entity.JobTimeshift:
+		var opt photoprism.TimeshiftOptions
+
+		if err := job.Options(&opt); err != nil {
+			return err
+		}
+
+		return w.timeshift(opt)
+	case entity.JobRename:
+		var opt photoprism.RenameOptions
+
+		if err := job.Options(&opt); err != nil {
+			return err
+		}
+
+		renamed, err := service.Rename().Start(opt)
+
+		if err != nil {
+			return err
+		}
+
+		log.Infof("jobs: renamed %d photos", len(renamed))
+
+		return nil
+	default:
+		return fmt.Errorf("unknown job type %s", txt.Quote(job.JobType))
+	}
+}
+
+// index indexes originals and removes missing files from search results.
+func (w *Jobs) index(opt photoprism.IndexOptions) error {
+	start := time.Now()
+
+	if len(opt.Path) > 1 {
+		event.Info(fmt.Sprintf("indexing files in %s", txt.Quote(opt.Path)))
+	} else {
+		event.Info("indexing originals...")
+	}
+
+	var indexed map[string]bool
+
+	// Folders removed in the meantime, for example while watching for changes, are only purged.
+	if fs.PathExists(filepath.Join(w.conf.OriginalsPath(), opt.Path)) {
+		var err error
+
+		if indexed, err = service.Index().Start(opt); err != nil {
+			return err
+		}
+	}
+
+	prgOpt := photoprism.PurgeOptions{
+		Path:   opt.Path,
+		Ignore: indexed,
+	}
+
+	if files, photos, err := service.Purge().Start(prgOpt); err != nil {
+		return err
+	} else if len(files) > 0 || len(photos) > 0 {
+		event.Info(fmt.Sprintf("removed %d files and %d photos", len(files), len(photos)))
+	}
+
+	queueTranscode(service.Index().Transcode())
+
+	elapsed := int(time.Since(start).Seconds())
+
+	event.Success(fmt.Sprintf("indexing completed in %d s", elapsed))
+	event.Publish("index.completed", event.Data{"path": w.conf.OriginalsPath(), "seconds": elapsed})
+	event.Publish("config.updated", event.Data(w.conf.ClientConfig()))
+
+	return nil
+}
+
+// imp imports files and removes the source folder if it is an empty sub folder of the import path.
+func (w *Jobs) imp(opt photoprism.ImportOptions) error {
+	start := time.Now()
+	importPath := w.conf.ImportPath()
+
+	if opt.Move {
+		event.Info(fmt.Sprintf("moving files from %s", txt.Quote(filepath.Base(opt.Path))))
+	} else {
+		event.Info(fmt.Sprintf("copying files from %s", txt.Quote(filepath.Base(opt.Path))))
+	}
+
+	if _, err := service.Import().Start(opt); err != nil {
+		return err
+	}
+
+	if strings.HasPrefix(opt.Path, importPath+string(os.PathSeparator)) && fs.IsEmpty(opt.Path) {
+		if err := os.Remove(opt.Path); err != nil {
+			log.Errorf("import: could not delete empty folder %s: %s", txt.Quote(opt.Path), err)
+		} else {
+			log.Infof("import: deleted empty folder %s", txt.Quote(opt.Path))
+		}
+	}
+
+	queueTranscode(service.Index().Transcode())
+
+	elapsed := int(time.Since(start).Seconds())
+
+	event.Success(fmt.Sprintf("import completed in %d s", elapsed))
+	event.Publish("import.completed", event.Data{"path": opt.Path, "seconds": elapsed})
+	event.Publish("index.completed", event.Data{"path": opt.Path, "seconds": elapsed})
+	event.Publish("config.updated", event.Data(w.conf.ClientConfig()))
+
+	return nil
+}
+
+// geotag adds locations from track files to photos and updates the client config if any were found.
+func (w *Jobs) geotag(opt photoprism.GeotagOptions) error {
+	tagged, err := service.Geotag().Start(opt)
+
+	if err != nil {
+		return err
+	}
+
+	log.Infof("jobs: tagged %d photos", len(tagged))
+
+	if len(tagged) > 0 {
+		event.Publish("config.updated", event.Data(w.conf.ClientConfig()))
+	}
+
+	return nil
+}
+
+// timeshift changes the time photos were taken and publishes the updated photos.
+func (w *Jobs) timeshift(opt photoprism.TimeshiftOptions) error {
+	shifted, err := service.Timeshift().Start(opt)
+
+	if err != nil {
+		return err
+	}
+
+	log.Infof("jobs: changed time of %d photos", len(shifted))
+
+	if len(shifted) == 0 {
+		return nil
+	}
+
+	if entities, err := query.PhotoSelection(form.Selection{Photos: shifted}); err == nil {
+		event.EntitiesUpdated("photos", entities)
+	}
+
+	event.Publish("config.updated", event.Data(w.conf.ClientConfig()))
+
+	return nil
+}
+
+// queueTranscode queues transcode jobs for indexed videos browsers can't play.
+func queueTranscode(hashes []string) {
+	for _, hash := range hashes {
+		if _, err := EnqueueJob(entity.JobTranscode, entity.JobPriorityLow, TranscodeJobOptions{Hash: hash}); err != nil {
+			log.Errorf("jobs: %s", err)
+		}
+	}
+}
+
+// CancelJobs cancels queued jobs of the given type, requests cancellation of running ones
+// and returns the number of jobs found.
+func CancelJobs(jobType string) int {
+	jobs, err := query.ActiveJobs(jobType)
+
+	if err != nil {
+		log.Errorf("jobs: %s", err)
+		return 0
+	}
+
+	for _, job := range jobs {
+		if err := job.Cancel(); err != nil {
+			log.Warnf("jobs: %s", err)
+		}
+	}
+
+	return len(jobs)
+}
@@ -0,0 +1,351 @@
+package workers
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/karrick/godirwalk"
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/mutex"
+	"github.com/photoprism/photoprism/internal/photoprism"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// Watch represents a filesystem watcher that indexes changed folders in the originals path.
+type Watch struct {
+	conf    *config.Config
+	watcher *fsnotify.Watcher
+	ignore  *fs.IgnoreList
+	dirs    map[string]bool
+	pending map[string]bool
+	stop    chan bool
+	mutex   sync.Mutex
+}
+
+// NewWatch returns a new filesystem watcher.
+func NewWatch(conf *config.Config) *Watch {
+	return &Watch{
+		conf:    conf,
+		ignore:  fs.NewIgnoreList(fs.IgnoreFile, true, false),
+		dirs:    make(map[string]bool),
+		pending: make(map[string]bool),
+		stop:    make(chan bool, 1),
+	}
+}
+
+// Start watches the originals folder, or only folders flagged for watching if not enabled
+// in the config. Nothing happens if there is nothing to watch.
+func (w *Watch) Start() error {
+	roots := w.roots()
+
+	if len(roots) == 0 {
+		return nil
+	}
+
+	if err := mutex.Watch.Start(); err != nil {
+		return err
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+
+	if err != nil {
+		mutex.Watch.Stop()
+		return err
+	}
+
+	w.watcher = watcher
+
+	w.mutex.Lock()
+
+	for _, root := range roots {
+		w.addDir(root)
+	}
+
+	count := len(w.dirs)
+
+	w.mutex.Unlock()
+
+	log.Infof("watch: watching %d folders for changes", count)
+	event.Publish("watch.started", event.Data{"folders": count})
+
+	go w.run()
+
+	return nil
+}
+
+// Stop stops watching for changes.
+func (w *Watch) Stop() {
+	select {
+	case w.stop <- true:
+	default:
+	}
+}
+
+// Refresh adds folders that were flagged for watching after the watcher was started.
+func (w *Watch) Refresh() {
+	roots := w.roots()
+
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	for _, root := range roots {
+		w.addDir(root)
+	}
+}
+
+// roots returns the absolute paths of the folders to be watched.
+func (w *Watch) roots() (result []string) {
+	originalsPath := w.conf.OriginalsPath()
+
+	if w.conf.Watch() {
+		return []string{originalsPath}
+	}
+
+	folders, err := query.WatchedFolders()
+
+	if err != nil {
+		log.Errorf("watch: %s", err)
+		return result
+	}
+
+	for _, folder := range folders {
+		if dir := filepath.Join(originalsPath, folder.Path); fs.PathExists(dir) {
+			result = append(result, dir)
+		}
+	}
+
+	return result
+}
+
+// addDir watches a directory and all sub directories that are not ignored.
+func (w *Watch) addDir(dir string) {
+	err := godirwalk.Walk(dir, &godirwalk.Options{
+		Callback: func(fileName string, info *godirwalk.Dirent) error {
+			if !info.IsDir() {
+				return nil
+			}
+
+			if fileName != dir && w.ignore.Ignore(fileName) {
+				return filepath.SkipDir
+			}
+
+			// Ignore patterns apply to all sub directories.
+			_ = w.ignore.Dir(fileName)
+
+			if w.dirs[fileName] {
+				return nil
+			}
+
+			if err := w.watcher.Add(fileName); err != nil {
+				log.Warnf("watch: %s in %s", err, txt.Quote(fs.RelativeName(fileName, w.conf.OriginalsPath())))
+				return filepath.SkipDir
+			}
+
+			w.dirs[fileName] = true
+
+			return nil
+		},
+		Unsorted:            true,
+		FollowSymbolicLinks: false,
+	})
+
+	if err != nil {
+		log.Warnf("watch: %s", err)
+	}
+}
+
+// removeDir stops watching a directory and all its sub directories.
+func (w *Watch) removeDir(dir string) {
+	for name := range w.dirs {
+		if name == dir || strings.HasPrefix(name, dir+string(os.PathSeparator)) {
+			_ = w.watcher.Remove(name)
+			delete(w.dirs, name)
+		}
+	}
+}
+
+// handle adds the folder affected by a filesystem event to the pending folders and
+// returns true if it must be indexed.
+func (w *Watch) handle(ev fsnotify.Event) bool {
+	if ev.Op == fsnotify.Chmod {
+		return false
+	}
+
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	name := ev.Name
+	base := filepath.Base(name)
+	dir := filepath.Dir(name)
+
+	if base == fs.IgnoreFile {
+		if ev.Op&(fsnotify.Create|fsnotify.Write) != 0 {
+			_ = w.ignore.ConfigFile(name)
+		}
+
+		return false
+	} else if strings.HasPrefix(base, ".") || w.ignore.Ignore(name) {
+		return false
+	}
+
+	if ev.Op&fsnotify.Create != 0 {
+		if info, err := os.Stat(name); err == nil && info.IsDir() {
+			w.addDir(name)
+			dir = name
+		}
+	} else if ev.Op&(fsnotify.Remove|fsnotify.Rename) != 0 && w.dirs[name] {
+		w.removeDir(name)
+	} else if fs.GetFileType(name) == fs.TypeYaml {
+		// Sidecar files written while indexing.
+		return false
+	}
+
+	folder := fs.RelativeName(dir, w.conf.OriginalsPath())
+
+	if len(w.pending) == 0 {
+		event.Publish("watch.pending", event.Data{"folder": folder})
+	}
+
+	w.pending[folder] = true
+
+	return true
+}
+
+// run processes filesystem events until the watcher is stopped.
+func (w *Watch) run() {
+	defer func() {
+		if err := w.watcher.Close(); err != nil {
+			log.Errorf("watch: %s", err)
+		}
+
+		mutex.Watch.Stop()
+
+		log.Info("watch: stopped")
+		event.Publish("watch.stopped", event.Data{})
+	}()
+
+	delay := w.conf.WatchDelay()
+	timer := time.NewTimer(delay)
+	timer.Stop()
+
+	for {
+		select {
+		case <-w.stop:
+			return
+		case ev, ok := <-w.watcher.Events:
+			if !ok {
+				return
+			}
+
+			if w.handle(ev) {
+				debounce(timer, delay)
+			}
+		case err, ok := <-w.watcher.Errors:
+			if !ok {
+				return
+			}
+
+			log.Errorf("watch: %s", err)
+		case <-timer.C:
+			if err := w.flush(); err != nil {
+				log.Errorf("watch: %s, retrying in %s", err, delay)
+				timer.Reset(delay)
+			}
+		}
+	}
+}
+
+// debounce restarts the timer so that folders are indexed once no more changes occur.
+func debounce(timer *time.Timer, delay time.Duration) {
+	if !timer.Stop() {
+		select {
+		case <-timer.C:
+		default:
+		}
+	}
+
+	timer.Reset(delay)
+}
+
+// flush queues index jobs for pending folders and starts running them in the background.
+// Folders remain pending until their job was queued, so that they are retried otherwise.
+func (w *Watch) flush() error {
+	w.mutex.Lock()
+	pending := make(map[string]bool, len(w.pending))
+
+	for folder := range w.pending {
+		pending[folder] = true
+	}
+
+	w.mutex.Unlock()
+
+	folders := reduceFolders(pending)
+
+	if len(folders) == 0 {
+		return nil
+	}
+
+	convert := w.conf.Settings().Index.Convert && !w.conf.ReadOnly()
+
+	for _, folder := range folders {
+		log.Infof("watch: queueing %s for indexing", txt.Quote("/"+folder))
+
+		if _, err := EnqueueJob(entity.JobIndex, entity.JobPriorityDefault, photoprism.IndexOptions{Path: folder, Convert: convert}); err != nil {
+			return err
+		}
+
+		// Sub folders are indexed as well.
+		w.mutex.Lock()
+
+		for name := range pending {
+			if name == folder || folder == "" || strings.HasPrefix(name, folder+"/") {
+				delete(w.pending, name)
+			}
+		}
+
+		w.mutex.Unlock()
+	}
+
+	event.Publish("watch.indexing", event.Data{"folders": folders})
+
+	StartJobs(w.conf)
+
+	return nil
+}
+
+// reduceFolders returns the pending folders sorted by name, without sub folders of other pending folders.
+func reduceFolders(pending map[string]bool) (result []string) {
+	folders := make([]string, 0, len(pending))
+
+	for folder := range pending {
+		folders = append(folders, folder)
+	}
+
+	sort.Strings(folders)
+
+	for _, folder := range folders {
+		isSub := false
+
+		for _, parent := range result {
+			if parent == "" || strings.HasPrefix(folder, parent+"/") {
+				isSub = true
+				break
+			}
+		}
+
+		if !isSub {
+			result = append(result, folder)
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,188 @@
+package workers
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/mutex"
+	"github.com/photoprism/photoprism/internal/photoprism"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDebounce(t *testing.T) {
+	t.Run("changes", func(t *testing.T) {
+		delay := 50 * time.Millisecond
+		timer := time.NewTimer(delay)
+		timer.Stop()
+
+		// The timer must not fire as long as changes occur.
+		for i := 0; i < 5; i++ {
+			debounce(timer, delay)
+
+			select {
+			case <-timer.C:
+				t.Fatal("timer fired before changes stopped")
+			case <-time.After(20 * time.Millisecond):
+			}
+		}
+
+		select {
+		case <-timer.C:
+		case <-time.After(time.Second):
+			t.Fatal("timer did not fire")
+		}
+
+		select {
+		case <-timer.C:
+			t.Fatal("timer fired twice")
+		case <-time.After(100 * time.Millisecond):
+		}
+	})
+	t.Run("expired", func(t *testing.T) {
+		delay := 50 * time.Millisecond
+		timer := time.NewTimer(time.Millisecond)
+
+		time.Sleep(10 * time.Millisecond)
+
+		// Expired timers are drained, so that they don't fire immediately.
+		debounce(timer, delay)
+
+		select {
+		case <-timer.C:
+			t.Fatal("timer fired before delay")
+		case <-time.After(20 * time.Millisecond):
+		}
+
+		select {
+		case <-timer.C:
+		case <-time.After(time.Second):
+			t.Fatal("timer did not fire")
+		}
+	})
+}
+
+func TestReduceFolders(t *testing.T) {
+	t.Run("sub folders", func(t *testing.T) {
+		pending := map[string]bool{"2020/c": true, "2020": true, "2020/b/a": true, "2019": true, "20201": true}
+		assert.Equal(t, []string{"2019", "2020", "20201"}, reduceFolders(pending))
+	})
+	t.Run("root", func(t *testing.T) {
+		pending := map[string]bool{"2020": true, "": true}
+		assert.Equal(t, []string{""}, reduceFolders(pending))
+	})
+	t.Run("empty", func(t *testing.T) {
+		assert.Empty(t, reduceFolders(map[string]bool{}))
+	})
+}
+
+func TestWatch_handle(t *testing.T) {
+	conf := config.TestConfig()
+	dir := filepath.Join(conf.OriginalsPath(), "watch-handle")
+
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	w := NewWatch(conf)
+
+	watcher, err := fsnotify.NewWatcher()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer watcher.Close()
+
+	w.watcher = watcher
+
+	t.Run("chmod", func(t *testing.T) {
+		assert.False(t, w.handle(fsnotify.Event{Name: filepath.Join(dir, "photo.jpg"), Op: fsnotify.Chmod}))
+	})
+	t.Run("hidden", func(t *testing.T) {
+		assert.False(t, w.handle(fsnotify.Event{Name: filepath.Join(dir, ".photo.jpg"), Op: fsnotify.Write}))
+	})
+	t.Run("sidecar", func(t *testing.T) {
+		assert.False(t, w.handle(fsnotify.Event{Name: filepath.Join(dir, "photo.yml"), Op: fsnotify.Write}))
+	})
+	t.Run("file", func(t *testing.T) {
+		assert.True(t, w.handle(fsnotify.Event{Name: filepath.Join(dir, "photo.jpg"), Op: fsnotify.Write}))
+		assert.True(t, w.pending["watch-handle"])
+	})
+	t.Run("folder", func(t *testing.T) {
+		sub := filepath.Join(dir, "sub")
+
+		if err := os.Mkdir(sub, os.ModePerm); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.True(t, w.handle(fsnotify.Event{Name: sub, Op: fsnotify.Create}))
+		assert.True(t, w.pending["watch-handle/sub"])
+		assert.True(t, w.dirs[sub])
+
+		assert.True(t, w.handle(fsnotify.Event{Name: sub, Op: fsnotify.Remove}))
+		assert.False(t, w.dirs[sub])
+	})
+}
+
+func TestWatch_flush(t *testing.T) {
+	conf := config.TestConfig()
+
+	// Queued jobs must not be started while testing.
+	if err := mutex.Jobs.Start(); err != nil {
+		t.Fatal(err)
+	}
+
+	defer mutex.Jobs.Stop()
+
+	CancelJobs(entity.JobIndex)
+
+	defer CancelJobs(entity.JobIndex)
+
+	w := NewWatch(conf)
+
+	t.Run("nothing pending", func(t *testing.T) {
+		assert.NoError(t, w.flush())
+
+		jobs, err := query.ActiveJobs(entity.JobIndex)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Empty(t, jobs)
+	})
+	t.Run("pending folders", func(t *testing.T) {
+		w.pending = map[string]bool{"watch-flush/a": true, "watch-flush/a/b": true, "watch-flush/c": true}
+
+		assert.NoError(t, w.flush())
+		assert.Empty(t, w.pending)
+
+		jobs, err := query.ActiveJobs(entity.JobIndex)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var paths []string
+
+		for _, job := range jobs {
+			var opt photoprism.IndexOptions
+
+			if err := job.Options(&opt); err != nil {
+				t.Fatal(err)
+			}
+
+			paths = append(paths, opt.Path)
+		}
+
+		assert.Equal(t, []string{"watch-flush/a", "watch-flush/c"}, paths)
+	})
+}
@@ -4,17 +4,28 @@ import (
 	"time"
 
 	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/event"
 	"github.com/photoprism/photoprism/internal/mutex"
+	"github.com/photoprism/photoprism/internal/photoprism"
+	"github.com/photoprism/photoprism/internal/service"
 )
 
 var log = event.Log
 var stop = make(chan bool, 1)
+var watch *Watch
 
 // Start runs PhotoPrism background workers every wakeup interval.
 func Start(conf *config.Config) {
 	ticker := time.NewTicker(conf.WakeupInterval())
 
+	if err := entity.RequeueRunningJobs(); err != nil {
+		log.Errorf("jobs: %s", err)
+	}
+
+	StartJobs(conf)
+	StartWatch(conf)
+
 	go func() {
 		for {
 			select {
@@ -23,10 +34,16 @@ func Start(conf *config.Config) {
 				ticker.Stop()
 				mutex.Share.Cancel()
 				mutex.Sync.Cancel()
+				mutex.Verify.Cancel()
+				mutex.Jobs.Cancel()
+				StopWatch()
 				return
 			case <-ticker.C:
+				StartJobs(conf)
 				StartShare(conf)
 				StartSync(conf)
+				StartWatch(conf)
+				StartVerify(conf)
 			}
 		}
 	}()
@@ -60,3 +77,48 @@ func StartSync(conf *config.Config) {
 		}()
 	}
 }
+
+// StartVerify verifies originals against their stored hashes once, if a budget is configured.
+func StartVerify(conf *config.Config) {
+	if conf.VerifyBudget() <= 0 || mutex.Verify.Busy() {
+		return
+	}
+
+	go func() {
+		result, err := service.Verify().Start(photoprism.VerifyOptionsConfig(conf))
+
+		if err != nil {
+			log.Error(err)
+		} else if result.Files > 0 {
+			log.Infof("verify: %d files verified, %d mismatches", result.Files, result.Mismatches)
+		}
+	}()
+}
+
+// StartWatch starts the filesystem watcher if there are folders to watch,
+// or adds folders flagged for watching if it is already running.
+func StartWatch(conf *config.Config) {
+	if mutex.Watch.Busy() {
+		if watch != nil {
+			watch.Refresh()
+		}
+
+		return
+	}
+
+	w := NewWatch(conf)
+
+	if err := w.Start(); err != nil {
+		log.Errorf("watch: %s", err)
+	} else if mutex.Watch.Busy() {
+		watch = w
+	}
+}
+
+// StopWatch stops the filesystem watcher.
+func StopWatch() {
+	if watch != nil {
+		watch.Stop()
+		watch = nil
+	}
+}
@@ -0,0 +1,287 @@
+package fs
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ArchiveMaxFiles is the maximum number of files extracted from a single archive.
+var ArchiveMaxFiles = 50000
+
+// ArchiveMaxSize is the maximum total size of files extracted from a single archive in bytes.
+var ArchiveMaxSize int64 = 32 * 1024 * 1024 * 1024
+
+// ArchiveMaxRatio is the maximum ratio between extracted and archive size, higher ratios indicate zip bombs.
+var ArchiveMaxRatio int64 = 100
+
+// archiveMinRatioSize is the extracted size in bytes from which the ratio is checked.
+const archiveMinRatioSize = 10 * 1024 * 1024
+
+// ArchiveExt lists the supported archive file extensions.
+var ArchiveExt = []string{".zip", ".tar", ".tar.gz", ".tgz"}
+
+// IsArchive returns true if the file name has a supported archive extension.
+func IsArchive(fileName string) bool {
+	name := strings.ToLower(fileName)
+
+	for _, ext := range ArchiveExt {
+		if strings.HasSuffix(name, ext) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Extract extracts a zip, tar or tar.gz archive to the destination directory and returns the
+// names of all extracted files. Entries outside the destination, links and archives exceeding
+// the configured limits are rejected.
+func Extract(src, dest string) (fileNames []string, err error) {
+	name := strings.ToLower(src)
+
+	switch {
+	case strings.HasSuffix(name, ".zip"):
+		return Unzip(src, dest)
+	case strings.HasSuffix(name, ".tar"):
+		return Untar(src, dest, false)
+	case strings.HasSuffix(name, ".tar.gz"), strings.HasSuffix(name, ".tgz"):
+		return Untar(src, dest, true)
+	default:
+		return fileNames, fmt.Errorf("archive: unsupported file type %s", filepath.Base(src))
+	}
+}
+
+// Unzip extracts a zip archive to the destination directory.
+func Unzip(src, dest string) (fileNames []string, err error) {
+	r, err := zip.OpenReader(src)
+
+	if err != nil {
+		return fileNames, err
+	}
+
+	defer r.Close()
+
+	x, err := newArchiveExtractor(src, dest)
+
+	if err != nil {
+		return fileNames, err
+	}
+
+	for _, f := range r.File {
+		if f.FileInfo().IsDir() {
+			if err := x.dir(f.Name); err != nil {
+				return fileNames, err
+			}
+
+			continue
+		}
+
+		if !f.Mode().IsRegular() {
+			continue
+		}
+
+		rc, err := f.Open()
+
+		if err != nil {
+			return fileNames, err
+		}
+
+		fileName, err := x.file(f.Name, rc)
+
+		rc.Close()
+
+		if err != nil {
+			return fileNames, err
+		} else if fileName != "" {
+			fileNames = append(fileNames, fileName)
+		}
+	}
+
+	return fileNames, nil
+}
+
+// Untar extracts an optionally gzip compressed tar archive to the destination directory.
+func Untar(src, dest string, compressed bool) (fileNames []string, err error) {
+	f, err := os.Open(src)
+
+	if err != nil {
+		return fileNames, err
+	}
+
+	defer f.Close()
+
+	var r io.Reader = f
+
+	if compressed {
+		gz, err := gzip.NewReader(f)
+
+		if err != nil {
+			return fileNames, err
+		}
+
+		defer gz.Close()
+
+		r = gz
+	}
+
+	x, err := newArchiveExtractor(src, dest)
+
+	if err != nil {
+		return fileNames, err
+	}
+
+	tr := tar.NewReader(r)
+
+	for {
+		header, err := tr.Next()
+
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return fileNames, err
+		}
+
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if err := x.dir(header.Name); err != nil {
+				return fileNames, err
+			}
+		case tar.TypeReg, tar.TypeRegA:
+			if fileName, err := x.file(header.Name, tr); err != nil {
+				return fileNames, err
+			} else if fileName != "" {
+				fileNames = append(fileNames, fileName)
+			}
+		}
+	}
+
+	return fileNames, nil
+}
+
+// archiveExtractor writes archive entries to a destination directory and enforces limits.
+type archiveExtractor struct {
+	dest     string
+	maxSize  int64
+	maxFiles int
+	size     int64
+	files    int
+}
+
+func newArchiveExtractor(src, dest string) (*archiveExtractor, error) {
+	info, err := os.Stat(src)
+
+	if err != nil {
+		return nil, err
+	}
+
+	dest, err = filepath.Abs(dest)
+
+	if err != nil {
+		return nil, err
+	}
+
+	maxSize := ArchiveMaxSize
+	ratioSize := info.Size() * ArchiveMaxRatio
+
+	if ratioSize < archiveMinRatioSize {
+		ratioSize = archiveMinRatioSize
+	}
+
+	if ratioSize < maxSize {
+		maxSize = ratioSize
+	}
+
+	return &archiveExtractor{dest: dest, maxSize: maxSize, maxFiles: ArchiveMaxFiles}, nil
+}
+
+// path returns the absolute destination path of an entry, or an error if it would be outside the destination.
+func (x *archiveExtractor) path(name string) (string, error) {
+	name = strings.ReplaceAll(name, "\\", "/")
+
+	if strings.HasPrefix(name, "/") || filepath.IsAbs(name) || filepath.VolumeName(name) != "" {
+		return "", fmt.Errorf("archive: invalid absolute path %s", name)
+	}
+
+	for _, segment := range strings.Split(name, "/") {
+		if segment == ".." {
+			return "", fmt.Errorf("archive: invalid relative path %s", name)
+		}
+	}
+
+	result := filepath.Join(x.dest, filepath.FromSlash(name))
+
+	if result != x.dest && !strings.HasPrefix(result, x.dest+string(os.PathSeparator)) {
+		return "", fmt.Errorf("archive: invalid path %s", name)
+	}
+
+	return result, nil
+}
+
+// skip returns true for entries like __MACOSX that don't contain media files.
+func (x *archiveExtractor) skip(name string) bool {
+	return strings.HasPrefix(name, "__")
+}
+
+// dir creates a directory entry.
+func (x *archiveExtractor) dir(name string) error {
+	if x.skip(name) {
+		return nil
+	}
+
+	dirName, err := x.path(name)
+
+	if err != nil {
+		return err
+	}
+
+	return os.MkdirAll(dirName, os.ModePerm)
+}
+
+// file writes a file entry and returns its name, or an empty string if it was skipped.
+func (x *archiveExtractor) file(name string, r io.Reader) (string, error) {
+	if x.skip(name) {
+		return "", nil
+	}
+
+	fileName, err := x.path(name)
+
+	if err != nil {
+		return "", err
+	}
+
+	if x.files++; x.files > x.maxFiles {
+		return "", fmt.Errorf("archive: contains more than %d files", x.maxFiles)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(fileName), os.ModePerm); err != nil {
+		return "", err
+	}
+
+	f, err := os.OpenFile(fileName, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0666)
+
+	if err != nil {
+		return "", err
+	}
+
+	defer f.Close()
+
+	remaining := x.maxSize - x.size
+
+	n, err := io.Copy(f, io.LimitReader(r, remaining+1))
+
+	x.size += n
+
+	if err != nil {
+		return "", err
+	} else if x.size > x.maxSize {
+		return "", fmt.Errorf("archive: extracted size exceeds %d bytes", x.maxSize)
+	}
+
+	return fileName, nil
+}
@@ -0,0 +1,200 @@
+package fs
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type archiveEntry struct {
+	Name string
+	Data []byte
+}
+
+func createTestZip(t *testing.T, fileName string, entries []archiveEntry) {
+	f, err := os.Create(fileName)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer f.Close()
+
+	w := zip.NewWriter(f)
+
+	for _, e := range entries {
+		fw, err := w.CreateHeader(&zip.FileHeader{Name: e.Name, Method: zip.Deflate})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := fw.Write(e.Data); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func createTestTarGz(t *testing.T, fileName string, entries []archiveEntry) {
+	f, err := os.Create(fileName)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer f.Close()
+
+	gz := gzip.NewWriter(f)
+	w := tar.NewWriter(gz)
+
+	for _, e := range entries {
+		if err := w.WriteHeader(&tar.Header{Name: e.Name, Mode: 0644, Size: int64(len(e.Data)), Typeflag: tar.TypeReg}); err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := w.Write(e.Data); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := w.WriteHeader(&tar.Header{Name: "link.jpg", Linkname: "/etc/passwd", Typeflag: tar.TypeSymlink}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := gz.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestIsArchive(t *testing.T) {
+	assert.True(t, IsArchive("/import/photos.zip"))
+	assert.True(t, IsArchive("/import/photos.ZIP"))
+	assert.True(t, IsArchive("/import/photos.tar"))
+	assert.True(t, IsArchive("/import/photos.tar.gz"))
+	assert.True(t, IsArchive("/import/photos.tgz"))
+	assert.False(t, IsArchive("/import/photo.jpg"))
+	assert.False(t, IsArchive("/import/photos.gz"))
+}
+
+func TestExtract(t *testing.T) {
+	dir, err := ioutil.TempDir("", "archive")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	t.Run("zip", func(t *testing.T) {
+		src := filepath.Join(dir, "photos.zip")
+		dest := filepath.Join(dir, "zip")
+
+		createTestZip(t, src, []archiveEntry{
+			{Name: "2020/photo.jpg", Data: []byte("jpeg")},
+			{Name: "__MACOSX/2020/._photo.jpg", Data: []byte("mac")},
+		})
+
+		fileNames, err := Extract(src, dest)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, []string{filepath.Join(dest, "2020/photo.jpg")}, fileNames)
+		assert.True(t, FileExists(filepath.Join(dest, "2020/photo.jpg")))
+		assert.False(t, PathExists(filepath.Join(dest, "__MACOSX")))
+	})
+	t.Run("tar.gz", func(t *testing.T) {
+		src := filepath.Join(dir, "photos.tar.gz")
+		dest := filepath.Join(dir, "tar")
+
+		createTestTarGz(t, src, []archiveEntry{
+			{Name: "photo.jpg", Data: []byte("jpeg")},
+			{Name: "raw/photo.cr2", Data: []byte("raw")},
+		})
+
+		fileNames, err := Extract(src, dest)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, fileNames, 2)
+		assert.True(t, FileExists(filepath.Join(dest, "raw/photo.cr2")))
+		assert.False(t, FileExists(filepath.Join(dest, "link.jpg")))
+	})
+	t.Run("path traversal", func(t *testing.T) {
+		src := filepath.Join(dir, "traversal.zip")
+		dest := filepath.Join(dir, "traversal")
+
+		createTestZip(t, src, []archiveEntry{
+			{Name: "../../evil.jpg", Data: []byte("evil")},
+		})
+
+		_, err := Extract(src, dest)
+
+		assert.EqualError(t, err, "archive: invalid relative path ../../evil.jpg")
+		assert.False(t, FileExists(filepath.Join(dir, "evil.jpg")))
+	})
+	t.Run("absolute path", func(t *testing.T) {
+		src := filepath.Join(dir, "absolute.tar.gz")
+		dest := filepath.Join(dir, "absolute")
+
+		createTestTarGz(t, src, []archiveEntry{
+			{Name: "/tmp/evil.jpg", Data: []byte("evil")},
+		})
+
+		_, err := Extract(src, dest)
+
+		assert.EqualError(t, err, "archive: invalid absolute path /tmp/evil.jpg")
+	})
+	t.Run("max files", func(t *testing.T) {
+		src := filepath.Join(dir, "files.zip")
+		dest := filepath.Join(dir, "files")
+
+		createTestZip(t, src, []archiveEntry{
+			{Name: "a.jpg", Data: []byte("a")},
+			{Name: "b.jpg", Data: []byte("b")},
+		})
+
+		maxFiles := ArchiveMaxFiles
+		ArchiveMaxFiles = 1
+		defer func() { ArchiveMaxFiles = maxFiles }()
+
+		_, err := Extract(src, dest)
+
+		assert.EqualError(t, err, "archive: contains more than 1 files")
+	})
+	t.Run("zip bomb", func(t *testing.T) {
+		src := filepath.Join(dir, "bomb.zip")
+		dest := filepath.Join(dir, "bomb")
+
+		createTestZip(t, src, []archiveEntry{
+			{Name: "zeros.jpg", Data: bytes.Repeat([]byte{0}, 2*archiveMinRatioSize)},
+		})
+
+		_, err := Extract(src, dest)
+
+		assert.EqualError(t, err, "archive: extracted size exceeds 10485760 bytes")
+	})
+	t.Run("unsupported", func(t *testing.T) {
+		_, err := Extract(filepath.Join(dir, "photos.rar"), dir)
+
+		assert.EqualError(t, err, "archive: unsupported file type photos.rar")
+	})
+}
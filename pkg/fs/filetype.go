@@ -17,11 +17,13 @@ const (
 	TypeGif      FileType = "gif"  // GIF image file.
 	TypeTiff     FileType = "tiff" // TIFF image file.
 	TypeBitmap   FileType = "bmp"  // BMP image file.
+	TypeWebP     FileType = "webp" // WebP image file.
 	TypeRaw      FileType = "raw"  // RAW image file.
 	TypeHEIF     FileType = "heif" // High Efficiency Image File Format
 	TypeMov      FileType = "mov"  // Video files.
 	TypeMP4      FileType = "mp4"
 	TypeAvi      FileType = "avi"
+	TypeMkv      FileType = "mkv"
 	TypeXMP      FileType = "xmp"  // Adobe XMP sidecar file (XML).
 	TypeAAE      FileType = "aae"  // Apple sidecar file (XML).
 	TypeXML      FileType = "xml"  // XML metadata / config / sidecar file.
@@ -39,6 +41,7 @@ type TypeExtensions map[FileType][]string
 const (
 	YamlExt = ".yml"
 	JpegExt = ".jpg"
+	JsonExt = ".json"
 )
 
 // FileExt contains the filename extensions of file formats known to PhotoPrism.
@@ -48,6 +51,7 @@ var FileExt = FileExtensions{
 	".tif":  TypeTiff,
 	".tiff": TypeTiff,
 	".png":  TypePng,
+	".webp": TypeWebP,
 	".crw":  TypeRaw,
 	".cr2":  TypeRaw,
 	".nef":  TypeRaw,
@@ -56,6 +60,7 @@ var FileExt = FileExtensions{
 	".mov":  TypeMov,
 	".avi":  TypeAvi,
 	".mp4":  TypeMP4,
+	".mkv":  TypeMkv,
 	".yml":  TypeYaml,
 	".yaml": TypeYaml,
 	".jpg":  TypeJpeg,
@@ -17,6 +17,17 @@ func TestGetFileType(t *testing.T) {
 		assert.Equal(t, TypeRaw, result)
 	})
 
+	t.Run("webp", func(t *testing.T) {
+		result := GetFileType("testdata/test.WEBP")
+		assert.Equal(t, TypeWebP, result)
+	})
+
+	t.Run("mkv", func(t *testing.T) {
+		result := GetFileType("testdata/test.mkv")
+		assert.Equal(t, TypeMkv, result)
+		assert.Equal(t, MediaVideo, GetMediaType("testdata/test.mkv"))
+	})
+
 	t.Run("empty", func(t *testing.T) {
 		result := GetFileType("")
 		assert.Equal(t, TypeOther, result)
@@ -17,10 +17,12 @@ var MediaTypes = map[FileType]MediaType{
 	TypeGif:      MediaImage,
 	TypeTiff:     MediaImage,
 	TypeBitmap:   MediaImage,
+	TypeWebP:     MediaImage,
 	TypeHEIF:     MediaImage,
 	TypeAvi:      MediaVideo,
 	TypeMP4:      MediaVideo,
 	TypeMov:      MediaVideo,
+	TypeMkv:      MediaVideo,
 	TypeXMP:      MediaSidecar,
 	TypeXML:      MediaSidecar,
 	TypeAAE:      MediaSidecar,
@@ -4,7 +4,6 @@ import (
 	"archive/zip"
 	"io"
 	"os"
-	"strings"
 )
 
 // ZipFiles compresses one or many files into a single zip archive file.
@@ -60,29 +59,3 @@ func AddToZip(zipWriter *zip.Writer, filename string) error {
 	_, err = io.Copy(writer, fileToZip)
 	return err
 }
-
-// Extract Zip file in destination directory
-func Unzip(src, dest string) (fileNames []string, err error) {
-	r, err := zip.OpenReader(src)
-	if err != nil {
-		return fileNames, err
-	}
-
-	defer r.Close()
-
-	for _, f := range r.File {
-		// Skip directories like __OSX
-		if strings.HasPrefix(f.Name, "__") {
-			continue
-		}
-
-		fn, err := copyToFile(f, dest)
-		if err != nil {
-			return fileNames, err
-		}
-
-		fileNames = append(fileNames, fn)
-	}
-
-	return fileNames, nil
-}
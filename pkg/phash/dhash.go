@@ -0,0 +1,36 @@
+package phash
+
+import (
+	"image"
+	"image/color"
+
+	"github.com/disintegration/imaging"
+)
+
+// DHash returns the difference hash of an image, which compares the brightness
+// of adjacent pixels in a downscaled grayscale version of the image. It is not
+// affected by resizing, recompression, or small changes in color and brightness.
+func DHash(img image.Image) Hash {
+	small := imaging.Resize(img, 9, 8, imaging.Box)
+
+	var h Hash
+
+	for y := 0; y < 8; y++ {
+		for x := 0; x < 8; x++ {
+			h <<= 1
+
+			if luminance(small.At(x, y)) > luminance(small.At(x+1, y)) {
+				h |= 1
+			}
+		}
+	}
+
+	return h
+}
+
+// luminance returns the relative luminance of a color.
+func luminance(c color.Color) uint32 {
+	r, g, b, _ := c.RGBA()
+
+	return (299*r + 587*g + 114*b) / 1000
+}
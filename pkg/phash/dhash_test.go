@@ -0,0 +1,41 @@
+package phash
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func gradient(width, height int, reverse bool) image.Image {
+	img := image.NewGray(image.Rect(0, 0, width, height))
+
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			v := uint8(x * 255 / (width - 1))
+
+			if reverse {
+				v = 255 - v
+			}
+
+			img.SetGray(x, y, color.Gray{Y: v})
+		}
+	}
+
+	return img
+}
+
+func TestDHash(t *testing.T) {
+	t.Run("brighter to the right", func(t *testing.T) {
+		assert.Equal(t, Hash(0), DHash(gradient(256, 128, false)))
+	})
+	t.Run("brighter to the left", func(t *testing.T) {
+		assert.Equal(t, Hash(^uint64(0)), DHash(gradient(256, 128, true)))
+	})
+	t.Run("resized", func(t *testing.T) {
+		a := DHash(gradient(256, 128, true))
+		b := DHash(gradient(1024, 512, true))
+		assert.Equal(t, 0, a.Distance(b))
+	})
+}
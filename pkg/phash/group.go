@@ -0,0 +1,110 @@
+package phash
+
+// GroupBucketLimit is the maximum number of different hashes compared with each other because they
+// share a block. Larger buckets, e.g. of featureless images, are skipped to keep grouping fast, so
+// their hashes are only grouped if other blocks match as well.
+var GroupBucketLimit = 1000
+
+// Group returns the indexes of hashes that are within the given distance, transitively.
+// Hashes without similar hashes are omitted. Candidates are found by splitting hashes
+// into distance+1 blocks: two hashes within the distance must have one identical block.
+// Identical hashes are grouped without comparing them.
+func Group(hashes []Hash, distance int) [][]int {
+	if distance < 0 || distance > 63 {
+		return nil
+	}
+
+	parent := make([]int, len(hashes))
+
+	for i := range parent {
+		parent[i] = i
+	}
+
+	find := func(i int) int {
+		for parent[i] != i {
+			parent[i] = parent[parent[i]]
+			i = parent[i]
+		}
+
+		return i
+	}
+
+	first := make(map[Hash]int, len(hashes))
+	var unique []int
+
+	for i, h := range hashes {
+		if j, ok := first[h]; ok {
+			parent[find(i)] = find(j)
+			continue
+		}
+
+		first[h] = i
+		unique = append(unique, i)
+	}
+
+	blocks := distance + 1
+	width := 64 / blocks
+
+	for b := 0; b < blocks; b++ {
+		shift := uint(b * width)
+		size := width
+
+		if b == blocks-1 {
+			size = 64 - b*width
+		}
+
+		mask := uint64(1)<<uint(size) - 1
+
+		if size == 64 {
+			mask = ^uint64(0)
+		}
+
+		buckets := make(map[uint64][]int)
+
+		for _, i := range unique {
+			key := (uint64(hashes[i]) >> shift) & mask
+			buckets[key] = append(buckets[key], i)
+		}
+
+		for _, bucket := range buckets {
+			if len(bucket) > GroupBucketLimit {
+				continue
+			}
+
+			for i := 0; i < len(bucket); i++ {
+				for j := i + 1; j < len(bucket); j++ {
+					x, y := bucket[i], bucket[j]
+
+					if find(x) == find(y) || hashes[x].Distance(hashes[y]) > distance {
+						continue
+					}
+
+					parent[find(x)] = find(y)
+				}
+			}
+		}
+	}
+
+	groups := make(map[int][]int)
+	var roots []int
+
+	for i := range hashes {
+		r := find(i)
+
+		if _, ok := groups[r]; !ok {
+			roots = append(roots, r)
+		}
+
+		groups[r] = append(groups[r], i)
+	}
+
+	var result [][]int
+
+	for _, r := range roots {
+		if len(groups[r]) > 1 {
+			result = append(result, groups[r])
+		}
+	}
+
+	return result
+}
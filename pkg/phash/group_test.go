@@ -0,0 +1,58 @@
+package phash
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGroup(t *testing.T) {
+	hashes := []Hash{
+		0x0000000000000000,
+		0xff00ff00ff00ff00,
+		0x0000000000000003,
+		0xff00ff00ff00ff01,
+		0x00ff00ff00ff00ff,
+		0x000000000000000f,
+	}
+
+	t.Run("exact", func(t *testing.T) {
+		assert.Empty(t, Group(hashes, 0))
+	})
+	t.Run("distance 2", func(t *testing.T) {
+		assert.Equal(t, [][]int{{0, 2, 5}, {1, 3}}, Group(hashes, 2))
+	})
+	t.Run("distance 1", func(t *testing.T) {
+		assert.Equal(t, [][]int{{1, 3}}, Group(hashes, 1))
+	})
+	t.Run("invalid distance", func(t *testing.T) {
+		assert.Nil(t, Group(hashes, -1))
+	})
+	t.Run("identical", func(t *testing.T) {
+		identical := make([]Hash, 5000)
+
+		for i := range identical {
+			identical[i] = 0xff00ff00ff00ff00
+		}
+
+		groups := Group(identical, 4)
+
+		assert.Len(t, groups, 1)
+		assert.Len(t, groups[0], 5000)
+	})
+	t.Run("bucket limit", func(t *testing.T) {
+		limit := GroupBucketLimit
+		GroupBucketLimit = 2
+
+		defer func() { GroupBucketLimit = limit }()
+
+		// The last two hashes only share the upper block with the first one, which is skipped.
+		similar := []Hash{0x0000000000000001, 0x0000000100000001, 0x00000000f0000000, 0x00000000f1000000}
+
+		assert.Equal(t, [][]int{{0, 1}}, Group(similar, 1))
+
+		GroupBucketLimit = limit
+
+		assert.Equal(t, [][]int{{0, 1}, {2, 3}}, Group(similar, 1))
+	})
+}
@@ -0,0 +1,42 @@
+/*
+Package phash provides perceptual image hashes to find visually similar images.
+
+Additional information can be found in our Developer Guide:
+
+https://github.com/photoprism/photoprism/wiki
+*/
+package phash
+
+import (
+	"fmt"
+	"math/bits"
+	"strconv"
+)
+
+// Hash represents a 64 bit perceptual image hash.
+type Hash uint64
+
+// Hex returns the hash as fixed-length hex string.
+func (h Hash) Hex() string {
+	return fmt.Sprintf("%016x", uint64(h))
+}
+
+// Distance returns the number of bits that differ between two hashes.
+func (h Hash) Distance(other Hash) int {
+	return bits.OnesCount64(uint64(h ^ other))
+}
+
+// FromHex parses a hash string as returned by Hex.
+func FromHex(s string) (Hash, error) {
+	if len(s) != 16 {
+		return 0, fmt.Errorf("phash: invalid hash %q", s)
+	}
+
+	u, err := strconv.ParseUint(s, 16, 64)
+
+	if err != nil {
+		return 0, fmt.Errorf("phash: invalid hash %q", s)
+	}
+
+	return Hash(u), nil
+}
@@ -0,0 +1,39 @@
+package phash
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHash_Hex(t *testing.T) {
+	assert.Equal(t, "0000000000000000", Hash(0).Hex())
+	assert.Equal(t, "00000000000000ff", Hash(255).Hex())
+	assert.Equal(t, "ffffffffffffffff", Hash(^uint64(0)).Hex())
+}
+
+func TestHash_Distance(t *testing.T) {
+	assert.Equal(t, 0, Hash(0xf0).Distance(0xf0))
+	assert.Equal(t, 4, Hash(0xf0).Distance(0xff))
+	assert.Equal(t, 64, Hash(0).Distance(Hash(^uint64(0))))
+}
+
+func TestFromHex(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		h, err := FromHex("00000000000000ff")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, Hash(255), h)
+	})
+	t.Run("too short", func(t *testing.T) {
+		_, err := FromHex("ff")
+		assert.Error(t, err)
+	})
+	t.Run("invalid", func(t *testing.T) {
+		_, err := FromHex("zz000000000000ff")
+		assert.Error(t, err)
+	})
+}